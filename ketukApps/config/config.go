@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -19,6 +20,13 @@ type Config struct {
 	Queue     QueueConfig
 	Schedule  ScheduleConfig
 	SMTPGmail SMTPGmailConfig
+	Notify    NotifyConfig
+
+	// PublicURL is the scheme and host clients reach the API at, used for
+	// links such as calendar feed URLs. Empty means the request's own host.
+	PublicURL string
+	// TrustedProxies are the IPs or CIDRs whose X-Forwarded-* headers are believed
+	TrustedProxies []string
 }
 
 type GoogleOAuthConfig struct {
@@ -53,6 +61,9 @@ type QueueConfig struct {
 	HeartbeatInterval int
 	ConnectionTimeout int
 	Prefetch          int
+	MaxAttempts       int
+	RetryBaseDelay    int
+	ClosedWindow      string // "park" or "reject"
 	Exchanges         ExchangesConfig
 	Queues            QueuesConfig
 }
@@ -77,12 +88,21 @@ type QueuesConfig struct {
 
 type ScheduleConfig struct {
 	UnblockCron string
+	// IdempotencyRetentionDays is how long processed idempotency keys are kept
+	IdempotencyRetentionDays int
 }
 
 type SMTPGmailConfig struct {
 	Email    string
 	Password string
 	Host	 string
+	Port     string
+}
+
+type NotifyConfig struct {
+	WebhookURL  string
+	TemplateDir string
+	LogOnly     bool
 }
 
 func Load() *Config {
@@ -120,6 +140,9 @@ func Load() *Config {
 			HeartbeatInterval: getEnvInt("QUEUE_HEARTBEAT", 60),
 			ConnectionTimeout: getEnvInt("QUEUE_CONNECT_TIMEOUT", 30),
 			Prefetch:          getEnvInt("QUEUE_PREFETCH", 1),
+			MaxAttempts:       getEnvInt("QUEUE_MAX_ATTEMPTS", 5),
+			RetryBaseDelay:    getEnvInt("QUEUE_RETRY_BASE_DELAY", 5),
+			ClosedWindow:      getEnv("QUEUE_CLOSED_WINDOW_POLICY", "park"),
 			Exchanges: ExchangesConfig{
 				Direct:     getEnv("QUEUE_EXCHANGE_DIRECT", "ketuk.direct"),
 				Topic:      getEnv("QUEUE_EXCHANGE_TOPIC", "ketuk.topic"),
@@ -138,13 +161,22 @@ func Load() *Config {
 			},
 		},
 		Schedule: ScheduleConfig{
-			UnblockCron: getEnv("CRON_SCHEDULE_UNBLOCK_JOBS", "*/1 * * * *"),
+			UnblockCron:              getEnv("CRON_SCHEDULE_UNBLOCK_JOBS", "*/1 * * * *"),
+			IdempotencyRetentionDays: getEnvInt("IDEMPOTENCY_RETENTION_DAYS", 30),
 		},
 		SMTPGmail: SMTPGmailConfig{
 			Email:    getEnv("SMTP_GMAIL_EMAIL", ""),
 			Password: getEnv("SMTP_GMAIL_PASSWORD", ""),
 			Host:     getEnv("SMTP_GMAIL_HOST", "smtp.gmail.com"),
+			Port:     getEnv("SMTP_GMAIL_PORT", "587"),
 		},
+		Notify: NotifyConfig{
+			WebhookURL:  getEnv("NOTIFY_WEBHOOK_URL", ""),
+			TemplateDir: getEnv("NOTIFY_TEMPLATE_DIR", ""),
+			LogOnly:     getEnvBool("NOTIFY_LOG_ONLY", false),
+		},
+		PublicURL:      getEnv("PUBLIC_URL", ""),
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
 	}
 }
 
@@ -163,3 +195,23 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvList splits a comma separated variable, skipping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
@@ -30,7 +30,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all event logs for a specific ticket",
+                "description": "Get a page of event logs for a specific ticket, newest first",
                 "produces": [
                     "application/json"
                 ],
@@ -45,6 +45,48 @@ const docTemplate = `{
                         "name": "ticket_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, createdAt, action; prefix with - for descending (defaults to -id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events with this action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -63,6 +105,9 @@ const docTemplate = `{
                                             "items": {
                                                 "$ref": "#/definitions/models.TicketEventLog"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
                                         }
                                     }
                                 }
@@ -91,7 +136,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all event logs created by a specific user",
+                "description": "Get a page of event logs created by a specific user, newest first",
                 "produces": [
                     "application/json"
                 ],
@@ -106,6 +151,48 @@ const docTemplate = `{
                         "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, createdAt, action; prefix with - for descending (defaults to -id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events with this action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -124,6 +211,9 @@ const docTemplate = `{
                                             "items": {
                                                 "$ref": "#/definitions/models.TicketEventLog"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
                                         }
                                     }
                                 }
@@ -469,52 +559,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/item-categories/v1": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a list of all item categories",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "item-categories"
-                ],
-                "summary": "Get all item categories",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ItemCategory"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            },
+        "/api/bookings/v1": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new item category",
+                "description": "Validate a booking and queue it for processing. Returns 202 with a tracking ID; poll GET /api/bookings/v1/{id} for the outcome. While the message queue is unavailable the booking is accepted as unpublished and queued once it is back. Admins may book for another user with userId. With an Idempotency-Key header a retried request returns the booking of the first one (Idempotent-Replayed: true).",
                 "consumes": [
                     "application/json"
                 ],
@@ -522,23 +574,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "item-categories"
+                    "bookings"
                 ],
-                "summary": "Create a new item category",
+                "summary": "Submit a booking",
                 "parameters": [
                     {
-                        "description": "Item category data",
-                        "name": "category",
+                        "type": "string",
+                        "description": "Unique key of this request, at most 255 characters",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Booking data",
+                        "name": "booking",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateItemCategoryRequest"
+                            "$ref": "#/definitions/models.CreateBookingRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "allOf": [
                                 {
@@ -548,7 +606,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ItemCategory"
+                                            "$ref": "#/definitions/models.BookingRequest"
                                         }
                                     }
                                 }
@@ -560,29 +618,53 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key reused for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Booking could not be recorded as unpublished",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/item-categories/v1/{id}": {
+        "/api/bookings/v1/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get an item category by its ID",
+                "description": "Get whether a booking is queued, waiting to be published, processed or rejected, with the resulting ticket ID or the rejection reason. Users can only see their own bookings.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "item-categories"
+                    "bookings"
                 ],
-                "summary": "Get item category by ID",
+                "summary": "Get booking status",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
+                        "type": "string",
+                        "description": "Tracking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -600,15 +682,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ItemCategory"
+                                            "$ref": "#/definitions/models.BookingRequest"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -620,46 +702,108 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
+            }
+        },
+        "/api/calendar/v1/feeds/{token}/lab.ics": {
+            "get": {
+                "description": "iCalendar feed of all regular schedules and accepted bookings in the lab. Authenticated by any user's feed token in the URL.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Lab iCalendar feed",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "Calendar feed token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "Update item category information by ID. All fields are optional.",
-                "consumes": [
-                    "application/json"
-                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/calendar/v1/feeds/{token}/user.ics": {
+            "get": {
+                "description": "iCalendar feed of the token owner's regular schedules and accepted bookings. Authenticated by the feed token in the URL.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "item-categories"
+                    "calendar"
                 ],
-                "summary": "Update item category",
+                "summary": "Personal iCalendar feed",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Calendar feed token",
+                        "name": "token",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar data",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
-                    {
-                        "description": "Updated category data (categoryName, specification)",
-                        "name": "updates",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
                         }
                     }
+                }
+            }
+        },
+        "/api/calendar/v1/token": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Create a new iCalendar feed token for the current user. Any previous token is revoked. The token is only shown once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Issue calendar feed token",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -669,21 +813,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ItemCategory"
+                                            "$ref": "#/definitions/models.CalendarFeedTokenResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -696,23 +840,14 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an item category by ID",
+                "description": "Revoke the current user's iCalendar feed token. Subscribed calendars stop updating.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "item-categories"
-                ],
-                "summary": "Delete item category",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "calendar"
                 ],
+                "summary": "Revoke calendar feed token",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -720,8 +855,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -735,21 +870,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/items/v1": {
+        "/api/item-categories/v1": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all items",
+                "description": "Get a list of all item categories",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "item-categories"
                 ],
-                "summary": "Get all items",
+                "summary": "Get all item categories",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -764,7 +899,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Item"
+                                                "$ref": "#/definitions/models.ItemCategory"
                                             }
                                         }
                                     }
@@ -780,7 +915,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new item",
+                "description": "Create a new item category",
                 "consumes": [
                     "application/json"
                 ],
@@ -788,17 +923,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "item-categories"
                 ],
-                "summary": "Create a new item",
+                "summary": "Create a new item category",
                 "parameters": [
                     {
-                        "description": "Item data",
-                        "name": "item",
+                        "description": "Item category data",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateItemRequest"
+                            "$ref": "#/definitions/models.CreateItemCategoryRequest"
                         }
                     }
                 ],
@@ -814,7 +949,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Item"
+                                            "$ref": "#/definitions/models.ItemCategory"
                                         }
                                     }
                                 }
@@ -830,26 +965,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/items/v1/category/{category_id}": {
+        "/api/item-categories/v1/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all items for a specific category",
+                "description": "Get an item category by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "item-categories"
                 ],
-                "summary": "Get items by category ID",
+                "summary": "Get item category by ID",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Category ID",
-                        "name": "category_id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -866,10 +1001,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Item"
-                                            }
+                                            "$ref": "#/definitions/models.ItemCategory"
                                         }
                                     }
                                 }
@@ -881,32 +1013,49 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/api/items/v1/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get an item by its ID",
+                "description": "Update item category information by ID. All fields are optional.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "item-categories"
                 ],
-                "summary": "Get item by ID",
+                "summary": "Update item category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Item ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated category data (categoryName, specification)",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 ],
                 "responses": {
@@ -921,7 +1070,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Item"
+                                            "$ref": "#/definitions/models.ItemCategory"
                                         }
                                     }
                                 }
@@ -942,59 +1091,34 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update item information by ID. All fields are optional.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete an item category by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "items"
+                    "item-categories"
                 ],
-                "summary": "Update item",
+                "summary": "Delete item category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Item ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated item data (name, year, kondisi, note, categoryId)",
-                        "name": "updates",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.Item"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "400": {
@@ -1010,25 +1134,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/item-categories/v1/{id}/labels": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an item by ID",
+                "description": "Get a printable A4 PDF with an asset tag label for every item in the category (3 x 8 labels per page). Written-off items are skipped.",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "items"
+                    "item-categories"
                 ],
-                "summary": "Delete item",
+                "summary": "Get category label sheet",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Item ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1038,7 +1164,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1056,21 +1182,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/schedules/reguler/v1": {
+        "/api/items/v1": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all regular schedules",
+                "description": "Get a page of items. Filters accept comma separated values.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-reguler"
+                    "items"
+                ],
+                "summary": "Get all items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, name, year, kondisi, createdAt, updatedAt; prefix with - for descending (defaults to id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only items in this category",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items with this kondisi",
+                        "name": "kondisi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only items procured in this year",
+                        "name": "year",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all regular schedules",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1085,16 +1255,19 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.ScheduleReguler"
+                                                "$ref": "#/definitions/models.Item"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -1107,7 +1280,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new regular schedule",
+                "description": "Create a new item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1115,17 +1288,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-reguler"
+                    "items"
                 ],
-                "summary": "Create a new regular schedule",
+                "summary": "Create a new item",
                 "parameters": [
                     {
-                        "description": "Schedule data",
-                        "name": "schedule",
+                        "description": "Item data",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateScheduleRegulerRequest"
+                            "$ref": "#/definitions/models.CreateItemRequest"
                         }
                     }
                 ],
@@ -1141,7 +1314,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ScheduleReguler"
+                                            "$ref": "#/definitions/models.Item"
                                         }
                                     }
                                 }
@@ -1157,28 +1330,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/schedules/reguler/v1/user/{user_id}": {
+        "/api/items/v1/category/{category_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all regular schedules for a specific user",
+                "description": "Get a page of items for a specific category",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-reguler"
+                    "items"
                 ],
-                "summary": "Get regular schedules by user ID",
+                "summary": "Get items by category ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Category ID",
+                        "name": "category_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, name, year, kondisi, createdAt, updatedAt; prefix with - for descending (defaults to id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items with this kondisi",
+                        "name": "kondisi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only items procured in this year",
+                        "name": "year",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1195,8 +1404,11 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.ScheduleReguler"
+                                                "$ref": "#/definitions/models.Item"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
                                         }
                                     }
                                 }
@@ -1208,57 +1420,39 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/schedules/reguler/v1/{id}": {
+        "/api/items/v1/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a regular schedule by its ID",
+                "description": "Export every item with its category as CSV or XLSX. The file uses the same columns as the import: name, category, year, kondisi, note, specification.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "schedule-reguler"
+                    "items"
                 ],
-                "summary": "Get regular schedule by ID",
+                "summary": "Export inventory",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "csv or xlsx (defaults to csv)",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.ScheduleReguler"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1267,47 +1461,58 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/items/v1/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update regular schedule information by ID. All fields are optional.",
+                "description": "Import items from a CSV or XLSX file (first worksheet). Items are matched by name within their category: existing items are updated, others are created. Every row is validated (category, year, kondisi and its allowed transitions). With dryRun=true only the report is returned; otherwise all rows are written in one transaction, or none if any row is invalid. Category quantities are recounted afterwards.\nColumns: name, category, year, kondisi, note, specification. Only name and category are required; blank cells keep the current values of existing items and specification is only used for categories created by the import.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-reguler"
+                    "items"
                 ],
-                "summary": "Update regular schedule",
+                "summary": "Import inventory",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "Updated schedule data",
-                        "name": "updates",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateScheduleRegulerRequest"
-                        }
+                        "type": "string",
+                        "description": "csv or xlsx (defaults to the file extension)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create categories that do not exist yet instead of rejecting the row",
+                        "name": "createCategories",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, do not write anything",
+                        "name": "dryRun",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1322,7 +1527,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ScheduleReguler"
+                                            "$ref": "#/definitions/models.ItemImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ItemImportReport"
                                         }
                                     }
                                 }
@@ -1335,42 +1558,117 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ItemImportReport"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/items/v1/kondisi/{kondisi}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a regular schedule by ID",
+                "description": "Get a page of items with a specific condition",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-reguler"
+                    "items"
                 ],
-                "summary": "Delete regular schedule",
+                "summary": "Get items by kondisi",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "id",
+                        "enum": [
+                            "Baik",
+                            "Rusak Ringan",
+                            "Rusak Berat",
+                            "Dalam Perbaikan",
+                            "Dihapuskan"
+                        ],
+                        "type": "string",
+                        "description": "Item Kondisi",
+                        "name": "kondisi",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, name, year, kondisi, createdAt, updatedAt; prefix with - for descending (defaults to id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only items in this category",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only items procured in this year",
+                        "name": "year",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Item"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1378,31 +1676,34 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/schedules/tickets/v1": {
+        "/api/items/v1/lookup/{tag}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all schedule tickets",
+                "description": "Resolve a scanned label to the item and its current status. Accepts the asset tag (KTK-000012) or the item ID. Items that are checked out are on_loan; items whose kondisi does not allow lending are unavailable. The next approved loan is included when there is one.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-ticket"
+                    "items"
+                ],
+                "summary": "Look up item by asset tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset tag or item ID",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all schedule tickets",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1415,55 +1716,55 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ScheduleTicket"
-                                            }
+                                            "$ref": "#/definitions/models.ItemLookup"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/items/v1/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new schedule ticket",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get an item by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-ticket"
+                    "items"
                 ],
-                "summary": "Create a new schedule ticket",
+                "summary": "Get item by ID",
                 "parameters": [
                     {
-                        "description": "Schedule ticket data",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateScheduleTicketRequest"
-                        }
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1473,7 +1774,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ScheduleTicket"
+                                            "$ref": "#/definitions/models.Item"
                                         }
                                     }
                                 }
@@ -1485,32 +1786,48 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/api/schedules/tickets/v1/category/{category}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all schedule tickets with a specific category",
+                "description": "Update item information by ID. All fields are optional. A kondisi change must follow the allowed transitions and needs a kondisiReason.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-ticket"
+                    "items"
                 ],
-                "summary": "Get schedule tickets by category",
+                "summary": "Update item",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Category (barang, ruangan)",
-                        "name": "category",
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated item data",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateItemRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -1525,45 +1842,46 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ScheduleTicket"
-                                            }
+                                            "$ref": "#/definitions/models.Item"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/schedules/tickets/v1/user/{user_id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all schedule tickets for a specific user",
+                "description": "Delete an item by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-ticket"
+                    "items"
                 ],
-                "summary": "Get schedule tickets by user ID",
+                "summary": "Delete item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Item ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1572,22 +1890,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.ScheduleTicket"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "400": {
@@ -1596,8 +1899,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -1605,25 +1908,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/schedules/tickets/v1/{id}": {
+        "/api/items/v1/{id}/history": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a schedule ticket by its ID",
+                "description": "Get the kondisi timeline of an item, oldest first, with who changed it and why",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-ticket"
+                    "items"
                 ],
-                "summary": "Get schedule ticket by ID",
+                "summary": "Get item kondisi history",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Schedule ID",
+                        "description": "Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1641,7 +1944,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ScheduleTicket"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ItemKondisiHistory"
+                                            }
                                         }
                                     }
                                 }
@@ -1661,14 +1967,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/items/v1/{id}/kondisi": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update schedule ticket information by ID. All fields are optional.",
+                "description": "Move an item to another kondisi. Allowed transitions: Baik -\u003e Rusak Ringan/Rusak Berat/Dalam Perbaikan/Dihapuskan; Rusak Ringan -\u003e Baik/Rusak Berat/Dalam Perbaikan/Dihapuskan; Rusak Berat -\u003e Dalam Perbaikan/Dihapuskan; Dalam Perbaikan -\u003e Baik/Rusak Ringan/Rusak Berat/Dihapuskan. Dihapuskan is final.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1676,24 +1984,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "schedule-ticket"
+                    "items"
                 ],
-                "summary": "Update schedule ticket",
+                "summary": "Change item kondisi",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Schedule ID",
+                        "description": "Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated schedule data",
-                        "name": "updates",
+                        "description": "New kondisi and reason",
+                        "name": "kondisi",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateScheduleTicketRequest"
+                            "$ref": "#/definitions/models.ChangeKondisiRequest"
                         }
                     }
                 ],
@@ -1709,7 +2017,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ScheduleTicket"
+                                            "$ref": "#/definitions/models.Item"
                                         }
                                     }
                                 }
@@ -1729,35 +2037,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/items/v1/{id}/qr": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a schedule ticket by ID",
+                "description": "Get the QR code of an item's asset tag as PNG or SVG. The code encodes the lookup URL of the item.",
                 "produces": [
-                    "application/json"
+                    "image/png",
+                    "image/svg+xml"
                 ],
                 "tags": [
-                    "schedule-ticket"
+                    "items"
                 ],
-                "summary": "Delete schedule ticket",
+                "summary": "Get item QR code",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Schedule ID",
+                        "description": "Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "png or svg (defaults to png)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image size in pixels, 64 to 1024 (defaults to 256)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1775,24 +2098,110 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tickets/v1": {
+        "/api/loans/v1": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all tickets",
+                "description": "Get a page of equipment loans. Filters accept comma separated values.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "loans"
+                ],
+                "summary": "Get all loans",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, status; prefix with - for descending (defaults to -startDate)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Loan status (requested, approved, rejected, checked_out, returned)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only overdue (true) or not overdue (false) loans",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only loans requested by this user",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all tickets",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ItemLoan"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -1805,7 +2214,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new ticket for a user",
+                "description": "Request one or more items for a time window. A pending ticket is created for admin approval.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1813,17 +2222,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "loans"
                 ],
-                "summary": "Create a new ticket",
+                "summary": "Request an equipment loan",
                 "parameters": [
                     {
-                        "description": "Ticket data",
-                        "name": "ticket",
+                        "description": "Loan request",
+                        "name": "loan",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateTicketRequest"
+                            "$ref": "#/definitions/models.CreateLoanRequest"
                         }
                     }
                 ],
@@ -1831,7 +2240,19 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ItemLoan"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1839,44 +2260,95 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LoanConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
             }
         },
-        "/api/tickets/v1/bulk-status": {
-            "post": {
+        "/api/loans/v1/availability": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update status for multiple tickets",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List approved or checked out loans that hold any of the items in the given window. An empty list means every item is available.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "loans"
                 ],
-                "summary": "Bulk update ticket status",
+                "summary": "Check item availability",
                 "parameters": [
                     {
-                        "description": "Bulk update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.BulkUpdateStatusRequest"
-                        }
+                        "type": "string",
+                        "description": "Comma separated item IDs",
+                        "name": "itemIds",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LoanConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1888,35 +2360,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tickets/v1/{id}": {
+        "/api/loans/v1/user/{user_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a ticket by its ID",
+                "description": "Get a page of equipment loans requested by a user. Users can only list their own loans.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "loans"
                 ],
-                "summary": "Get ticket by ID",
+                "summary": "Get loans by user ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Ticket ID",
-                        "name": "id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, status; prefix with - for descending (defaults to -startDate)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Loan status (requested, approved, rejected, checked_out, returned)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only overdue (true) or not overdue (false) loans",
+                        "name": "overdue",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ItemLoan"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1925,54 +2451,68 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/loans/v1/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update ticket information by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get an equipment loan by its ID. Users can only see their own loans.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "loans"
                 ],
-                "summary": "Update ticket",
+                "summary": "Get loan by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Ticket ID",
+                        "description": "Loan ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated ticket data",
-                        "name": "ticket",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateTicketRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ItemLoan"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1981,6 +2521,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1988,35 +2540,60 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/loans/v1/{id}/approve": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a ticket by ID",
+                "description": "Approve an equipment loan. This accepts its ticket and emails the borrower.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "loans"
                 ],
-                "summary": "Delete ticket",
+                "summary": "Approve loan",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Ticket ID",
+                        "description": "Loan ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Approval reason",
+                        "name": "decision",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoanDecisionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ItemLoan"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2030,18 +2607,39 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LoanConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
             }
         },
-        "/api/tickets/v1/{id}/status": {
-            "patch": {
+        "/api/loans/v1/{id}/checkin": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the status of a ticket",
+                "description": "Record the return of a checked out loan with a condition note. Per-item conditions also update the item's kondisi.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2049,24 +2647,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tickets"
+                    "loans"
                 ],
-                "summary": "Update ticket status",
+                "summary": "Check in loan",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Ticket ID",
+                        "description": "Loan ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "New status",
-                        "name": "status",
+                        "description": "Return condition",
+                        "name": "checkin",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateStatusRequest"
+                            "$ref": "#/definitions/models.CheckInLoanRequest"
                         }
                     }
                 ],
@@ -2074,7 +2672,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ItemLoan"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2092,58 +2702,93 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/unblockings/v1": {
-            "get": {
+        "/api/loans/v1/{id}/checkout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all unblocking requests",
+                "description": "Hand the items of an approved loan to the borrower",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "unblocking"
+                    "loans"
+                ],
+                "summary": "Check out loan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all unblocking requests",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/models.UnblockingsResponse"
+                                    "$ref": "#/definitions/models.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "unblockings": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Unblocking"
-                                            }
+                                        "data": {
+                                            "$ref": "#/definitions/models.ItemLoan"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LoanConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/loans/v1/{id}/reject": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new unblocking request for semester unblocking",
+                "description": "Reject an equipment loan. This rejects its ticket and emails the borrower.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2151,33 +2796,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "unblocking"
+                    "loans"
                 ],
-                "summary": "Create a new unblocking request",
+                "summary": "Reject loan",
                 "parameters": [
                     {
-                        "description": "Unblocking data",
-                        "name": "unblocking",
+                        "type": "integer",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "decision",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateUnblockingRequest"
+                            "$ref": "#/definitions/models.LoanDecisionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/models.UnblockingResponse"
+                                    "$ref": "#/definitions/models.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "unblocking": {
-                                            "$ref": "#/definitions/models.Unblocking"
+                                        "data": {
+                                            "$ref": "#/definitions/models.ItemLoan"
                                         }
                                     }
                                 }
@@ -2190,8 +2841,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -2199,28 +2850,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/unblockings/v1/user/{user_id}": {
+        "/api/notifications/v1": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all unblocking requests for a specific user",
+                "description": "Get a page of the authenticated user's in-app notifications",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "unblocking"
+                    "notifications"
                 ],
-                "summary": "Get unblocking requests by user ID",
+                "summary": "Get my notifications",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, createdAt; prefix with - for descending (defaults to -id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type (ticket.created, ticket.status_changed, booking.closed)",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread (true) or read (false) notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2229,16 +2921,19 @@ const docTemplate = `{
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/models.UnblockingsResponse"
+                                    "$ref": "#/definitions/models.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "unblockings": {
+                                        "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Unblocking"
+                                                "$ref": "#/definitions/models.Notification"
                                             }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
                                         }
                                     }
                                 }
@@ -2251,6 +2946,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2260,57 +2961,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/unblockings/v1/{id}": {
+        "/api/notifications/v1/preferences": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get an unblocking request by its ID",
+                "description": "List every event and channel combination and whether the authenticated user receives it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "unblocking"
-                ],
-                "summary": "Get unblocking request by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Unblocking ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notifications"
                 ],
+                "summary": "Get my notification preferences",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/models.UnblockingResponse"
+                                    "$ref": "#/definitions/models.APIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
-                                        "unblocking": {
-                                            "$ref": "#/definitions/models.Unblocking"
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.NotificationPreference"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -2322,39 +3011,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/users/v1": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a list of all users",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Get all users",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
-                        }
-                    }
-                }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new user with name and email",
+                "description": "Enable or disable channels per event type for the authenticated user. Combinations not listed keep their current setting.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2362,29 +3026,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "notifications"
                 ],
-                "summary": "Create a new user",
+                "summary": "Update my notification preferences",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "user",
+                        "description": "Preferences to change",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateUserRequest"
+                            "$ref": "#/definitions/models.UpdateNotificationPreferencesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.NotificationPreference"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -2392,35 +3083,114 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/v1/{id}": {
-            "get": {
+        "/api/notifications/v1/read-all": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a user by their ID",
+                "description": "Mark every unread notification of the authenticated user as read",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "notifications"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "integer",
+                                                "format": "int64"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/v1/templates/{event}/preview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render the email template of an event with sample data. Templates overridden in NOTIFY_TEMPLATE_DIR are used when present.",
+                "produces": [
+                    "application/json",
+                    "text/html",
+                    "text/plain"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Preview a notification email",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Event type (ticket.created, ticket.status_changed, booking.closed)",
+                        "name": "event",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language: id or en (defaults to id)",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (subject, text and HTML), html or text (defaults to json)",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/notify.Email"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2434,49 +3204,57 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/notifications/v1/{id}/read": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user information by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mark one of the authenticated user's notifications as read",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "notifications"
                 ],
-                "summary": "Update user",
+                "summary": "Mark a notification as read",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Notification ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated user data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateUserRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Notification"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2485,42 +3263,70 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/queue/v1/dead-letters": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a user by ID",
+                "description": "List messages that failed permanently or ran out of retries, oldest first, with the failure reason. The messages stay in the dead-letter queue.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "queue"
                 ],
-                "summary": "Delete user",
+                "summary": "List dead letters",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Maximum number of messages, 1 to 100 (defaults to 20)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.DeadLetter"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2529,8 +3335,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
@@ -2538,274 +3344,4674 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Check if the API is running",
+        "/api/queue/v1/dead-letters/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send dead letters back to their original queue with their attempt count reset. Without IDs every dead letter is replayed.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "queue"
+                ],
+                "summary": "Replay dead letters",
+                "parameters": [
+                    {
+                        "description": "Dead letters to replay",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReplayDeadLettersRequest"
+                        }
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.HealthResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReplayDeadLettersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.BulkUpdateStatusRequest": {
-            "type": "object",
-            "required": [
-                "ids",
-                "status"
-            ],
-            "properties": {
-                "ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
-                "reason": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                }
-            }
+        },
+        "/api/rooms/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all bookable rooms",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Get all rooms",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only return active rooms",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Room"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new bookable room. Opening hours are optional and use HH:MM in lab time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Create a new room",
+                "parameters": [
+                    {
+                        "description": "Room data",
+                        "name": "room",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Room"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/rooms/v1/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a room by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Get room by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Room"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update room information by ID. All fields are optional; send empty opensAt and closesAt to clear the opening hours.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Update room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated room data",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Room"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a room that has never been booked. Rooms with schedules or tickets must be deactivated instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Delete room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/schedules/reguler/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of regular schedules. When from and to are given, recurring schedules are instead expanded into all occurrences within that window, without pagination.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-reguler"
+                ],
+                "summary": "Get all regular schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules in this room",
+                        "name": "roomId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to startDate). Ignored for occurrences",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules of this academic year. Ignored for occurrences",
+                        "name": "tahun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only schedules of this semester (Ganjil, Genap). Ignored for occurrences",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules of this user. Ignored for occurrences",
+                        "name": "userId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleReguler"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new regular schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-reguler"
+                ],
+                "summary": "Create a new regular schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule data",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateScheduleRegulerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleReguler"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/schedules/reguler/v1/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import a semester timetable from a CSV or ICS file. Every row is validated (dates, owner email, overlaps with existing schedules and with other rows). With dryRun=true only the report is returned; otherwise all rows are created in one transaction, or none if any row is invalid.\nCSV columns: title, email, start_date, end_date, rrule, exdates, tahun, semester, room. ICS events use SUMMARY, ORGANIZER (mailto), LOCATION (room name), DTSTART, DTEND, RRULE and EXDATE.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-reguler"
+                ],
+                "summary": "Import regular schedules",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or ICS file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or ics (defaults to the file extension)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default academic year for rows without one",
+                        "name": "tahun",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Default semester (Ganjil/Genap) for rows without one",
+                        "name": "semester",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Default room for rows without one",
+                        "name": "roomId",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only, do not create anything",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/schedules/reguler/v1/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of regular schedules for a specific user. When from and to are given, recurring schedules are instead expanded into all occurrences within that window, without pagination.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-reguler"
+                ],
+                "summary": "Get regular schedules by user ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules in this room",
+                        "name": "roomId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to startDate). Ignored for occurrences",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules of this academic year. Ignored for occurrences",
+                        "name": "tahun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only schedules of this semester (Ganjil, Genap). Ignored for occurrences",
+                        "name": "semester",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleReguler"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/schedules/reguler/v1/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a regular schedule by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-reguler"
+                ],
+                "summary": "Get regular schedule by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleReguler"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update regular schedule information by ID. All fields are optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-reguler"
+                ],
+                "summary": "Update regular schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated schedule data",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateScheduleRegulerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleReguler"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a regular schedule by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-reguler"
+                ],
+                "summary": "Delete regular schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/schedules/tickets/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of schedule tickets, latest first. Filters accept comma separated values.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-ticket"
+                ],
+                "summary": "Get all schedule tickets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to -startDate)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules in this room",
+                        "name": "roomId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules of this user",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only schedules with this category (Kelas, Lainnya, Praktikum, Skripsi)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only booked (true) or free (false) schedules",
+                        "name": "isBooked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleTicket"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new schedule ticket",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-ticket"
+                ],
+                "summary": "Create a new schedule ticket",
+                "parameters": [
+                    {
+                        "description": "Schedule ticket data",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateScheduleTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleTicket"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/schedules/tickets/v1/category/{category}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of schedule tickets with a specific category, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-ticket"
+                ],
+                "summary": "Get schedule tickets by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category (barang, ruangan)",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to -startDate)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules in this room",
+                        "name": "roomId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules of this user",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only booked (true) or free (false) schedules",
+                        "name": "isBooked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleTicket"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/schedules/tickets/v1/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of schedule tickets for a specific user, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-ticket"
+                ],
+                "summary": "Get schedule tickets by user ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to -startDate)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only schedules in this room",
+                        "name": "roomId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only schedules with this category (Kelas, Lainnya, Praktikum, Skripsi)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only booked (true) or free (false) schedules",
+                        "name": "isBooked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleTicket"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/schedules/tickets/v1/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a schedule ticket by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-ticket"
+                ],
+                "summary": "Get schedule ticket by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleTicket"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update schedule ticket information by ID. All fields are optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-ticket"
+                ],
+                "summary": "Update schedule ticket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated schedule data",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateScheduleTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleTicket"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a schedule ticket by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule-ticket"
+                ],
+                "summary": "Delete schedule ticket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ranked full-text search with web search syntax: \"quoted phrases\", OR and -excluded words. Matched words are wrapped in \u003cmark\u003e tags in headline and snippet. Regular users only find their own tickets and bookings.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search tickets, items and schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated types to search: ticket, item, schedule_ticket, schedule_reguler (defaults to all)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum hits per type, 1 to 50 (defaults to 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SearchResults"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stream/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream replacing ticket polling. The first event is unblock.state ({\"open\": bool}), true while any booking window for the user's role is open. After that users get ticket.status_changed for their own tickets, admins get ticket.created for new pending tickets and everyone gets unblock.opened and unblock.closed for the windows of their role; the kategori of the window tells which category opened or closed. Event data is the domain event JSON and the SSE id is its ID. Browsers' EventSource cannot send an Authorization header, so the token may be passed as access_token instead. A client that falls behind is disconnected and should refetch what it shows when it reconnects.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream live updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT access token, for clients that cannot send an Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tickets/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of tickets, newest first. Filters accept comma separated values.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Get all tickets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, createdAt, updatedAt, approvedAt, title, status; prefix with - for descending (defaults to -id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tickets with this status (pending, accepted, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tickets of this user",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tickets for this room",
+                        "name": "roomId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Ticket"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new ticket for a user, without booking a schedule. Use POST /api/bookings/v1 to book a room. With an Idempotency-Key header a retried request returns the ticket created by the first one (200, Idempotent-Replayed: true) instead of creating another.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Create a new ticket",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique key of this request, at most 255 characters",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Ticket data",
+                        "name": "ticket",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Replayed request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key reused for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tickets/v1/bulk-status": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update status for multiple tickets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Bulk update ticket status",
+                "parameters": [
+                    {
+                        "description": "Bulk update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkUpdateStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tickets/v1/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a ticket by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Get ticket by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update ticket information by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Update ticket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated ticket data",
+                        "name": "ticket",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a ticket by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Delete ticket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tickets/v1/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the status of a ticket. Accepting or rejecting an equipment loan ticket approves or rejects the loan; item conflicts return 409 with the conflicting loans.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tickets"
+                ],
+                "summary": "Update ticket status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unblockings/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of unblocking requests, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unblocking"
+                ],
+                "summary": "Get all unblocking requests",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, tahun; prefix with - for descending (defaults to -startDate)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only windows of this academic year",
+                        "name": "tahun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only windows of this semester (Ganjil, Genap)",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only requests of this user",
+                        "name": "userId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.UnblockingsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "unblockings": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Unblocking"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new unblocking request for semester unblocking. A window may be limited to one booking category (kategori) and one role; without them it applies to every category or role. Windows of the same category and role may touch but not overlap.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unblocking"
+                ],
+                "summary": "Create a new unblocking request",
+                "parameters": [
+                    {
+                        "description": "Unblocking data",
+                        "name": "unblocking",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUnblockingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.UnblockingResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "unblocking": {
+                                            "$ref": "#/definitions/models.Unblocking"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlaps an existing window",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unblockings/v1/effective": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report whether booking of a category by a role is open now (or at the given moment), which window decides it and why. Booking is open while any window for the category (or every category) and the role (or every role) covers the moment; the most specific of them is reported and all of them are listed in covering. When old overlapping windows of the same scope cover the moment, the one created last wins. When booking is closed the next window is included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unblocking"
+                ],
+                "summary": "Get the effective unblocking window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Moment to evaluate (RFC3339, or YYYY-MM-DD for midnight lab time), defaults to now",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Booking category (Kelas, Lainnya, Praktikum, Skripsi); any category when omitted",
+                        "name": "kategori",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role of the booking user (admin, user); defaults to the caller's role",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.EffectiveUnblocking"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unblockings/v1/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of unblocking requests for a specific user, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unblocking"
+                ],
+                "summary": "Get unblocking requests by user ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, startDate, endDate, createdAt, tahun; prefix with - for descending (defaults to -startDate)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only windows of this academic year",
+                        "name": "tahun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only windows of this semester (Ganjil, Genap)",
+                        "name": "semester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.UnblockingsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "unblockings": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Unblocking"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/unblockings/v1/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an unblocking request by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "unblocking"
+                ],
+                "summary": "Get unblocking request by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unblocking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.UnblockingResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "unblocking": {
+                                            "$ref": "#/definitions/models.Unblocking"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of users",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, name, email, role, created_at; prefix with - for descending (defaults to id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users with this role (admin, user)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.User"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new user with name and email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/v1/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a user by their ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user information by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated user data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a user by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/v1": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every webhook subscription. Secrets are not included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get all webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.WebhookSubscription"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to ticket.created, ticket.status_changed, schedule.created or item.updated events. Every event is POSTed as JSON with X-Ketuk-Event, X-Ketuk-Delivery, X-Ketuk-Timestamp and X-Ketuk-Signature headers; the signature is \"sha256=\" followed by the hex HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" keyed with the secret. A secret is generated when none is given and is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateWebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookSubscriptionWithSecret"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/v1/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a webhook subscription by its ID. The secret is not included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook subscription by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookSubscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a webhook subscription. All fields are optional. With rotateSecret a new secret is generated and returned in this response only; deliveries still pending are signed with it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated subscription data",
+                        "name": "updates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateWebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookSubscriptionWithSecret"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook subscription together with its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/v1/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a page of the delivery log of a subscription, newest first, with the attempts made and the last response code and body",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from meta.nextCursor; requires sort=id or sort=-id",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort keys: id, createdAt; prefix with - for descending (defaults to -id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only deliveries with this status (pending, succeeded, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only deliveries of this event type",
+                        "name": "eventType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only deliveries of this event",
+                        "name": "eventId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339 or YYYY-MM-DD, inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.WebhookDelivery"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/models.PageMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/v1/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue the event of a delivery to be sent again with a fresh signature. The original delivery stays in the log and the new one refers to it with redeliveryOf.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Redeliver a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.WebhookDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Check if the API is running. The status is \"degraded\" while RabbitMQ is (re)connecting; the API itself keeps serving.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.BulkUpdateStatusRequest": {
+            "type": "object",
+            "required": [
+                "ids",
+                "status"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         },
         "handlers.LoginRequest": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "handlers.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "handlers.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "password123"
+                }
+            }
+        },
+        "handlers.UpdateStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.APIResponse": {
+            "description": "Standard API response format",
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Operation completed successfully"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.PageMeta"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.BookingRequest": {
+            "description": "Booking submitted through the queue and its processing status",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Praktikum routing statis untuk kelas B"
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2025-03-17T10:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7f1c2b9e-5d4a-4c1e-9a43-2f6d1e0b8c71"
+                },
+                "kategori": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "Praktikum"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "requested slot conflicts with 1 existing schedule(s)"
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2025-03-17T08:00:00Z"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.BookingStatus"
+                        }
+                    ],
+                    "example": "processed"
+                },
+                "ticketId": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Praktikum Jaringan Komputer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.BookingStatus": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "unpublished",
+                "processed",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "BookingQueued",
+                "BookingUnpublished",
+                "BookingProcessed",
+                "BookingRejected"
+            ]
+        },
+        "models.CalendarFeedTokenResponse": {
+            "description": "Newly issued calendar feed token and subscription URLs",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "labFeedUrl": {
+                    "type": "string",
+                    "example": "https://ketuk.example.com/api/calendar/v1/feeds/3f9c1b.../lab.ics"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3f9c1b..."
+                },
+                "userFeedUrl": {
+                    "type": "string",
+                    "example": "https://ketuk.example.com/api/calendar/v1/feeds/3f9c1b.../user.ics"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "string",
+            "enum": [
+                "Kelas",
+                "Lainnya",
+                "Praktikum",
+                "Skripsi"
+            ],
+            "x-enum-varnames": [
+                "Kelas",
+                "Lainnya",
+                "Praktikum",
+                "Skripsi"
+            ]
+        },
+        "models.ChangeKondisiRequest": {
+            "type": "object",
+            "required": [
+                "kondisi",
+                "reason"
+            ],
+            "properties": {
+                "kondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Dalam Perbaikan"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Dikirim ke teknisi untuk ganti PSU"
+                }
+            }
+        },
+        "models.CheckInLoanRequest": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LoanItemReturn"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Semua barang kembali lengkap"
+                }
+            }
+        },
+        "models.ComponentHealth": {
+            "description": "State of a dependency",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "since": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "connected"
+                }
+            }
+        },
+        "models.CreateBookingRequest": {
+            "description": "Request body for submitting a booking",
+            "type": "object",
+            "required": [
+                "description",
+                "endDate",
+                "kategori",
+                "startDate",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Praktikum routing statis untuk kelas B"
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2025-03-17T10:00:00+07:00"
+                },
+                "kategori": {
+                    "enum": [
+                        "Kelas",
+                        "Lainnya",
+                        "Praktikum",
+                        "Skripsi"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "Praktikum"
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2025-03-17T08:00:00+07:00"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Praktikum Jaringan Komputer"
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CreateItemCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "categoryName": {
+                    "type": "string",
+                    "example": "Komputer"
+                },
+                "specification": {
+                    "type": "string",
+                    "example": "Komputer Desktop Intel Core i5"
+                }
+            }
+        },
+        "models.CreateItemRequest": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Baik"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PC-001"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Kondisi normal, ready to use"
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2023
+                }
+            }
+        },
+        "models.CreateLoanRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "itemIds",
+                "purpose",
+                "startDate"
+            ],
+            "properties": {
+                "endDate": {
+                    "type": "string",
+                    "example": "2023-12-01T16:00:00Z"
+                },
+                "itemIds": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "purpose": {
+                    "type": "string",
+                    "example": "Presentasi tugas akhir"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2023-12-01T08:00:00Z"
+                }
+            }
+        },
+        "models.CreateRoomRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 40
+                },
+                "closesAt": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Gedung A Lantai 3"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lab 2"
+                },
+                "opensAt": {
+                    "type": "string",
+                    "example": "07:00"
+                }
+            }
+        },
+        "models.CreateScheduleRegulerRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "startDate",
+                "title",
+                "userId"
+            ],
+            "properties": {
+                "endDate": {
+                    "type": "string",
+                    "example": "2023-12-01T17:00:00Z"
+                },
+                "exDates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO,WE"
+                },
+                "semester": {
+                    "enum": [
+                        "Ganjil",
+                        "Genap"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SemesterCategory"
+                        }
+                    ],
+                    "example": "Ganjil"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2023-12-01T09:00:00Z"
+                },
+                "tahun": {
+                    "type": "integer",
+                    "example": 2023
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Regular Maintenance Schedule"
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CreateScheduleTicketRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "kategori",
+                "startDate",
+                "title",
+                "userId"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Scheduled network maintenance"
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2023-12-01T17:00:00Z"
+                },
+                "kategori": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "barang"
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2023-12-01T09:00:00Z"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Network Maintenance"
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CreateTicketRequest": {
+            "description": "Request body for creating a new ticket",
+            "type": "object",
+            "required": [
+                "description",
+                "kategori",
+                "title",
+                "userId"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Need to book conference room for meeting"
+                },
+                "kategori": {
+                    "enum": [
+                        "Kelas",
+                        "Lainnya",
+                        "Praktikum",
+                        "Skripsi"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "Praktikum"
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Room Booking Request"
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CreateUnblockingRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "semester",
+                "startDate",
+                "tahun",
+                "userId"
+            ],
+            "properties": {
+                "endDate": {
+                    "type": "string",
+                    "example": "2023-12-31T00:00:00Z"
+                },
+                "kategori": {
+                    "enum": [
+                        "Kelas",
+                        "Lainnya",
+                        "Praktikum",
+                        "Skripsi"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "Praktikum"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "user"
+                    ],
+                    "example": "user"
+                },
+                "semester": {
+                    "enum": [
+                        "Ganjil",
+                        "Genap"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SemesterCategory"
+                        }
+                    ],
+                    "example": "Ganjil"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2023-09-01T00:00:00Z"
+                },
+                "tahun": {
+                    "type": "integer",
+                    "example": 2023
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.CreateUserRequest": {
+            "description": "Request body for creating a new user",
+            "type": "object",
+            "required": [
+                "email",
+                "google_sub",
+                "name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "google_sub": {
+                    "type": "string",
+                    "example": "google-oauth2|123456789"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                }
+            }
+        },
+        "models.CreateWebhookSubscriptionRequest": {
+            "description": "Request body for creating a webhook subscription",
+            "type": "object",
+            "required": [
+                "eventTypes",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Inventory dashboard"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.DomainEventType"
+                    },
+                    "example": [
+                        "ticket.created",
+                        "item.updated"
+                    ]
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16,
+                    "example": "3f9a6c1e0b7d4e2a9c5f8b1d6e3a7c0f"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://lab.example.ac.id/hooks/ketuk"
+                }
+            }
+        },
+        "models.DeadLetter": {
+            "description": "Message in the dead-letter queue",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "body": {
+                    "type": "string",
+                    "example": "{\"userId\":1,\"title\":\"Praktikum\"}"
+                },
+                "contentType": {
+                    "type": "string",
+                    "example": "application/json"
+                },
+                "failedAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1718000000000000000-3"
+                },
+                "queue": {
+                    "type": "string",
+                    "example": "schedule"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "requested slot conflicts with 1 existing schedule(s)"
+                }
+            }
+        },
+        "models.DomainEventType": {
+            "type": "string",
+            "enum": [
+                "ticket.created",
+                "ticket.status_changed",
+                "schedule.created",
+                "user.created",
+                "item.updated",
+                "unblock.opened",
+                "unblock.closed"
+            ],
+            "x-enum-varnames": [
+                "DomainTicketCreated",
+                "DomainTicketStatusChanged",
+                "DomainScheduleCreated",
+                "DomainUserCreated",
+                "DomainItemUpdated",
+                "DomainUnblockOpened",
+                "DomainUnblockClosed"
+            ]
+        },
+        "models.EffectiveUnblocking": {
+            "description": "Unblocking window in effect at a moment and why",
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string",
+                    "example": "2023-09-05T10:00:00+07:00"
+                },
+                "covering": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Unblocking"
+                    }
+                },
+                "kategori": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "Praktikum"
+                },
+                "next": {
+                    "$ref": "#/definitions/models.Unblocking"
+                },
+                "open": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "window 3 (Praktikum) covers this moment"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "window": {
+                    "$ref": "#/definitions/models.Unblocking"
+                }
+            }
+        },
+        "models.HealthResponse": {
+            "description": "Health check response format",
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.ComponentHealth"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "healthy"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                }
+            }
+        },
+        "models.InventoryFormat": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "xlsx"
             ],
+            "x-enum-varnames": [
+                "InventoryCSV",
+                "InventoryXLSX"
+            ]
+        },
+        "models.Item": {
+            "description": "Item information",
+            "type": "object",
             "properties": {
-                "email": {
+                "category": {
+                    "$ref": "#/definitions/models.ItemCategory"
+                },
+                "categoryId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Baik"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PC-001"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Kondisi normal, ready to use"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2023
+                }
+            }
+        },
+        "models.ItemAvailability": {
+            "type": "string",
+            "enum": [
+                "available",
+                "on_loan",
+                "unavailable"
+            ],
+            "x-enum-varnames": [
+                "ItemAvailable",
+                "ItemOnLoan",
+                "ItemUnavailable"
+            ]
+        },
+        "models.ItemCategory": {
+            "description": "Item category information",
+            "type": "object",
+            "properties": {
+                "categoryName": {
+                    "type": "string",
+                    "example": "Komputer"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Item"
+                    }
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "specification": {
+                    "type": "string",
+                    "example": "Komputer Desktop Intel Core i5"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.ItemImportAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "unchanged"
+            ],
+            "x-enum-varnames": [
+                "ItemImportCreate",
+                "ItemImportUpdate",
+                "ItemImportUnchanged"
+            ]
+        },
+        "models.ItemImportReport": {
+            "description": "Result of an inventory import",
+            "type": "object",
+            "properties": {
+                "categoriesCreated": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "committed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "dryRun": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "format": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.InventoryFormat"
+                        }
+                    ],
+                    "example": "xlsx"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ItemImportRow"
+                    }
+                },
+                "totalRows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "validRows": {
+                    "type": "integer",
+                    "example": 118
+                }
+            }
+        },
+        "models.ItemImportRow": {
+            "description": "Validation result of a single imported inventory row",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ItemImportAction"
+                        }
+                    ],
+                    "example": "create"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Komputer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "itemId": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "kondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Baik"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PC-001"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2023
+                }
+            }
+        },
+        "models.ItemKondisiHistory": {
+            "description": "Item kondisi change",
+            "type": "object",
+            "properties": {
+                "changedBy": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "changedByUser": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "fromKondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Baik"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "itemId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "loanId": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Keyboard tidak berfungsi"
+                },
+                "toKondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Rusak Ringan"
+                }
+            }
+        },
+        "models.ItemLoan": {
+            "description": "Equipment loan information",
+            "type": "object",
+            "properties": {
+                "checkedOutAt": {
+                    "type": "string",
+                    "example": "2023-12-01T08:05:00Z"
+                },
+                "checkedOutBy": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2023-12-01T16:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "isOverdue": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ItemLoanItem"
+                    }
+                },
+                "purpose": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "Presentasi tugas akhir"
                 },
-                "password": {
+                "returnNote": {
                     "type": "string",
-                    "example": "password123"
-                }
-            }
-        },
-        "handlers.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "refresh_token": {
+                    "example": "Semua barang kembali lengkap"
+                },
+                "returnedAt": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "2023-12-01T15:55:00Z"
                 },
-                "token": {
+                "returnedBy": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "startDate": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "2023-12-01T08:00:00Z"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LoanStatus"
+                        }
+                    ],
+                    "example": "requested"
+                },
+                "ticket": {
+                    "$ref": "#/definitions/models.Ticket"
+                },
+                "ticketId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
                 },
                 "user": {
                     "$ref": "#/definitions/models.User"
+                },
+                "userId": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "handlers.RegisterRequest": {
+        "models.ItemLoanItem": {
+            "description": "Item lent in a loan",
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "item": {
+                    "$ref": "#/definitions/models.Item"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "John Doe"
+                "itemId": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
-                }
-            }
-        },
-        "handlers.UpdateStatusRequest": {
-            "type": "object",
-            "required": [
-                "status"
-            ],
-            "properties": {
-                "reason": {
-                    "type": "string"
+                "loanId": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "status": {
-                    "type": "string"
+                "returnKondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Baik"
+                },
+                "returnNote": {
+                    "type": "string",
+                    "example": "Kabel HDMI sedikit longgar"
                 }
             }
         },
-        "models.APIResponse": {
-            "description": "Standard API response format",
+        "models.ItemLookup": {
+            "description": "Item and its current status, resolved from an asset tag",
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {
+                "assetTag": {
                     "type": "string",
-                    "example": ""
+                    "example": "KTK-000001"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Operation completed successfully"
+                "currentLoan": {
+                    "$ref": "#/definitions/models.ItemLoan"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "item": {
+                    "$ref": "#/definitions/models.Item"
+                },
+                "nextLoan": {
+                    "$ref": "#/definitions/models.ItemLoan"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ItemAvailability"
+                        }
+                    ],
+                    "example": "available"
                 }
             }
         },
-        "models.Category": {
+        "models.Kondisi": {
             "type": "string",
             "enum": [
-                "Kelas",
-                "Lainnya",
-                "Praktikum",
-                "Skripsi"
+                "Baik",
+                "Rusak Ringan",
+                "Rusak Berat",
+                "Dalam Perbaikan",
+                "Dihapuskan"
             ],
             "x-enum-varnames": [
-                "Kelas",
-                "Lainnya",
-                "Praktikum",
-                "Skripsi"
+                "KondisiBaik",
+                "KondisiRusakRingan",
+                "KondisiRusakBerat",
+                "KondisiDalamPerbaikan",
+                "KondisiDihapuskan"
             ]
         },
-        "models.CreateItemCategoryRequest": {
+        "models.LoanConflict": {
+            "description": "Loan holding an item during the requested window",
             "type": "object",
             "properties": {
-                "categoryName": {
+                "endDate": {
                     "type": "string",
-                    "example": "Komputer"
+                    "example": "2023-12-01T16:00:00Z"
                 },
-                "specification": {
-                    "type": "string",
-                    "example": "Komputer Desktop Intel Core i5"
-                }
-            }
-        },
-        "models.CreateItemRequest": {
-            "type": "object",
-            "properties": {
-                "categoryId": {
+                "itemId": {
                     "type": "integer",
                     "example": 1
                 },
-                "kondisi": {
-                    "type": "string",
-                    "example": "Baik"
-                },
-                "name": {
+                "itemName": {
                     "type": "string",
                     "example": "PC-001"
                 },
-                "note": {
+                "loanId": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "startDate": {
                     "type": "string",
-                    "example": "Kondisi normal, ready to use"
+                    "example": "2023-12-01T08:00:00Z"
                 },
-                "year": {
-                    "type": "integer",
-                    "example": 2023
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LoanStatus"
+                        }
+                    ],
+                    "example": "approved"
                 }
             }
         },
-        "models.CreateScheduleRegulerRequest": {
+        "models.LoanDecisionRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Barang tersedia"
+                }
+            }
+        },
+        "models.LoanItemReturn": {
             "type": "object",
             "required": [
-                "endDate",
-                "startDate",
-                "title",
-                "userId"
+                "itemId",
+                "kondisi"
             ],
             "properties": {
-                "endDate": {
-                    "type": "string",
-                    "example": "2023-12-01T17:00:00Z"
+                "itemId": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "startDate": {
-                    "type": "string",
-                    "example": "2023-12-01T09:00:00Z"
+                "kondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Baik"
                 },
-                "title": {
+                "note": {
                     "type": "string",
-                    "example": "Regular Maintenance Schedule"
-                },
-                "userId": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "Kabel HDMI sedikit longgar"
                 }
             }
         },
-        "models.CreateScheduleTicketRequest": {
-            "type": "object",
-            "required": [
-                "endDate",
-                "kategori",
-                "startDate",
-                "title",
-                "userId"
+        "models.LoanStatus": {
+            "type": "string",
+            "enum": [
+                "requested",
+                "approved",
+                "rejected",
+                "checked_out",
+                "returned"
             ],
+            "x-enum-varnames": [
+                "LoanRequested",
+                "LoanApproved",
+                "LoanRejected",
+                "LoanCheckedOut",
+                "LoanReturned"
+            ]
+        },
+        "models.Notification": {
+            "description": "In-app notification",
+            "type": "object",
             "properties": {
-                "description": {
+                "body": {
                     "type": "string",
-                    "example": "Scheduled network maintenance"
+                    "example": "Your ticket status has been approved."
                 },
-                "endDate": {
+                "createdAt": {
                     "type": "string",
-                    "example": "2023-12-01T17:00:00Z"
+                    "example": "2023-01-01T00:00:00Z"
                 },
-                "kategori": {
+                "data": {
+                    "type": "string"
+                },
+                "event": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.Category"
+                            "$ref": "#/definitions/models.NotificationEvent"
                         }
                     ],
-                    "example": "barang"
-                },
-                "startDate": {
-                    "type": "string",
-                    "example": "2023-12-01T09:00:00Z"
+                    "example": "ticket.status_changed"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Network Maintenance"
-                },
-                "userId": {
+                "id": {
                     "type": "integer",
                     "example": 1
-                }
-            }
-        },
-        "models.CreateTicketRequest": {
-            "description": "Request body for creating a new ticket",
-            "type": "object",
-            "required": [
-                "description",
-                "title",
-                "userId"
-            ],
-            "properties": {
-                "description": {
+                },
+                "readAt": {
                     "type": "string",
-                    "example": "Need to book conference room for meeting"
+                    "example": "2023-01-01T00:00:00Z"
                 },
-                "title": {
+                "subject": {
                     "type": "string",
-                    "example": "Room Booking Request"
+                    "example": "Ticket #12 Status approved"
                 },
                 "userId": {
                     "type": "integer",
@@ -2813,97 +8019,145 @@ const docTemplate = `{
                 }
             }
         },
-        "models.CreateUnblockingRequest": {
+        "models.NotificationChannel": {
+            "type": "string",
+            "enum": [
+                "email",
+                "webhook",
+                "in_app"
+            ],
+            "x-enum-varnames": [
+                "ChannelEmail",
+                "ChannelWebhook",
+                "ChannelInApp"
+            ]
+        },
+        "models.NotificationEvent": {
+            "type": "string",
+            "enum": [
+                "ticket.created",
+                "ticket.status_changed",
+                "booking.closed"
+            ],
+            "x-enum-varnames": [
+                "NotifyTicketCreated",
+                "NotifyTicketStatusChanged",
+                "NotifyBookingClosed"
+            ]
+        },
+        "models.NotificationPreference": {
+            "description": "Whether a user receives an event on a channel",
             "type": "object",
             "required": [
-                "endDate",
-                "semester",
-                "startDate",
-                "tahun",
-                "userId"
+                "channel",
+                "event"
             ],
             "properties": {
-                "endDate": {
-                    "type": "string",
-                    "example": "2023-12-31T00:00:00Z"
-                },
-                "semester": {
-                    "enum": [
-                        "Ganjil",
-                        "Genap"
+                "channel": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationChannel"
+                        }
                     ],
+                    "example": "email"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "event": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.SemesterCategory"
+                            "$ref": "#/definitions/models.NotificationEvent"
                         }
                     ],
-                    "example": "Ganjil"
+                    "example": "ticket.status_changed"
                 },
-                "startDate": {
+                "updatedAt": {
                     "type": "string",
-                    "example": "2023-09-01T00:00:00Z"
+                    "example": "2023-01-01T00:00:00Z"
+                }
+            }
+        },
+        "models.PageMeta": {
+            "description": "Pagination information of a list response",
+            "type": "object",
+            "properties": {
+                "hasMore": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "tahun": {
+                "limit": {
                     "type": "integer",
-                    "example": 2023
+                    "example": 20
                 },
-                "userId": {
+                "nextCursor": {
+                    "type": "string",
+                    "example": "MTIz"
+                },
+                "page": {
                     "type": "integer",
                     "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 134
+                },
+                "totalPages": {
+                    "type": "integer",
+                    "example": 7
                 }
             }
         },
-        "models.CreateUserRequest": {
-            "description": "Request body for creating a new user",
+        "models.RefreshTokenRequest": {
+            "description": "Request body for refreshing access token",
             "type": "object",
             "required": [
-                "email",
-                "google_sub",
-                "name"
+                "refresh_token"
             ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "john.doe@example.com"
-                },
-                "google_sub": {
-                    "type": "string",
-                    "example": "google-oauth2|123456789"
-                },
-                "name": {
+                "refresh_token": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "refresh_token_123456789"
                 }
             }
         },
-        "models.HealthResponse": {
-            "description": "Health check response format",
+        "models.ReplayDeadLettersRequest": {
+            "description": "Dead letter IDs to replay; all dead letters when empty",
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "string",
-                    "example": "healthy"
-                },
-                "timestamp": {
-                    "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
-                },
-                "version": {
-                    "type": "string",
-                    "example": "1.0.0"
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "1718000000000000000-3"
+                    ]
                 }
             }
         },
-        "models.Item": {
-            "description": "Item information",
+        "models.ReplayDeadLettersResponse": {
+            "description": "Number of dead letters sent back to their queue",
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/models.ItemCategory"
-                },
-                "categoryId": {
+                "replayed": {
                     "type": "integer",
                     "example": 1
+                }
+            }
+        },
+        "models.Room": {
+            "description": "Bookable room information",
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "closesAt": {
+                    "type": "string",
+                    "example": "17:00"
                 },
                 "createdAt": {
                     "type": "string",
@@ -2913,74 +8167,158 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
-                "kondisi": {
+                "isActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "location": {
                     "type": "string",
-                    "example": "Baik"
+                    "example": "Gedung A Lantai 2"
                 },
                 "name": {
                     "type": "string",
-                    "example": "PC-001"
+                    "example": "Lab 1"
                 },
-                "note": {
+                "opensAt": {
                     "type": "string",
-                    "example": "Kondisi normal, ready to use"
+                    "example": "07:00"
                 },
                 "updatedAt": {
                     "type": "string",
                     "example": "2023-01-01T00:00:00Z"
-                },
-                "year": {
-                    "type": "integer",
-                    "example": 2023
                 }
             }
         },
-        "models.ItemCategory": {
-            "description": "Item category information",
+        "models.ScheduleConflict": {
+            "description": "Existing schedule clashing with the requested time slot",
             "type": "object",
             "properties": {
-                "categoryName": {
+                "endDate": {
                     "type": "string",
-                    "example": "Komputer"
+                    "example": "2023-12-01T11:00:00Z"
                 },
-                "createdAt": {
+                "idSchedule": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "source": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ScheduleSource"
+                        }
+                    ],
+                    "example": "ticket"
+                },
+                "startDate": {
                     "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                    "example": "2023-12-01T09:00:00Z"
                 },
-                "id": {
+                "title": {
+                    "type": "string",
+                    "example": "Praktikum Basis Data"
+                },
+                "userId": {
                     "type": "integer",
                     "example": 1
+                }
+            }
+        },
+        "models.ScheduleImportFormat": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "ics"
+            ],
+            "x-enum-varnames": [
+                "ScheduleImportCSV",
+                "ScheduleImportICS"
+            ]
+        },
+        "models.ScheduleImportReport": {
+            "description": "Result of a regular schedule import",
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "items": {
+                "dryRun": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "format": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ScheduleImportFormat"
+                        }
+                    ],
+                    "example": "csv"
+                },
+                "rows": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Item"
+                        "$ref": "#/definitions/models.ScheduleImportRow"
                     }
                 },
-                "quantity": {
+                "totalRows": {
                     "type": "integer",
-                    "example": 10
+                    "example": 20
                 },
-                "specification": {
-                    "type": "string",
-                    "example": "Komputer Desktop Intel Core i5"
-                },
-                "updatedAt": {
-                    "type": "string",
-                    "example": "2023-01-01T00:00:00Z"
+                "validRows": {
+                    "type": "integer",
+                    "example": 19
                 }
             }
         },
-        "models.RefreshTokenRequest": {
-            "description": "Request body for refreshing access token",
+        "models.ScheduleImportRow": {
+            "description": "Validation result of a single imported row",
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
+                "email": {
                     "type": "string",
-                    "example": "refresh_token_123456789"
+                    "example": "dosen@example.com"
+                },
+                "endDate": {
+                    "type": "string",
+                    "example": "2023-09-04T10:00:00Z"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "idSchedule": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "occurrences": {
+                    "type": "integer",
+                    "example": 16
+                },
+                "room": {
+                    "type": "string",
+                    "example": "Lab 1"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO"
+                },
+                "startDate": {
+                    "type": "string",
+                    "example": "2023-09-04T08:00:00Z"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Praktikum Basis Data"
                 }
             }
         },
@@ -2993,12 +8331,41 @@ const docTemplate = `{
                 "endDate": {
                     "type": "string"
                 },
+                "exDates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "idSchedule": {
                     "type": "integer"
                 },
+                "room": {
+                    "$ref": "#/definitions/models.Room"
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO"
+                },
+                "semester": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SemesterCategory"
+                        }
+                    ],
+                    "example": "Ganjil"
+                },
                 "startDate": {
                     "type": "string"
                 },
+                "tahun": {
+                    "type": "integer",
+                    "example": 2023
+                },
                 "title": {
                     "type": "string"
                 },
@@ -3010,6 +8377,17 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ScheduleSource": {
+            "type": "string",
+            "enum": [
+                "ticket",
+                "reguler"
+            ],
+            "x-enum-varnames": [
+                "ScheduleSourceTicket",
+                "ScheduleSourceReguler"
+            ]
+        },
         "models.ScheduleTicket": {
             "type": "object",
             "properties": {
@@ -3025,9 +8403,18 @@ const docTemplate = `{
                 "idSchedule": {
                     "type": "integer"
                 },
+                "isBooked": {
+                    "type": "boolean"
+                },
                 "kategori": {
                     "$ref": "#/definitions/models.Category"
                 },
+                "room": {
+                    "$ref": "#/definitions/models.Room"
+                },
+                "roomId": {
+                    "type": "integer"
+                },
                 "startDate": {
                     "type": "string"
                 },
@@ -3040,6 +8427,10 @@ const docTemplate = `{
                 "title": {
                     "type": "string"
                 },
+                "unbookedReason": {
+                    "description": "UnbookedReason is set when the overlap guard migration took the slot away",
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 },
@@ -3051,6 +8442,89 @@ const docTemplate = `{
                 }
             }
         },
+        "models.SearchHit": {
+            "description": "Ranked full-text search result",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "headline": {
+                    "type": "string",
+                    "example": "\u003cmark\u003ePeminjaman\u003c/mark\u003e ruang lab"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "rank": {
+                    "type": "number",
+                    "example": 0.6079
+                },
+                "snippet": {
+                    "type": "string",
+                    "example": "untuk \u003cmark\u003epraktikum\u003c/mark\u003e jaringan"
+                },
+                "subtitle": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Peminjaman ruang lab"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SearchType"
+                        }
+                    ],
+                    "example": "ticket"
+                }
+            }
+        },
+        "models.SearchResults": {
+            "description": "Unified full-text search results",
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchHit"
+                    }
+                },
+                "query": {
+                    "type": "string",
+                    "example": "lab jaringan"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.SearchType": {
+            "type": "string",
+            "enum": [
+                "ticket",
+                "item",
+                "schedule_ticket",
+                "schedule_reguler"
+            ],
+            "x-enum-varnames": [
+                "SearchTicket",
+                "SearchItem",
+                "SearchScheduleTicket",
+                "SearchScheduleReguler"
+            ]
+        },
         "models.SemesterCategory": {
             "type": "string",
             "enum": [
@@ -3086,10 +8560,25 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "kategori": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ],
+                    "example": "Praktikum"
+                },
                 "reason": {
                     "type": "string",
                     "example": "No reason provided"
                 },
+                "room": {
+                    "$ref": "#/definitions/models.Room"
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "status": {
                     "allOf": [
                         {
@@ -3208,6 +8697,12 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "kategori": {
+                    "$ref": "#/definitions/models.Category"
+                },
+                "role": {
+                    "type": "string"
+                },
                 "semester": {
                     "$ref": "#/definitions/models.SemesterCategory"
                 },
@@ -3249,6 +8744,9 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Unblocking operation completed successfully"
                 },
+                "meta": {
+                    "$ref": "#/definitions/models.PageMeta"
+                },
                 "success": {
                     "type": "boolean",
                     "example": true
@@ -3261,6 +8759,85 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateItemRequest": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kondisi": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Kondisi"
+                        }
+                    ],
+                    "example": "Rusak Ringan"
+                },
+                "kondisiReason": {
+                    "type": "string",
+                    "example": "Keyboard tidak berfungsi"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PC-001"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Kondisi normal, ready to use"
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 2023
+                }
+            }
+        },
+        "models.UpdateNotificationPreferencesRequest": {
+            "description": "Channels to enable or disable per event type",
+            "type": "object",
+            "required": [
+                "preferences"
+            ],
+            "properties": {
+                "preferences": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.NotificationPreference"
+                    }
+                }
+            }
+        },
+        "models.UpdateRoomRequest": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 40
+                },
+                "closesAt": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Gedung A Lantai 3"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lab 2"
+                },
+                "opensAt": {
+                    "type": "string",
+                    "example": "07:00"
+                }
+            }
+        },
         "models.UpdateScheduleRegulerRequest": {
             "type": "object",
             "properties": {
@@ -3268,10 +8845,40 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2023-12-01T17:00:00Z"
                 },
+                "exDates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=MO;COUNT=16"
+                },
+                "semester": {
+                    "enum": [
+                        "Ganjil",
+                        "Genap"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SemesterCategory"
+                        }
+                    ],
+                    "example": "Ganjil"
+                },
                 "startDate": {
                     "type": "string",
                     "example": "2023-12-01T09:00:00Z"
                 },
+                "tahun": {
+                    "type": "integer",
+                    "example": 2023
+                },
                 "title": {
                     "type": "string",
                     "example": "Updated Schedule Title"
@@ -3297,6 +8904,10 @@ const docTemplate = `{
                     ],
                     "example": "barang"
                 },
+                "roomId": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "startDate": {
                     "type": "string",
                     "example": "2023-12-01T09:00:00Z"
@@ -3329,9 +8940,54 @@ const docTemplate = `{
                     "type": "string",
                     "example": "jane.doe@example.com"
                 },
+                "locale": {
+                    "type": "string",
+                    "enum": [
+                        "id",
+                        "en"
+                    ],
+                    "example": "en"
+                },
                 "name": {
                     "type": "string",
                     "example": "Jane Doe"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "models.UpdateWebhookSubscriptionRequest": {
+            "description": "Request body for updating a webhook subscription",
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Inventory dashboard"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.DomainEventType"
+                    },
+                    "example": [
+                        "ticket.created"
+                    ]
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "rotateSecret": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://lab.example.ac.id/hooks/ketuk"
                 }
             }
         },
@@ -3360,6 +9016,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "locale": {
+                    "description": "Language of notifications, id or en",
+                    "type": "string",
+                    "example": "id"
+                },
                 "name": {
                     "type": "string",
                     "example": "John Doe"
@@ -3373,6 +9034,196 @@ const docTemplate = `{
                     "example": "2023-01-01T00:00:00Z"
                 }
             }
+        },
+        "models.WebhookDelivery": {
+            "description": "Delivery of an event to a webhook subscription",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "durationMs": {
+                    "type": "integer",
+                    "example": 142
+                },
+                "eventId": {
+                    "type": "string",
+                    "example": "7f1c2b9e-5d4a-4c1e-9a43-2f6d1e0b8c71"
+                },
+                "eventType": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DomainEventType"
+                        }
+                    ],
+                    "example": "ticket.created"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "redeliveryOf": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "responseBody": {
+                    "type": "string"
+                },
+                "responseCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.WebhookDeliveryStatus"
+                        }
+                    ],
+                    "example": "succeeded"
+                },
+                "subscriptionId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "WebhookPending",
+                "WebhookSucceeded",
+                "WebhookFailed"
+            ]
+        },
+        "models.WebhookSubscription": {
+            "description": "URL that receives signed domain events",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Inventory dashboard"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DomainEventType"
+                    },
+                    "example": [
+                        "ticket.created",
+                        "item.updated"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://lab.example.ac.id/hooks/ketuk"
+                }
+            }
+        },
+        "models.WebhookSubscriptionWithSecret": {
+            "description": "Webhook subscription including its signing secret",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Inventory dashboard"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DomainEventType"
+                    },
+                    "example": [
+                        "ticket.created",
+                        "item.updated"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "3f9a6c1e0b7d4e2a9c5f8b1d6e3a7c0f"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://lab.example.ac.id/hooks/ketuk"
+                }
+            }
+        },
+        "notify.Email": {
+            "description": "Rendered email with its plain text and HTML alternatives",
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string",
+                    "example": "\u003c!DOCTYPE html\u003e..."
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Tiket #12 Disetujui"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Halo Budi, ..."
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -21,12 +21,19 @@ func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
 }
 
 // @Summary Get ticket event logs
-// @Description Get all event logs for a specific ticket
+// @Description Get a page of event logs for a specific ticket, newest first
 // @Tags audit
 // @Security BearerAuth
 // @Produce json
 // @Param ticket_id path int true "Ticket ID"
-// @Success 200 {object} models.APIResponse{data=[]models.TicketEventLog}
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, createdAt, action; prefix with - for descending (defaults to -id)"
+// @Param action query string false "Only events with this action"
+// @Param from query string false "Logged at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Logged before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.TicketEventLog,meta=models.PageMeta}
 // @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/audit/tickets/{ticket_id}/logs [get]
@@ -42,13 +49,15 @@ func (h *AuditHandler) GetTicketEventLogs(c *gin.Context) {
 		return
 	}
 
-	logs, err := h.auditService.GetTicketEventLogs(ticketID)
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve event logs",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	logs, meta, err := h.auditService.GetTicketEventLogs(ticketID, query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve event logs", err)
 		return
 	}
 
@@ -56,16 +65,24 @@ func (h *AuditHandler) GetTicketEventLogs(c *gin.Context) {
 		Success: true,
 		Message: "Event logs retrieved successfully",
 		Data:    logs,
+		Meta:    meta,
 	})
 }
 
 // @Summary Get event logs by user
-// @Description Get all event logs created by a specific user
+// @Description Get a page of event logs created by a specific user, newest first
 // @Tags audit
 // @Security BearerAuth
 // @Produce json
 // @Param user_id path int true "User ID"
-// @Success 200 {object} models.APIResponse{data=[]models.TicketEventLog}
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, createdAt, action; prefix with - for descending (defaults to -id)"
+// @Param action query string false "Only events with this action"
+// @Param from query string false "Logged at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Logged before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.TicketEventLog,meta=models.PageMeta}
 // @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/audit/users/{user_id}/logs [get]
@@ -81,13 +98,15 @@ func (h *AuditHandler) GetEventLogsByUser(c *gin.Context) {
 		return
 	}
 
-	logs, err := h.auditService.GetEventLogsByUser(userID)
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve event logs",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	logs, meta, err := h.auditService.GetEventLogsByUser(userID, query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve event logs", err)
 		return
 	}
 
@@ -95,5 +114,6 @@ func (h *AuditHandler) GetEventLogsByUser(c *gin.Context) {
 		Success: true,
 		Message: "Event logs retrieved successfully",
 		Data:    logs,
+		Meta:    meta,
 	})
 }
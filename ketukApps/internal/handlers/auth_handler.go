@@ -22,6 +22,7 @@ import (
 // AuthHandler handles authentication-related requests
 type AuthHandler struct {
 	db          *gorm.DB
+	userService *services.UserService
 	googleOAuth *services.GoogleOAuthService
 	stateStore  map[string]time.Time // Simple in-memory store for OAuth state (use Redis in production)
 }
@@ -30,6 +31,7 @@ type AuthHandler struct {
 func NewAuthHandler(db *gorm.DB, googleOAuth *services.GoogleOAuthService) *AuthHandler {
 	return &AuthHandler{
 		db:          db,
+		userService: services.NewUserService(db),
 		googleOAuth: googleOAuth,
 		stateStore:  make(map[string]time.Time),
 	}
@@ -194,7 +196,8 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Role:     "user", // Default role
 	}
 
-	if err := h.db.Create(&user).Error; err != nil {
+	// Created through the user service so the user.created event is published
+	if _, err := h.userService.Create(&user); err != nil {
 		c.JSON(http.StatusInternalServerError, models.APIResponse{
 			Success: false,
 			Message: "Failed to create user",
@@ -502,7 +505,7 @@ func (h *AuthHandler) GoogleCallback(c *gin.Context) {
 			Role:      "user", // Default role
 		}
 
-		if err := h.db.Create(&user).Error; err != nil {
+		if _, err := h.userService.Create(&user); err != nil {
 			c.JSON(http.StatusInternalServerError, models.APIResponse{
 				Success: false,
 				Message: "Failed to create user",
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/queue"
+	"ketukApps/internal/services"
+)
+
+// publishTimeout bounds how long a booking request waits for RabbitMQ
+const publishTimeout = 5 * time.Second
+
+type BookingHandler struct {
+	bookingService *services.BookingService
+	publisher      *queue.BookingPublisher
+}
+
+func NewBookingHandler(bookingService *services.BookingService, publisher *queue.BookingPublisher) *BookingHandler {
+	return &BookingHandler{
+		bookingService: bookingService,
+		publisher:      publisher,
+	}
+}
+
+// @Summary Submit a booking
+// @Description Validate a booking and queue it for processing. Returns 202 with a tracking ID; poll GET /api/bookings/v1/{id} for the outcome. While the message queue is unavailable the booking is accepted as unpublished and queued once it is back. Admins may book for another user with userId. With an Idempotency-Key header a retried request returns the booking of the first one (Idempotent-Replayed: true).
+// @Tags bookings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Unique key of this request, at most 255 characters"
+// @Param booking body models.CreateBookingRequest true "Booking data"
+// @Success 202 {object} models.APIResponse{data=models.BookingRequest}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse "Idempotency key reused for a different request"
+// @Failure 503 {object} models.APIResponse "Booking could not be recorded as unpublished"
+// @Router /api/bookings/v1 [post]
+func (h *BookingHandler) CreateBooking(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	var req models.CreateBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	userID := user.ID
+	if req.UserID != 0 && req.UserID != user.ID {
+		if user.Role != "admin" {
+			c.JSON(http.StatusForbidden, models.APIResponse{
+				Success: false,
+				Message: "Forbidden",
+				Error:   "Only admins can book for another user",
+			})
+			return
+		}
+		userID = req.UserID
+	}
+
+	booking, created, err := h.bookingService.Create(userID, c.GetHeader("Idempotency-Key"), req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrIdempotencyKeyReused) {
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to submit booking",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	// A replayed request whose first attempt was queued is not published again;
+	// the worker would skip it anyway
+	if created {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), publishTimeout)
+		defer cancel()
+		if err := h.publisher.Publish(ctx, booking); err != nil {
+			// The broker may have the message even though the publish timed
+			// out, so the booking is kept and published again later
+			log.Printf("Failed to publish booking %s: %v", booking.ID, err)
+			if err := h.bookingService.MarkUnpublished(booking.ID); err != nil {
+				log.Printf("Failed to mark booking %s as unpublished: %v", booking.ID, err)
+				c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+					Success: false,
+					Message: "Failed to submit booking",
+					Error:   "Message queue unavailable, please try again later",
+				})
+				return
+			}
+			booking.Status = models.BookingUnpublished
+		}
+	} else {
+		c.Header("Idempotent-Replayed", "true")
+	}
+
+	c.Header("Location", "/api/bookings/v1/"+booking.ID)
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: "Booking queued",
+		Data:    booking,
+	})
+}
+
+// @Summary Get booking status
+// @Description Get whether a booking is queued, waiting to be published, processed or rejected, with the resulting ticket ID or the rejection reason. Users can only see their own bookings.
+// @Tags bookings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Tracking ID"
+// @Success 200 {object} models.APIResponse{data=models.BookingRequest}
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/bookings/v1/{id} [get]
+func (h *BookingHandler) GetBooking(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	booking, err := h.bookingService.GetByID(c.Param("id"))
+	if err == nil && user.Role != "admin" && booking.UserID != user.ID {
+		// Other users' bookings are reported as missing
+		err = errors.New("booking not found")
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "booking not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to get booking",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Booking retrieved successfully",
+		Data:    booking,
+	})
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/services"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// @Summary Issue calendar feed token
+// @Description Create a new iCalendar feed token for the current user. Any previous token is revoked. The token is only shown once.
+// @Tags calendar
+// @Security BearerAuth
+// @Produce json
+// @Success 201 {object} models.APIResponse{data=models.CalendarFeedTokenResponse}
+// @Failure 401 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/calendar/v1/token [post]
+func (h *CalendarHandler) CreateFeedToken(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error:   "User not authenticated",
+		})
+		return
+	}
+	userData := user.(models.User)
+
+	token, feedToken, err := h.calendarService.CreateFeedToken(int(userData.ID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to create calendar feed token",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	baseURL := fmt.Sprintf("%s/api/calendar/v1/feeds/%s", requestOrigin(c), token)
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Calendar feed token created successfully",
+		Data: models.CalendarFeedTokenResponse{
+			Token:       token,
+			UserFeedURL: baseURL + "/user.ics",
+			LabFeedURL:  baseURL + "/lab.ics",
+			CreatedAt:   feedToken.CreatedAt,
+		},
+	})
+}
+
+// @Summary Revoke calendar feed token
+// @Description Revoke the current user's iCalendar feed token. Subscribed calendars stop updating.
+// @Tags calendar
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/calendar/v1/token [delete]
+func (h *CalendarHandler) RevokeFeedToken(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error:   "User not authenticated",
+		})
+		return
+	}
+	userData := user.(models.User)
+
+	if err := h.calendarService.RevokeFeedToken(int(userData.ID)); err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "calendar feed token not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to revoke calendar feed token",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Calendar feed token revoked successfully",
+	})
+}
+
+// @Summary Personal iCalendar feed
+// @Description iCalendar feed of the token owner's regular schedules and accepted bookings. Authenticated by the feed token in the URL.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Success 200 {string} string "iCalendar data"
+// @Failure 401 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/calendar/v1/feeds/{token}/user.ics [get]
+func (h *CalendarHandler) GetUserFeed(c *gin.Context) {
+	user, err := h.calendarService.GetUserByFeedToken(c.Param("token"))
+	if err != nil {
+		h.feedTokenError(c, err)
+		return
+	}
+
+	feed, err := h.calendarService.UserFeed(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to build calendar feed",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="ketuk-user.ics"`)
+	c.Data(http.StatusOK, calendarContentType, []byte(feed))
+}
+
+// @Summary Lab iCalendar feed
+// @Description iCalendar feed of all regular schedules and accepted bookings in the lab. Authenticated by any user's feed token in the URL.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Success 200 {string} string "iCalendar data"
+// @Failure 401 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/calendar/v1/feeds/{token}/lab.ics [get]
+func (h *CalendarHandler) GetLabFeed(c *gin.Context) {
+	if _, err := h.calendarService.GetUserByFeedToken(c.Param("token")); err != nil {
+		h.feedTokenError(c, err)
+		return
+	}
+
+	feed, err := h.calendarService.LabFeed()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to build calendar feed",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="ketuk-lab.ics"`)
+	c.Data(http.StatusOK, calendarContentType, []byte(feed))
+}
+
+func (h *CalendarHandler) feedTokenError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if err.Error() == "invalid calendar feed token" {
+		status = http.StatusUnauthorized
+	}
+
+	c.JSON(status, models.APIResponse{
+		Success: false,
+		Message: "Unauthorized",
+		Error:   err.Error(),
+	})
+}
+
+// requestOrigin returns the scheme and host clients reach the API at, as
+// resolved by the PublicOrigin middleware. Forwarded headers are never read
+// here; without the middleware the request's own host is used.
+func requestOrigin(c *gin.Context) string {
+	if origin := c.GetString("request_origin"); origin != "" {
+		return origin
+	}
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
@@ -7,19 +7,31 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"ketukApps/internal/models"
+	"ketukApps/internal/queue"
 )
 
 // HealthCheck godoc
 // @Summary Health check
-// @Description Check if the API is running
+// @Description Check if the API is running. The status is "degraded" while RabbitMQ is (re)connecting; the API itself keeps serving.
 // @Tags health
 // @Produce json
 // @Success 200 {object} models.HealthResponse
 // @Router /health [get]
 func HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, models.HealthResponse{
-		Status:    "healthy",
-		Timestamp: time.Now().Format(time.RFC3339),
-		Version:   "1.0.0",
-	})
+	response := models.HealthResponse{
+		Status:     "healthy",
+		Timestamp:  time.Now().Format(time.RFC3339),
+		Version:    "1.0.0",
+		Components: map[string]models.ComponentHealth{},
+	}
+
+	if queue.RabbitMQClient != nil {
+		rabbitmq := queue.RabbitMQClient.Status()
+		response.Components["rabbitmq"] = rabbitmq
+		if rabbitmq.Status != string(queue.StateConnected) {
+			response.Status = "degraded"
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
 }
@@ -1,15 +1,33 @@
 package handlers
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"ketukApps/internal/models"
 	"ketukApps/internal/services"
+	"ketukApps/internal/utils"
 )
 
+// QR code image sizes in pixels
+const (
+	defaultQRCodeSize = 256
+	minQRCodeSize     = 64
+	maxQRCodeSize     = 1024
+)
+
+// inventoryContentTypes maps export formats to their MIME types
+var inventoryContentTypes = map[models.InventoryFormat]string{
+	models.InventoryCSV:  "text/csv; charset=utf-8",
+	models.InventoryXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
 type ItemHandler struct {
 	itemService *services.ItemService
 }
@@ -86,6 +104,71 @@ func (h *ItemHandler) GetItemCategoryByID(c *gin.Context) {
 	})
 }
 
+// @Summary Get category label sheet
+// @Description Get a printable A4 PDF with an asset tag label for every item in the category (3 x 8 labels per page). Written-off items are skipped.
+// @Tags item-categories
+// @Security BearerAuth
+// @Produce application/pdf
+// @Param id path int true "Category ID"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/item-categories/v1/{id}/labels [get]
+func (h *ItemHandler) GetItemCategoryLabels(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid category ID",
+			Error:   "ID must be a valid integer",
+		})
+		return
+	}
+
+	category, items, err := h.itemService.GetItemsForLabels(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "item category not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve category items",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	labels := make([]utils.AssetLabel, 0, len(items))
+	for _, item := range items {
+		subtitle := category.CategoryName
+		if item.Year != nil {
+			subtitle = fmt.Sprintf("%s - %d", subtitle, *item.Year)
+		}
+		labels = append(labels, utils.AssetLabel{
+			Tag:      utils.AssetTag(item.ID),
+			Title:    item.Name,
+			Subtitle: subtitle,
+			Content:  itemLookupURL(c, item.ID),
+		})
+	}
+
+	sheet, err := utils.AssetLabelSheet("Ketuk labels - "+category.CategoryName, labels)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to generate label sheet",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="ketuk-labels-%d.pdf"`, category.ID))
+	c.Data(http.StatusOK, "application/pdf", sheet)
+}
+
 // @Summary Create a new item category
 // @Description Create a new item category
 // @Tags item-categories
@@ -232,20 +315,30 @@ func (h *ItemHandler) DeleteItemCategory(c *gin.Context) {
 // Item Handlers
 
 // @Summary Get all items
-// @Description Get a list of all items
+// @Description Get a page of items. Filters accept comma separated values.
 // @Tags items
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {object} models.APIResponse{data=[]models.Item}
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, name, year, kondisi, createdAt, updatedAt; prefix with - for descending (defaults to id)"
+// @Param categoryId query int false "Only items in this category"
+// @Param kondisi query string false "Only items with this kondisi"
+// @Param year query int false "Only items procured in this year"
+// @Success 200 {object} models.APIResponse{data=[]models.Item,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
 // @Router /api/items/v1 [get]
 func (h *ItemHandler) GetAllItems(c *gin.Context) {
-	items, err := h.itemService.GetAllItems()
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve items",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	items, meta, err := h.itemService.GetAllItems(query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve items", err)
 		return
 	}
 
@@ -253,6 +346,7 @@ func (h *ItemHandler) GetAllItems(c *gin.Context) {
 		Success: true,
 		Message: "Items retrieved successfully",
 		Data:    items,
+		Meta:    meta,
 	})
 }
 
@@ -296,12 +390,18 @@ func (h *ItemHandler) GetItemByID(c *gin.Context) {
 }
 
 // @Summary Get items by category ID
-// @Description Get all items for a specific category
+// @Description Get a page of items for a specific category
 // @Tags items
 // @Security BearerAuth
 // @Produce json
 // @Param category_id path int true "Category ID"
-// @Success 200 {object} models.APIResponse{data=[]models.Item}
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, name, year, kondisi, createdAt, updatedAt; prefix with - for descending (defaults to id)"
+// @Param kondisi query string false "Only items with this kondisi"
+// @Param year query int false "Only items procured in this year"
+// @Success 200 {object} models.APIResponse{data=[]models.Item,meta=models.PageMeta}
 // @Failure 400 {object} models.APIResponse
 // @Router /api/items/v1/category/{category_id} [get]
 func (h *ItemHandler) GetItemsByCategoryID(c *gin.Context) {
@@ -316,13 +416,15 @@ func (h *ItemHandler) GetItemsByCategoryID(c *gin.Context) {
 		return
 	}
 
-	items, err := h.itemService.GetItemsByCategoryID(categoryID)
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve items for category",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	items, meta, err := h.itemService.GetItemsByCategoryID(categoryID, query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve items for category", err)
 		return
 	}
 
@@ -330,12 +432,13 @@ func (h *ItemHandler) GetItemsByCategoryID(c *gin.Context) {
 		Success: true,
 		Message: "Items retrieved successfully",
 		Data:    items,
+		Meta:    meta,
 	})
 }
 
 // NOTE: This endpoint is not registered in the router - comment out to hide from Swagger
 // //@Summary Search items
-// //@Description Search items by name or note
+// //@Description Full-text search of items by name, note and category, best match first
 // //@Tags items
 // //@Produce json
 // //@Param q query string true "Search query"
@@ -369,31 +472,49 @@ func (h *ItemHandler) SearchItems(c *gin.Context) {
 	})
 }
 
-// NOTE: This endpoint is not registered in the router - comment out to hide from Swagger
-// //@Summary Get items by kondisi
-// //@Description Get all items with a specific condition
-// //@Tags items
-// //@Produce json
-// //@Param kondisi path string true "Item Kondisi (Baik, Rusak Ringan, Rusak Berat)"
-// //@Success 200 {object} models.APIResponse
-// //@Router /api/items/v1/kondisi/{kondisi} [get]
+// @Summary Get items by kondisi
+// @Description Get a page of items with a specific condition
+// @Tags items
+// @Security BearerAuth
+// @Produce json
+// @Param kondisi path string true "Item Kondisi" Enums(Baik, Rusak Ringan, Rusak Berat, Dalam Perbaikan, Dihapuskan)
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, name, year, kondisi, createdAt, updatedAt; prefix with - for descending (defaults to id)"
+// @Param categoryId query int false "Only items in this category"
+// @Param year query int false "Only items procured in this year"
+// @Success 200 {object} models.APIResponse{data=[]models.Item,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
+// @Router /api/items/v1/kondisi/{kondisi} [get]
 func (h *ItemHandler) GetItemsByKondisi(c *gin.Context) {
-	kondisi := c.Param("kondisi")
-
-	items, err := h.itemService.GetItemsByKondisi(kondisi)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+	kondisi := models.Kondisi(c.Param("kondisi"))
+	if !kondisi.IsValid() {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
-			Message: "Failed to retrieve items by condition",
-			Error:   err.Error(),
+			Message: "Invalid kondisi",
+			Error:   "Kondisi must be one of Baik, Rusak Ringan, Rusak Berat, Dalam Perbaikan, Dihapuskan",
 		})
 		return
 	}
 
+	query, err := parseListQuery(c)
+	if err != nil {
+		invalidListQuery(c, err)
+		return
+	}
+
+	items, meta, err := h.itemService.GetItemsByKondisi(kondisi, query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve items by condition", err)
+		return
+	}
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Message: "Items retrieved successfully",
 		Data:    items,
+		Meta:    meta,
 	})
 }
 
@@ -427,7 +548,7 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 		CategoryID: item.CategoryID,
 	}
 
-	createdItem, err := h.itemService.CreateItem(&itemCreate)
+	createdItem, err := h.itemService.CreateItem(&itemCreate, userIDOf(c))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
@@ -445,13 +566,13 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 }
 
 // @Summary Update item
-// @Description Update item information by ID. All fields are optional.
+// @Description Update item information by ID. All fields are optional. A kondisi change must follow the allowed transitions and needs a kondisiReason.
 // @Tags items
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "Item ID"
-// @Param updates body map[string]interface{} true "Updated item data (name, year, kondisi, note, categoryId)"
+// @Param updates body models.UpdateItemRequest true "Updated item data"
 // @Success 200 {object} models.APIResponse{data=models.Item}
 // @Failure 400 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
@@ -468,8 +589,8 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		return
 	}
 
-	var updates map[string]interface{}
-	if err := c.ShouldBindJSON(&updates); err != nil {
+	var req models.UpdateItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Message: "Invalid request body",
@@ -478,7 +599,7 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		return
 	}
 
-	item, err := h.itemService.UpdateItem(id, updates)
+	item, err := h.itemService.UpdateItem(id, req, userIDOf(c))
 	if err != nil {
 		status := http.StatusBadRequest
 		if err.Error() == "item not found" {
@@ -500,6 +621,353 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 	})
 }
 
+// @Summary Change item kondisi
+// @Description Move an item to another kondisi. Allowed transitions: Baik -> Rusak Ringan/Rusak Berat/Dalam Perbaikan/Dihapuskan; Rusak Ringan -> Baik/Rusak Berat/Dalam Perbaikan/Dihapuskan; Rusak Berat -> Dalam Perbaikan/Dihapuskan; Dalam Perbaikan -> Baik/Rusak Ringan/Rusak Berat/Dihapuskan. Dihapuskan is final.
+// @Tags items
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Item ID"
+// @Param kondisi body models.ChangeKondisiRequest true "New kondisi and reason"
+// @Success 200 {object} models.APIResponse{data=models.Item}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/items/v1/{id}/kondisi [patch]
+func (h *ItemHandler) ChangeItemKondisi(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid item ID",
+			Error:   "ID must be a valid integer",
+		})
+		return
+	}
+
+	var req models.ChangeKondisiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	item, err := h.itemService.ChangeItemKondisi(id, req, userIDOf(c))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "item not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to change item kondisi",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Item kondisi changed successfully",
+		Data:    item,
+	})
+}
+
+// @Summary Get item kondisi history
+// @Description Get the kondisi timeline of an item, oldest first, with who changed it and why
+// @Tags items
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Item ID"
+// @Success 200 {object} models.APIResponse{data=[]models.ItemKondisiHistory}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/items/v1/{id}/history [get]
+func (h *ItemHandler) GetItemKondisiHistory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid item ID",
+			Error:   "ID must be a valid integer",
+		})
+		return
+	}
+
+	history, err := h.itemService.GetItemKondisiHistory(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "item not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve item history",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Item history retrieved successfully",
+		Data:    history,
+	})
+}
+
+// @Summary Export inventory
+// @Description Export every item with its category as CSV or XLSX. The file uses the same columns as the import: name, category, year, kondisi, note, specification.
+// @Tags items
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv or xlsx (defaults to csv)"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/items/v1/export [get]
+func (h *ItemHandler) ExportItems(c *gin.Context) {
+	format := models.InventoryFormat(strings.ToLower(c.DefaultQuery("format", string(models.InventoryCSV))))
+	contentType, ok := inventoryContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid export format",
+			Error:   "Format must be either 'csv' or 'xlsx'",
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.itemService.ExportItems(&buf, format); err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to export items",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="ketuk-inventory.%s"`, format))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// @Summary Import inventory
+// @Description Import items from a CSV or XLSX file (first worksheet). Items are matched by name within their category: existing items are updated, others are created. Every row is validated (category, year, kondisi and its allowed transitions). With dryRun=true only the report is returned; otherwise all rows are written in one transaction, or none if any row is invalid. Category quantities are recounted afterwards.
+// @Description Columns: name, category, year, kondisi, note, specification. Only name and category are required; blank cells keep the current values of existing items and specification is only used for categories created by the import.
+// @Tags items
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file"
+// @Param format formData string false "csv or xlsx (defaults to the file extension)"
+// @Param createCategories formData bool false "Create categories that do not exist yet instead of rejecting the row"
+// @Param dryRun query bool false "Validate only, do not write anything"
+// @Success 200 {object} models.APIResponse{data=models.ItemImportReport}
+// @Success 201 {object} models.APIResponse{data=models.ItemImportReport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse{data=models.ItemImportReport}
+// @Router /api/items/v1/import [post]
+func (h *ItemHandler) ImportItems(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid import file",
+			Error:   "file is required",
+		})
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid import file",
+			Error:   "file must not be larger than 5 MB",
+		})
+		return
+	}
+
+	format := models.InventoryFormat(strings.ToLower(c.PostForm("format")))
+	if format == "" {
+		format = models.InventoryFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), "."))
+	}
+	createCategories, _ := strconv.ParseBool(c.DefaultPostForm("createCategories", "false"))
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid import file",
+			Error:   err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	report, err := h.itemService.ImportItems(file, format, createCategories, dryRun, userIDOf(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Failed to import items",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	switch {
+	case report.ValidRows != report.TotalRows:
+		c.JSON(http.StatusUnprocessableEntity, models.APIResponse{
+			Success: false,
+			Message: "Import contains invalid rows, nothing was written",
+			Data:    report,
+			Error:   fmt.Sprintf("%d of %d rows are invalid", report.TotalRows-report.ValidRows, report.TotalRows),
+		})
+	case report.DryRun:
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Message: "Import validated successfully, nothing was written",
+			Data:    report,
+		})
+	default:
+		c.JSON(http.StatusCreated, models.APIResponse{
+			Success: true,
+			Message: "Items imported successfully",
+			Data:    report,
+		})
+	}
+}
+
+// @Summary Get item QR code
+// @Description Get the QR code of an item's asset tag as PNG or SVG. The code encodes the lookup URL of the item.
+// @Tags items
+// @Security BearerAuth
+// @Produce image/png
+// @Produce image/svg+xml
+// @Param id path int true "Item ID"
+// @Param format query string false "png or svg (defaults to png)"
+// @Param size query int false "Image size in pixels, 64 to 1024 (defaults to 256)"
+// @Success 200 {file} file
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/items/v1/{id}/qr [get]
+func (h *ItemHandler) GetItemQRCode(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid item ID",
+			Error:   "ID must be a valid integer",
+		})
+		return
+	}
+
+	size, err := strconv.Atoi(c.DefaultQuery("size", strconv.Itoa(defaultQRCodeSize)))
+	if err != nil || size < minQRCodeSize || size > maxQRCodeSize {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid QR code size",
+			Error:   fmt.Sprintf("Size must be between %d and %d pixels", minQRCodeSize, maxQRCodeSize),
+		})
+		return
+	}
+
+	item, err := h.itemService.GetItemByID(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "item not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Item not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "png"))
+	var image []byte
+	var contentType string
+	switch format {
+	case "png":
+		image, err = utils.QRCodePNG(itemLookupURL(c, item.ID), size)
+		contentType = "image/png"
+	case "svg":
+		image, err = utils.QRCodeSVG(itemLookupURL(c, item.ID), size)
+		contentType = "image/svg+xml"
+	default:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid QR code format",
+			Error:   "Format must be either 'png' or 'svg'",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to generate QR code",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.%s"`, utils.AssetTag(item.ID), format))
+	c.Data(http.StatusOK, contentType, image)
+}
+
+// @Summary Look up item by asset tag
+// @Description Resolve a scanned label to the item and its current status. Accepts the asset tag (KTK-000012) or the item ID. Items that are checked out are on_loan; items whose kondisi does not allow lending are unavailable. The next approved loan is included when there is one.
+// @Tags items
+// @Security BearerAuth
+// @Produce json
+// @Param tag path string true "Asset tag or item ID"
+// @Success 200 {object} models.APIResponse{data=models.ItemLookup}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/items/v1/lookup/{tag} [get]
+func (h *ItemHandler) LookupItem(c *gin.Context) {
+	id, err := utils.ParseAssetTag(c.Param("tag"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid asset tag",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	lookup, err := h.itemService.LookupItem(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "item not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Item not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Item retrieved successfully",
+		Data:    lookup,
+	})
+}
+
 // @Summary Delete item
 // @Description Delete an item by ID
 // @Tags items
@@ -562,3 +1030,8 @@ func (h *ItemHandler) GetItemStatistics(c *gin.Context) {
 		Data:    stats,
 	})
 }
+
+// itemLookupURL is the URL encoded in an item's QR code
+func itemLookupURL(c *gin.Context, itemID int) string {
+	return fmt.Sprintf("%s/api/items/v1/lookup/%s", requestOrigin(c), utils.AssetTag(itemID))
+}
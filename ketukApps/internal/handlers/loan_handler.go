@@ -0,0 +1,504 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/services"
+)
+
+type LoanHandler struct {
+	loanService *services.LoanService
+}
+
+func NewLoanHandler(loanService *services.LoanService) *LoanHandler {
+	return &LoanHandler{
+		loanService: loanService,
+	}
+}
+
+// @Summary Get all loans
+// @Description Get a page of equipment loans. Filters accept comma separated values.
+// @Tags loans
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, status; prefix with - for descending (defaults to -startDate)"
+// @Param status query string false "Loan status (requested, approved, rejected, checked_out, returned)"
+// @Param overdue query bool false "Only overdue (true) or not overdue (false) loans"
+// @Param userId query int false "Only loans requested by this user"
+// @Param from query string false "Starting at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.ItemLoan,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/loans/v1 [get]
+func (h *LoanHandler) GetAllLoans(c *gin.Context) {
+	query, err := parseListQuery(c)
+	if err != nil {
+		invalidListQuery(c, err)
+		return
+	}
+
+	loans, meta, err := h.loanService.GetAll(query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve loans", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Loans retrieved successfully",
+		Data:    loans,
+		Meta:    meta,
+	})
+}
+
+// @Summary Get loan by ID
+// @Description Get an equipment loan by its ID. Users can only see their own loans.
+// @Tags loans
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {object} models.APIResponse{data=models.ItemLoan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/loans/v1/{id} [get]
+func (h *LoanHandler) GetLoanByID(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+	id, ok := parseLoanID(c)
+	if !ok {
+		return
+	}
+
+	loan, err := h.loanService.GetByID(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "loan not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Loan not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if user.Role != "admin" && loan.UserID != user.ID {
+		forbiddenLoans(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Loan retrieved successfully",
+		Data:    loan,
+	})
+}
+
+// @Summary Get loans by user ID
+// @Description Get a page of equipment loans requested by a user. Users can only list their own loans.
+// @Tags loans
+// @Security BearerAuth
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, status; prefix with - for descending (defaults to -startDate)"
+// @Param status query string false "Loan status (requested, approved, rejected, checked_out, returned)"
+// @Param overdue query bool false "Only overdue (true) or not overdue (false) loans"
+// @Success 200 {object} models.APIResponse{data=[]models.ItemLoan,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 403 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/loans/v1/user/{user_id} [get]
+func (h *LoanHandler) GetLoansByUserID(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+	userIDParam := c.Param("user_id")
+	userID, err := strconv.Atoi(userIDParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid user ID",
+			Error:   "User ID must be a valid integer",
+		})
+		return
+	}
+	if user.Role != "admin" && uint(userID) != user.ID {
+		forbiddenLoans(c)
+		return
+	}
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		invalidListQuery(c, err)
+		return
+	}
+
+	loans, meta, err := h.loanService.GetByUserID(uint(userID), query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve loans", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "User loans retrieved successfully",
+		Data:    loans,
+		Meta:    meta,
+	})
+}
+
+// @Summary Check item availability
+// @Description List approved or checked out loans that hold any of the items in the given window. An empty list means every item is available.
+// @Tags loans
+// @Security BearerAuth
+// @Produce json
+// @Param itemIds query string true "Comma separated item IDs"
+// @Param from query string true "Window start (RFC3339 or YYYY-MM-DD)"
+// @Param to query string true "Window end (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.LoanConflict}
+// @Failure 400 {object} models.APIResponse
+// @Router /api/loans/v1/availability [get]
+func (h *LoanHandler) CheckAvailability(c *gin.Context) {
+	var itemIDs []int
+	for _, value := range strings.Split(c.Query("itemIds"), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Invalid item IDs",
+				Error:   "itemIds must be a comma separated list of integers",
+			})
+			return
+		}
+		itemIDs = append(itemIDs, id)
+	}
+	if len(itemIDs) == 0 {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid item IDs",
+			Error:   "itemIds is required",
+		})
+		return
+	}
+
+	from, to, windowed, err := parseDateWindow(c)
+	if err == nil && !windowed {
+		err = errors.New("from and to are required")
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid date window",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	conflicts, err := h.loanService.CheckAvailability(itemIDs, from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Failed to check availability",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Availability checked successfully",
+		Data:    conflicts,
+	})
+}
+
+// @Summary Request an equipment loan
+// @Description Request one or more items for a time window. A pending ticket is created for admin approval.
+// @Tags loans
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param loan body models.CreateLoanRequest true "Loan request"
+// @Success 201 {object} models.APIResponse{data=models.ItemLoan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse{data=[]models.LoanConflict}
+// @Router /api/loans/v1 [post]
+func (h *LoanHandler) CreateLoan(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, models.APIResponse{
+			Success: false,
+			Message: "Unauthorized",
+			Error:   "User not authenticated",
+		})
+		return
+	}
+	userData := user.(models.User)
+
+	var req models.CreateLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	loan, err := h.loanService.Create(userData.ID, req)
+	if err != nil {
+		h.loanError(c, "Failed to create loan", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Loan requested successfully",
+		Data:    loan,
+	})
+}
+
+// @Summary Approve loan
+// @Description Approve an equipment loan. This accepts its ticket and emails the borrower.
+// @Tags loans
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Param decision body models.LoanDecisionRequest false "Approval reason"
+// @Success 200 {object} models.APIResponse{data=models.ItemLoan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse{data=[]models.LoanConflict}
+// @Router /api/loans/v1/{id}/approve [patch]
+func (h *LoanHandler) ApproveLoan(c *gin.Context) {
+	h.decide(c, true)
+}
+
+// @Summary Reject loan
+// @Description Reject an equipment loan. This rejects its ticket and emails the borrower.
+// @Tags loans
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Param decision body models.LoanDecisionRequest false "Rejection reason"
+// @Success 200 {object} models.APIResponse{data=models.ItemLoan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/loans/v1/{id}/reject [patch]
+func (h *LoanHandler) RejectLoan(c *gin.Context) {
+	h.decide(c, false)
+}
+
+func (h *LoanHandler) decide(c *gin.Context, approve bool) {
+	id, ok := parseLoanID(c)
+	if !ok {
+		return
+	}
+
+	// The reason is optional, so an empty body is fine
+	var req models.LoanDecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
+
+	adminUser := currentUser(c)
+	var loan *models.ItemLoan
+	var err error
+	if approve {
+		loan, err = h.loanService.Approve(id, req.Reason, adminUser)
+	} else {
+		loan, err = h.loanService.Reject(id, req.Reason, adminUser)
+	}
+	if err != nil {
+		h.loanError(c, "Failed to update loan", err)
+		return
+	}
+
+	message := "Loan rejected successfully"
+	if approve {
+		message = "Loan approved successfully"
+	}
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    loan,
+	})
+}
+
+// @Summary Check out loan
+// @Description Hand the items of an approved loan to the borrower
+// @Tags loans
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Success 200 {object} models.APIResponse{data=models.ItemLoan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse{data=[]models.LoanConflict}
+// @Router /api/loans/v1/{id}/checkout [post]
+func (h *LoanHandler) CheckOutLoan(c *gin.Context) {
+	id, ok := parseLoanID(c)
+	if !ok {
+		return
+	}
+
+	loan, err := h.loanService.CheckOut(id, currentUser(c))
+	if err != nil {
+		h.loanError(c, "Failed to check out loan", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Loan checked out successfully",
+		Data:    loan,
+	})
+}
+
+// @Summary Check in loan
+// @Description Record the return of a checked out loan with a condition note. Per-item conditions also update the item's kondisi.
+// @Tags loans
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan ID"
+// @Param checkin body models.CheckInLoanRequest true "Return condition"
+// @Success 200 {object} models.APIResponse{data=models.ItemLoan}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/loans/v1/{id}/checkin [post]
+func (h *LoanHandler) CheckInLoan(c *gin.Context) {
+	id, ok := parseLoanID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CheckInLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	loan, err := h.loanService.CheckIn(id, req, currentUser(c))
+	if err != nil {
+		h.loanError(c, "Failed to check in loan", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Loan checked in successfully",
+		Data:    loan,
+	})
+}
+
+func (h *LoanHandler) loanError(c *gin.Context, message string, err error) {
+	var conflictErr *services.LoanConflictError
+	if errors.As(err, &conflictErr) {
+		c.JSON(http.StatusConflict, models.APIResponse{
+			Success: false,
+			Message: "Loan items are not available",
+			Data:    conflictErr.Conflicts,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	status := http.StatusBadRequest
+	if err.Error() == "loan not found" || err.Error() == "ticket not found" {
+		status = http.StatusNotFound
+	}
+
+	c.JSON(status, models.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+func parseLoanID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid loan ID",
+			Error:   "ID must be a valid integer",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// currentUser returns the authenticated user, or nil when the context has none
+func currentUser(c *gin.Context) *models.User {
+	if user, exists := c.Get("user"); exists {
+		if u, ok := user.(models.User); ok {
+			return &u
+		}
+	}
+	return nil
+}
+
+// notAuthenticated responds to a request without an authenticated user
+func notAuthenticated(c *gin.Context) {
+	c.JSON(http.StatusUnauthorized, models.APIResponse{
+		Success: false,
+		Message: "Unauthorized",
+		Error:   "User not authenticated",
+	})
+}
+
+// userIDOf returns the ID of the authenticated user, or nil when the context has none
+func userIDOf(c *gin.Context) *uint {
+	if user := currentUser(c); user != nil {
+		return &user.ID
+	}
+	return nil
+}
+
+// forbiddenLoans responds to a user asking for someone else's loans
+func forbiddenLoans(c *gin.Context) {
+	c.JSON(http.StatusForbidden, models.APIResponse{
+		Success: false,
+		Message: "Forbidden",
+		Error:   "Users can only see their own loans",
+	})
+}
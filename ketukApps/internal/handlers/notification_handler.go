@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/services"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// @Summary Get my notifications
+// @Description Get a page of the authenticated user's in-app notifications
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, createdAt; prefix with - for descending (defaults to -id)"
+// @Param event query string false "Event type (ticket.created, ticket.status_changed, booking.closed)"
+// @Param unread query bool false "Only unread (true) or read (false) notifications"
+// @Param from query string false "Created at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.Notification,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/notifications/v1 [get]
+func (h *NotificationHandler) GetMyNotifications(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		invalidListQuery(c, err)
+		return
+	}
+
+	notifications, meta, err := h.notificationService.GetByUserID(user.ID, query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve notifications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notifications retrieved successfully",
+		Data:    notifications,
+		Meta:    meta,
+	})
+}
+
+// @Summary Mark a notification as read
+// @Description Mark one of the authenticated user's notifications as read
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} models.APIResponse{data=models.Notification}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/notifications/v1/{id}/read [patch]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid notification ID",
+			Error:   "Notification ID must be a valid integer",
+		})
+		return
+	}
+
+	notification, err := h.notificationService.MarkRead(id, user.ID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "notification not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to mark notification as read",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notification marked as read",
+		Data:    notification,
+	})
+}
+
+// @Summary Mark all notifications as read
+// @Description Mark every unread notification of the authenticated user as read
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=map[string]int64}
+// @Failure 401 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/notifications/v1/read-all [patch]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	updated, err := h.notificationService.MarkAllRead(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to mark notifications as read",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notifications marked as read",
+		Data:    gin.H{"updated": updated},
+	})
+}
+
+// @Summary Get my notification preferences
+// @Description List every event and channel combination and whether the authenticated user receives it
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=[]models.NotificationPreference}
+// @Failure 401 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/notifications/v1/preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	prefs, err := h.notificationService.GetPreferences(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve notification preferences",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notification preferences retrieved successfully",
+		Data:    prefs,
+	})
+}
+
+// @Summary Update my notification preferences
+// @Description Enable or disable channels per event type for the authenticated user. Combinations not listed keep their current setting.
+// @Tags notifications
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body models.UpdateNotificationPreferencesRequest true "Preferences to change"
+// @Success 200 {object} models.APIResponse{data=[]models.NotificationPreference}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/notifications/v1/preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	var req models.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	prefs, err := h.notificationService.UpdatePreferences(user.ID, req.Preferences)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid preference") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to update notification preferences",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Notification preferences updated successfully",
+		Data:    prefs,
+	})
+}
+
+// @Summary Preview a notification email
+// @Description Render the email template of an event with sample data. Templates overridden in NOTIFY_TEMPLATE_DIR are used when present.
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Produce html
+// @Produce plain
+// @Param event path string true "Event type (ticket.created, ticket.status_changed, booking.closed)"
+// @Param locale query string false "Language: id or en (defaults to id)"
+// @Param format query string false "json (subject, text and HTML), html or text (defaults to json)"
+// @Success 200 {object} models.APIResponse{data=notify.Email}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/notifications/v1/templates/{event}/preview [get]
+func (h *NotificationHandler) PreviewTemplate(c *gin.Context) {
+	locale := c.DefaultQuery("locale", models.LocaleID)
+	if locale != models.LocaleID && locale != models.LocaleEN {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid locale",
+			Error:   "locale must be id or en",
+		})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "html" && format != "text" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid format",
+			Error:   "format must be json, html or text",
+		})
+		return
+	}
+
+	email, err := h.notificationService.PreviewTemplate(models.NotificationEvent(c.Param("event")), locale)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "notification event not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to render template",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	switch format {
+	case "html":
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(email.HTML))
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(email.Subject+"\n\n"+email.Text))
+	default:
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Message: "Template rendered successfully",
+			Data:    email,
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/services"
+)
+
+// listParams are the query parameters every list endpoint reads itself
+var listParams = map[string]bool{
+	"page":   true,
+	"limit":  true,
+	"cursor": true,
+	"sort":   true,
+	"from":   true,
+	"to":     true,
+}
+
+// parseListQuery reads the pagination, sort, date range and filter query
+// parameters shared by every list endpoint. Every other parameter is a
+// filter; each endpoint decides which filters it supports and rejects the
+// rest.
+func parseListQuery(c *gin.Context) (models.ListQuery, error) {
+	query := models.ListQuery{
+		Cursor:  c.Query("cursor"),
+		Sort:    c.Query("sort"),
+		Filters: make(map[string]string),
+	}
+	for name, values := range c.Request.URL.Query() {
+		if len(values) > 0 && !listParams[name] {
+			query.Filters[name] = values[0]
+		}
+	}
+
+	if value := c.Query("page"); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return query, errors.New("page must be a positive integer")
+		}
+		query.Page = page
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > services.MaxPageLimit {
+			return query, fmt.Errorf("limit must be between 1 and %d", services.MaxPageLimit)
+		}
+		query.Limit = limit
+	}
+
+	if value := c.Query("from"); value != "" {
+		from, err := parseDateParam(value)
+		if err != nil {
+			return query, fmt.Errorf("invalid from: %w", err)
+		}
+		query.From = &from
+	}
+	if value := c.Query("to"); value != "" {
+		to, err := parseDateParam(value)
+		if err != nil {
+			return query, fmt.Errorf("invalid to: %w", err)
+		}
+		if len(value) == len("2006-01-02") {
+			to = to.AddDate(0, 0, 1)
+		}
+		query.To = &to
+	}
+	return query, nil
+}
+
+// listQueryError responds to a failed list query, with 400 for bad parameters
+func listQueryError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, services.ErrInvalidListQuery) {
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, models.APIResponse{
+		Success: false,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
+
+// invalidListQuery responds to list parameters that could not be parsed
+func invalidListQuery(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, models.APIResponse{
+		Success: false,
+		Message: "Invalid list parameters",
+		Error:   err.Error(),
+	})
+}
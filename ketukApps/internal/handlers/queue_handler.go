@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/queue"
+)
+
+type QueueHandler struct {
+	deadLetters *queue.DeadLetters
+}
+
+func NewQueueHandler(deadLetters *queue.DeadLetters) *QueueHandler {
+	return &QueueHandler{
+		deadLetters: deadLetters,
+	}
+}
+
+// @Summary List dead letters
+// @Description List messages that failed permanently or ran out of retries, oldest first, with the failure reason. The messages stay in the dead-letter queue.
+// @Tags queue
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Maximum number of messages, 1 to 100 (defaults to 20)"
+// @Success 200 {object} models.APIResponse{data=[]models.DeadLetter}
+// @Failure 400 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /api/queue/v1/dead-letters [get]
+func (h *QueueHandler) ListDeadLetters(c *gin.Context) {
+	limit := 20
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 100 {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Invalid limit",
+				Error:   "limit must be between 1 and 100",
+			})
+			return
+		}
+		limit = n
+	}
+
+	letters, err := h.deadLetters.List(limit)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success: false,
+			Message: "Failed to read dead letters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Dead letters retrieved successfully",
+		Data:    letters,
+	})
+}
+
+// @Summary Replay dead letters
+// @Description Send dead letters back to their original queue with their attempt count reset. Without IDs every dead letter is replayed.
+// @Tags queue
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body models.ReplayDeadLettersRequest false "Dead letters to replay"
+// @Success 200 {object} models.APIResponse{data=models.ReplayDeadLettersResponse}
+// @Failure 400 {object} models.APIResponse
+// @Failure 503 {object} models.APIResponse
+// @Router /api/queue/v1/dead-letters/replay [post]
+func (h *QueueHandler) ReplayDeadLetters(c *gin.Context) {
+	var req models.ReplayDeadLettersRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	replayed, err := h.deadLetters.Replay(req.IDs)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, models.APIResponse{
+			Success: false,
+			Message: "Failed to replay dead letters",
+			Data:    models.ReplayDeadLettersResponse{Replayed: replayed},
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Dead letters replayed successfully",
+		Data:    models.ReplayDeadLettersResponse{Replayed: replayed},
+	})
+}
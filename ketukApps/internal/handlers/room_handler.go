@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/services"
+)
+
+type RoomHandler struct {
+	roomService *services.RoomService
+}
+
+func NewRoomHandler(roomService *services.RoomService) *RoomHandler {
+	return &RoomHandler{
+		roomService: roomService,
+	}
+}
+
+// @Summary Get all rooms
+// @Description Get a list of all bookable rooms
+// @Tags rooms
+// @Security BearerAuth
+// @Produce json
+// @Param active query bool false "Only return active rooms"
+// @Success 200 {object} models.APIResponse{data=[]models.Room}
+// @Failure 500 {object} models.APIResponse
+// @Router /api/rooms/v1 [get]
+func (h *RoomHandler) GetAllRooms(c *gin.Context) {
+	activeOnly, _ := strconv.ParseBool(c.DefaultQuery("active", "false"))
+
+	rooms, err := h.roomService.GetAll(activeOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve rooms",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Rooms retrieved successfully",
+		Data:    rooms,
+	})
+}
+
+// @Summary Get room by ID
+// @Description Get a room by its ID
+// @Tags rooms
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Room ID"
+// @Success 200 {object} models.APIResponse{data=models.Room}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/rooms/v1/{id} [get]
+func (h *RoomHandler) GetRoomByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid room ID",
+			Error:   "ID must be a valid integer",
+		})
+		return
+	}
+
+	room, err := h.roomService.GetByID(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "room not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Room not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Room retrieved successfully",
+		Data:    room,
+	})
+}
+
+// @Summary Create a new room
+// @Description Create a new bookable room. Opening hours are optional and use HH:MM in lab time.
+// @Tags rooms
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param room body models.CreateRoomRequest true "Room data"
+// @Success 201 {object} models.APIResponse{data=models.Room}
+// @Failure 400 {object} models.APIResponse
+// @Router /api/rooms/v1 [post]
+func (h *RoomHandler) CreateRoom(c *gin.Context) {
+	var req models.CreateRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	room, err := h.roomService.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Failed to create room",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Room created successfully",
+		Data:    room,
+	})
+}
+
+// @Summary Update room
+// @Description Update room information by ID. All fields are optional; send empty opensAt and closesAt to clear the opening hours.
+// @Tags rooms
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Room ID"
+// @Param updates body models.UpdateRoomRequest true "Updated room data"
+// @Success 200 {object} models.APIResponse{data=models.Room}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/rooms/v1/{id} [put]
+func (h *RoomHandler) UpdateRoom(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid room ID",
+			Error:   "ID must be a valid integer",
+		})
+		return
+	}
+
+	var req models.UpdateRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	room, err := h.roomService.Update(id, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "room not found" {
+			status = http.StatusNotFound
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to update room",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Room updated successfully",
+		Data:    room,
+	})
+}
+
+// @Summary Delete room
+// @Description Delete a room that has never been booked. Rooms with schedules or tickets must be deactivated instead.
+// @Tags rooms
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Room ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse
+// @Router /api/rooms/v1/{id} [delete]
+func (h *RoomHandler) DeleteRoom(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid room ID",
+			Error:   "ID must be a valid integer",
+		})
+		return
+	}
+
+	if err := h.roomService.Delete(id); err != nil {
+		status := http.StatusInternalServerError
+		switch err.Error() {
+		case "room not found":
+			status = http.StatusNotFound
+		case "room is still referenced by schedules or tickets, deactivate it instead":
+			status = http.StatusConflict
+		}
+
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to delete room",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Room deleted successfully",
+	})
+}
+
+// parseRoomFilter reads the optional roomId query parameter; 0 means all rooms
+func parseRoomFilter(c *gin.Context) (int, error) {
+	value := c.Query("roomId")
+	if value == "" {
+		return 0, nil
+	}
+	roomID, err := strconv.Atoi(value)
+	if err != nil || roomID <= 0 {
+		return 0, errors.New("roomId must be a positive integer")
+	}
+	return roomID, nil
+}
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +15,9 @@ import (
 	"ketukApps/internal/services"
 )
 
+// maxImportFileSize is the largest timetable file accepted by ImportScheduleReguler
+const maxImportFileSize = 5 << 20
+
 type ScheduleHandler struct {
 	scheduleService *services.ScheduleService
 }
@@ -24,21 +31,34 @@ func NewScheduleHandler(scheduleService *services.ScheduleService) *ScheduleHand
 // ScheduleTicket Handlers
 
 // @Summary Get all schedule tickets
-// @Description Get a list of all schedule tickets
+// @Description Get a page of schedule tickets, latest first. Filters accept comma separated values.
 // @Tags schedule-ticket
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {object} models.APIResponse{data=[]models.ScheduleTicket}
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to -startDate)"
+// @Param roomId query int false "Only schedules in this room"
+// @Param userId query int false "Only schedules of this user"
+// @Param category query string false "Only schedules with this category (Kelas, Lainnya, Praktikum, Skripsi)"
+// @Param isBooked query bool false "Only booked (true) or free (false) schedules"
+// @Param from query string false "Starting at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.ScheduleTicket,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/schedules/tickets/v1 [get]
 func (h *ScheduleHandler) GetAllScheduleTickets(c *gin.Context) {
-	schedules, err := h.scheduleService.GetAllScheduleTickets()
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve schedule tickets",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	schedules, meta, err := h.scheduleService.GetAllScheduleTickets(query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve schedule tickets", err)
 		return
 	}
 
@@ -46,6 +66,7 @@ func (h *ScheduleHandler) GetAllScheduleTickets(c *gin.Context) {
 		Success: true,
 		Message: "Schedule tickets retrieved successfully",
 		Data:    schedules,
+		Meta:    meta,
 	})
 }
 
@@ -89,12 +110,21 @@ func (h *ScheduleHandler) GetScheduleTicketByID(c *gin.Context) {
 }
 
 // @Summary Get schedule tickets by user ID
-// @Description Get all schedule tickets for a specific user
+// @Description Get a page of schedule tickets for a specific user, latest first
 // @Tags schedule-ticket
 // @Security BearerAuth
 // @Produce json
 // @Param user_id path int true "User ID"
-// @Success 200 {object} models.APIResponse{data=[]models.ScheduleTicket}
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to -startDate)"
+// @Param roomId query int false "Only schedules in this room"
+// @Param category query string false "Only schedules with this category (Kelas, Lainnya, Praktikum, Skripsi)"
+// @Param isBooked query bool false "Only booked (true) or free (false) schedules"
+// @Param from query string false "Starting at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.ScheduleTicket,meta=models.PageMeta}
 // @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/schedules/tickets/v1/user/{user_id} [get]
@@ -110,13 +140,15 @@ func (h *ScheduleHandler) GetScheduleTicketsByUserID(c *gin.Context) {
 		return
 	}
 
-	schedules, err := h.scheduleService.GetScheduleTicketsByUserID(userID)
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve user schedule tickets",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	schedules, meta, err := h.scheduleService.GetScheduleTicketsByUserID(userID, query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve user schedule tickets", err)
 		return
 	}
 
@@ -124,29 +156,42 @@ func (h *ScheduleHandler) GetScheduleTicketsByUserID(c *gin.Context) {
 		Success: true,
 		Message: "User schedule tickets retrieved successfully",
 		Data:    schedules,
+		Meta:    meta,
 	})
 }
 
 // @Summary Get schedule tickets by category
-// @Description Get all schedule tickets with a specific category
+// @Description Get a page of schedule tickets with a specific category, latest first
 // @Tags schedule-ticket
 // @Security BearerAuth
 // @Produce json
 // @Param category path string true "Category (barang, ruangan)"
-// @Success 200 {object} models.APIResponse{data=[]models.ScheduleTicket}
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to -startDate)"
+// @Param roomId query int false "Only schedules in this room"
+// @Param userId query int false "Only schedules of this user"
+// @Param isBooked query bool false "Only booked (true) or free (false) schedules"
+// @Param from query string false "Starting at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.ScheduleTicket,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/schedules/tickets/v1/category/{category} [get]
 func (h *ScheduleHandler) GetScheduleTicketsByCategory(c *gin.Context) {
 	categoryParam := c.Param("category")
 	category := models.Category(categoryParam)
 
-	schedules, err := h.scheduleService.GetScheduleTicketsByCategory(category)
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve schedule tickets by category",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	schedules, meta, err := h.scheduleService.GetScheduleTicketsByCategory(category, query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve schedule tickets by category", err)
 		return
 	}
 
@@ -154,6 +199,7 @@ func (h *ScheduleHandler) GetScheduleTicketsByCategory(c *gin.Context) {
 		Success: true,
 		Message: "Schedule tickets retrieved successfully",
 		Data:    schedules,
+		Meta:    meta,
 	})
 }
 
@@ -166,6 +212,7 @@ func (h *ScheduleHandler) GetScheduleTicketsByCategory(c *gin.Context) {
 // @Param schedule body models.CreateScheduleTicketRequest true "Schedule ticket data"
 // @Success 201 {object} models.APIResponse{data=models.ScheduleTicket}
 // @Failure 400 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse{data=[]models.ScheduleConflict}
 // @Router /api/schedules/tickets/v1 [post]
 func (h *ScheduleHandler) CreateScheduleTicket(c *gin.Context) {
 	var req models.CreateScheduleTicketRequest
@@ -186,10 +233,24 @@ func (h *ScheduleHandler) CreateScheduleTicket(c *gin.Context) {
 		UserID:      req.UserID,
 		Kategori:    req.Kategori,
 		Description: req.Description,
+		RoomID:      req.RoomID,
+		// Schedules entered directly by an admin hold the slot immediately
+		IsBooked: true,
 	}
 
 	createdSchedule, err := h.scheduleService.CreateScheduleTicket(&schedule)
 	if err != nil {
+		var conflictErr *services.ScheduleConflictError
+		if errors.As(err, &conflictErr) {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Message: "Schedule conflicts with existing schedules",
+				Data:    conflictErr.Conflicts,
+				Error:   err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Message: "Failed to create schedule ticket",
@@ -216,6 +277,7 @@ func (h *ScheduleHandler) CreateScheduleTicket(c *gin.Context) {
 // @Success 200 {object} models.APIResponse{data=models.ScheduleTicket}
 // @Failure 400 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse{data=[]models.ScheduleConflict}
 // @Router /api/schedules/tickets/v1/{id} [put]
 func (h *ScheduleHandler) UpdateScheduleTicket(c *gin.Context) {
 	idParam := c.Param("id")
@@ -229,8 +291,8 @@ func (h *ScheduleHandler) UpdateScheduleTicket(c *gin.Context) {
 		return
 	}
 
-	var updates map[string]interface{}
-	if err := c.ShouldBindJSON(&updates); err != nil {
+	var req models.UpdateScheduleTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Message: "Invalid request body",
@@ -239,8 +301,19 @@ func (h *ScheduleHandler) UpdateScheduleTicket(c *gin.Context) {
 		return
 	}
 
-	schedule, err := h.scheduleService.UpdateScheduleTicket(id, updates)
+	schedule, err := h.scheduleService.UpdateScheduleTicket(id, req)
 	if err != nil {
+		var conflictErr *services.ScheduleConflictError
+		if errors.As(err, &conflictErr) {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Message: "Schedule conflicts with existing schedules",
+				Data:    conflictErr.Conflicts,
+				Error:   err.Error(),
+			})
+			return
+		}
+
 		status := http.StatusBadRequest
 		if err.Error() == "schedule ticket not found" {
 			status = http.StatusNotFound
@@ -302,28 +375,80 @@ func (h *ScheduleHandler) DeleteScheduleTicket(c *gin.Context) {
 // ScheduleReguler Handlers
 
 // @Summary Get all regular schedules
-// @Description Get a list of all regular schedules
+// @Description Get a page of regular schedules. When from and to are given, recurring schedules are instead expanded into all occurrences within that window, without pagination.
 // @Tags schedule-reguler
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {object} models.APIResponse{data=[]models.ScheduleReguler}
+// @Param from query string false "Window start (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Window end (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Param roomId query int false "Only schedules in this room"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to startDate). Ignored for occurrences"
+// @Param tahun query int false "Only schedules of this academic year. Ignored for occurrences"
+// @Param semester query string false "Only schedules of this semester (Ganjil, Genap). Ignored for occurrences"
+// @Param userId query int false "Only schedules of this user. Ignored for occurrences"
+// @Success 200 {object} models.APIResponse{data=[]models.ScheduleReguler,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/schedules/reguler/v1 [get]
 func (h *ScheduleHandler) GetAllScheduleReguler(c *gin.Context) {
-	schedules, err := h.scheduleService.GetAllScheduleReguler()
+	roomID, err := parseRoomFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
-			Message: "Failed to retrieve regular schedules",
+			Message: "Invalid room ID",
 			Error:   err.Error(),
 		})
 		return
 	}
 
+	from, to, windowed, err := parseDateWindow(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid date window",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if windowed {
+		occurrences, err := h.scheduleService.GetScheduleRegulerOccurrences(from, to, roomID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Failed to expand regular schedules",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Message: "Regular schedule occurrences retrieved successfully",
+			Data:    occurrences,
+		})
+		return
+	}
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		invalidListQuery(c, err)
+		return
+	}
+
+	schedules, meta, err := h.scheduleService.GetAllScheduleReguler(query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve regular schedules", err)
+		return
+	}
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Message: "Regular schedules retrieved successfully",
 		Data:    schedules,
+		Meta:    meta,
 	})
 }
 
@@ -367,12 +492,21 @@ func (h *ScheduleHandler) GetScheduleRegulerByID(c *gin.Context) {
 }
 
 // @Summary Get regular schedules by user ID
-// @Description Get all regular schedules for a specific user
+// @Description Get a page of regular schedules for a specific user. When from and to are given, recurring schedules are instead expanded into all occurrences within that window, without pagination.
 // @Tags schedule-reguler
 // @Security BearerAuth
 // @Produce json
 // @Param user_id path int true "User ID"
-// @Success 200 {object} models.APIResponse{data=[]models.ScheduleReguler}
+// @Param from query string false "Window start (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Window end (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Param roomId query int false "Only schedules in this room"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, title; prefix with - for descending (defaults to startDate). Ignored for occurrences"
+// @Param tahun query int false "Only schedules of this academic year. Ignored for occurrences"
+// @Param semester query string false "Only schedules of this semester (Ganjil, Genap). Ignored for occurrences"
+// @Success 200 {object} models.APIResponse{data=[]models.ScheduleReguler,meta=models.PageMeta}
 // @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/schedules/reguler/v1/user/{user_id} [get]
@@ -388,20 +522,61 @@ func (h *ScheduleHandler) GetScheduleRegulerByUserID(c *gin.Context) {
 		return
 	}
 
-	schedules, err := h.scheduleService.GetScheduleRegulerByUserID(userID)
+	roomID, err := parseRoomFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid room ID",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	from, to, windowed, err := parseDateWindow(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
-			Message: "Failed to retrieve user regular schedules",
+			Message: "Invalid date window",
 			Error:   err.Error(),
 		})
 		return
 	}
+	if windowed {
+		occurrences, err := h.scheduleService.GetScheduleRegulerOccurrencesByUserID(userID, from, to, roomID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Failed to expand user regular schedules",
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Message: "User regular schedule occurrences retrieved successfully",
+			Data:    occurrences,
+		})
+		return
+	}
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		invalidListQuery(c, err)
+		return
+	}
+
+	schedules, meta, err := h.scheduleService.GetScheduleRegulerByUserID(userID, query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve user regular schedules", err)
+		return
+	}
 
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Message: "User regular schedules retrieved successfully",
 		Data:    schedules,
+		Meta:    meta,
 	})
 }
 
@@ -432,6 +607,11 @@ func (h *ScheduleHandler) CreateScheduleReguler(c *gin.Context) {
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
 		UserID:    req.UserID,
+		RRule:     req.RRule,
+		ExDates:   req.ExDates,
+		Tahun:     req.Tahun,
+		Semester:  req.Semester,
+		RoomID:    req.RoomID,
 	}
 
 	createdSchedule, err := h.scheduleService.CreateScheduleReguler(&schedule)
@@ -451,6 +631,131 @@ func (h *ScheduleHandler) CreateScheduleReguler(c *gin.Context) {
 	})
 }
 
+// @Summary Import regular schedules
+// @Description Import a semester timetable from a CSV or ICS file. Every row is validated (dates, owner email, overlaps with existing schedules and with other rows). With dryRun=true only the report is returned; otherwise all rows are created in one transaction, or none if any row is invalid.
+// @Description CSV columns: title, email, start_date, end_date, rrule, exdates, tahun, semester, room. ICS events use SUMMARY, ORGANIZER (mailto), LOCATION (room name), DTSTART, DTEND, RRULE and EXDATE.
+// @Tags schedule-reguler
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or ICS file"
+// @Param format formData string false "csv or ics (defaults to the file extension)"
+// @Param tahun formData int false "Default academic year for rows without one"
+// @Param semester formData string false "Default semester (Ganjil/Genap) for rows without one"
+// @Param roomId formData int false "Default room for rows without one"
+// @Param dryRun query bool false "Validate only, do not create anything"
+// @Success 200 {object} models.APIResponse{data=models.ScheduleImportReport}
+// @Success 201 {object} models.APIResponse{data=models.ScheduleImportReport}
+// @Failure 400 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse{data=models.ScheduleImportReport}
+// @Router /api/schedules/reguler/v1/import [post]
+func (h *ScheduleHandler) ImportScheduleReguler(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid import file",
+			Error:   "file is required",
+		})
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid import file",
+			Error:   "file must not be larger than 5 MB",
+		})
+		return
+	}
+
+	format := models.ScheduleImportFormat(strings.ToLower(c.PostForm("format")))
+	if format == "" {
+		format = models.ScheduleImportFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), "."))
+	}
+
+	var defaults services.ScheduleImportDefaults
+	if value := c.PostForm("tahun"); value != "" {
+		tahun, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Invalid tahun",
+				Error:   "Tahun must be a valid integer",
+			})
+			return
+		}
+		defaults.Tahun = &tahun
+	}
+	if value := c.PostForm("semester"); value != "" {
+		semester := models.SemesterCategory(value)
+		if semester != models.SemesterGanjil && semester != models.SemesterGenap {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Invalid semester",
+				Error:   "Semester must be either 'Ganjil' or 'Genap'",
+			})
+			return
+		}
+		defaults.Semester = &semester
+	}
+	if value := c.PostForm("roomId"); value != "" {
+		roomID, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Invalid room ID",
+				Error:   "Room ID must be a valid integer",
+			})
+			return
+		}
+		defaults.RoomID = roomID
+	}
+	dryRun, _ := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid import file",
+			Error:   err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	report, err := h.scheduleService.ImportScheduleReguler(file, format, defaults, dryRun)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Failed to import regular schedules",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	switch {
+	case report.ValidRows != report.TotalRows:
+		c.JSON(http.StatusUnprocessableEntity, models.APIResponse{
+			Success: false,
+			Message: "Import contains invalid rows, nothing was created",
+			Data:    report,
+			Error:   fmt.Sprintf("%d of %d rows are invalid", report.TotalRows-report.ValidRows, report.TotalRows),
+		})
+	case report.DryRun:
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Message: "Import validated successfully, nothing was created",
+			Data:    report,
+		})
+	default:
+		c.JSON(http.StatusCreated, models.APIResponse{
+			Success: true,
+			Message: "Regular schedules imported successfully",
+			Data:    report,
+		})
+	}
+}
+
 // @Summary Update regular schedule
 // @Description Update regular schedule information by ID. All fields are optional.
 // @Tags schedule-reguler
@@ -475,8 +780,8 @@ func (h *ScheduleHandler) UpdateScheduleReguler(c *gin.Context) {
 		return
 	}
 
-	var updates map[string]interface{}
-	if err := c.ShouldBindJSON(&updates); err != nil {
+	var req models.UpdateScheduleRegulerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
 			Message: "Invalid request body",
@@ -485,7 +790,7 @@ func (h *ScheduleHandler) UpdateScheduleReguler(c *gin.Context) {
 		return
 	}
 
-	schedule, err := h.scheduleService.UpdateScheduleReguler(id, updates)
+	schedule, err := h.scheduleService.UpdateScheduleReguler(id, req)
 	if err != nil {
 		status := http.StatusBadRequest
 		if err.Error() == "schedule reguler not found" {
@@ -677,7 +982,7 @@ func (h *ScheduleHandler) CreateUnblocking(c *gin.Context) {
 
 	createdUnblocking, err := h.scheduleService.CreateUnblocking(&unblocking)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.APIResponse{
+		c.JSON(unblockingWriteStatus(err, http.StatusBadRequest), models.APIResponse{
 			Success: false,
 			Message: "Failed to create unblocking record",
 			Error:   err.Error(),
@@ -717,7 +1022,7 @@ func (h *ScheduleHandler) UpdateUnblocking(c *gin.Context) {
 
 	unblocking, err := h.scheduleService.UpdateUnblocking(id, updates)
 	if err != nil {
-		status := http.StatusBadRequest
+		status := unblockingWriteStatus(err, http.StatusBadRequest)
 		if err.Error() == "unblocking not found" {
 			status = http.StatusNotFound
 		}
@@ -765,3 +1070,36 @@ func (h *ScheduleHandler) DeleteUnblocking(c *gin.Context) {
 		Message: "Unblocking record deleted successfully",
 	})
 }
+
+// parseDateWindow reads the optional from/to query parameters. Dates without a
+// time part are whole days, so to=2024-03-01 includes March 1st.
+func parseDateWindow(c *gin.Context) (time.Time, time.Time, bool, error) {
+	fromParam := c.Query("from")
+	toParam := c.Query("to")
+	if fromParam == "" && toParam == "" {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	if fromParam == "" || toParam == "" {
+		return time.Time{}, time.Time{}, false, errors.New("from and to must be provided together")
+	}
+
+	from, err := parseDateParam(fromParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid from: %w", err)
+	}
+	to, err := parseDateParam(toParam)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid to: %w", err)
+	}
+	if len(toParam) == len("2006-01-02") {
+		to = to.AddDate(0, 0, 1)
+	}
+	return from, to, true, nil
+}
+
+func parseDateParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/services"
+)
+
+type SearchHandler struct {
+	searchService *services.SearchService
+}
+
+func NewSearchHandler(searchService *services.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+var searchTypes = map[string]models.SearchType{
+	string(models.SearchTicket):          models.SearchTicket,
+	string(models.SearchItem):            models.SearchItem,
+	string(models.SearchScheduleTicket):  models.SearchScheduleTicket,
+	string(models.SearchScheduleReguler): models.SearchScheduleReguler,
+}
+
+// @Summary Search tickets, items and schedules
+// @Description Ranked full-text search with web search syntax: "quoted phrases", OR and -excluded words. Matched words are wrapped in <mark> tags in headline and snippet. Regular users only find their own tickets and bookings.
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param q query string true "Search query"
+// @Param types query string false "Comma separated types to search: ticket, item, schedule_ticket, schedule_reguler (defaults to all)"
+// @Param limit query int false "Maximum hits per type, 1 to 50 (defaults to 10)"
+// @Success 200 {object} models.APIResponse{data=models.SearchResults}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/search/v1 [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Search query is required",
+			Error:   "Query parameter 'q' cannot be empty",
+		})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+	scope := services.SearchScope{
+		UserID:  user.ID,
+		IsAdmin: user.Role == "admin",
+	}
+
+	if value := c.Query("types"); value != "" {
+		for _, name := range strings.Split(value, ",") {
+			searchType, ok := searchTypes[strings.TrimSpace(name)]
+			if !ok {
+				c.JSON(http.StatusBadRequest, models.APIResponse{
+					Success: false,
+					Message: "Invalid search type",
+					Error:   fmt.Sprintf("unknown type %q", strings.TrimSpace(name)),
+				})
+				return
+			}
+			scope.Types = append(scope.Types, searchType)
+		}
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > services.MaxSearchLimit {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Invalid limit",
+				Error:   fmt.Sprintf("limit must be between 1 and %d", services.MaxSearchLimit),
+			})
+			return
+		}
+		scope.Limit = limit
+	}
+
+	results, err := h.searchService.Search(query, scope)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Search failed",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Search completed successfully",
+		Data:    results,
+	})
+}
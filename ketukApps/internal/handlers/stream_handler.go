@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/scheduler"
+	"ketukApps/internal/stream"
+)
+
+// Stream settings. Heartbeats keep proxies from closing an idle stream; retry
+// tells EventSource how long to wait before reconnecting.
+const (
+	streamHeartbeat = 25 * time.Second
+	streamRetry     = 5 * time.Second
+)
+
+// streamUnblockState is sent first, so clients know whether booking of any
+// category is open for the user before the next transition
+const streamUnblockState = "unblock.state"
+
+type StreamHandler struct {
+	hub *stream.Hub
+}
+
+func NewStreamHandler(hub *stream.Hub) *StreamHandler {
+	return &StreamHandler{
+		hub: hub,
+	}
+}
+
+// @Summary Stream live updates
+// @Description Server-Sent Events stream replacing ticket polling. The first event is unblock.state ({"open": bool}), true while any booking window for the user's role is open. After that users get ticket.status_changed for their own tickets, admins get ticket.created for new pending tickets and everyone gets unblock.opened and unblock.closed for the windows of their role; the kategori of the window tells which category opened or closed. Event data is the domain event JSON and the SSE id is its ID. Browsers' EventSource cannot send an Authorization header, so the token may be passed as access_token instead. A client that falls behind is disconnected and should refetch what it shows when it reconnects.
+// @Tags stream
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param access_token query string false "JWT access token, for clients that cannot send an Authorization header"
+// @Success 200 {string} string "Event stream"
+// @Failure 401 {object} models.APIResponse
+// @Router /api/stream/v1 [get]
+func (h *StreamHandler) Stream(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	subscriber := h.hub.Subscribe(user.ID, user.Role)
+	defer h.hub.Unsubscribe(subscriber)
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Stop nginx from buffering the stream
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+	if err := writeStreamEvent(c.Writer, "", streamUnblockState, gin.H{"open": scheduler.IsUnblockEnabledForRole(user.Role)}); err != nil {
+		return
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-subscriber.Events():
+			if !ok {
+				// Dropped for falling behind
+				return
+			}
+			if err := writeStreamEvent(c.Writer, event.ID, string(event.Type), event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeStreamEvent writes one SSE event with data encoded as JSON
+func writeStreamEvent(w io.Writer, id, name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload)
+	return err
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -21,20 +22,33 @@ func NewTicketHandler(ticketService *services.TicketService) *TicketHandler {
 }
 
 // @Summary Get all tickets
-// @Description Get a list of all tickets
+// @Description Get a page of tickets, newest first. Filters accept comma separated values.
 // @Tags tickets
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {object} models.APIResponse
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, createdAt, updatedAt, approvedAt, title, status; prefix with - for descending (defaults to -id)"
+// @Param status query string false "Only tickets with this status (pending, accepted, rejected)"
+// @Param userId query int false "Only tickets of this user"
+// @Param roomId query int false "Only tickets for this room"
+// @Param from query string false "Created at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.Ticket,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
 // @Router /api/tickets/v1 [get]
 func (h *TicketHandler) GetAllTickets(c *gin.Context) {
-	tickets, err := h.ticketService.GetAll()
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve tickets",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	tickets, meta, err := h.ticketService.GetAll(query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve tickets", err)
 		return
 	}
 
@@ -42,6 +56,7 @@ func (h *TicketHandler) GetAllTickets(c *gin.Context) {
 		Success: true,
 		Message: "Tickets retrieved successfully",
 		Data:    tickets,
+		Meta:    meta,
 	})
 }
 
@@ -107,8 +122,18 @@ func (h *TicketHandler) GetTicketsByUserID(c *gin.Context) {
 		return
 	}
 
+	query, err := parseListQuery(c)
+	if err != nil {
+		invalidListQuery(c, err)
+		return
+	}
+
 	userID := uint(userIDInt)
-	tickets, err := h.ticketService.GetByUserID(userID)
+	tickets, meta, err := h.ticketService.GetByUserID(userID, query)
+	if errors.Is(err, services.ErrInvalidListQuery) {
+		invalidListQuery(c, err)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.APIResponse{
 			Success: false,
@@ -122,6 +147,7 @@ func (h *TicketHandler) GetTicketsByUserID(c *gin.Context) {
 		Success: true,
 		Message: "User tickets retrieved successfully",
 		Data:    tickets,
+		Meta:    meta,
 	})
 }
 
@@ -178,7 +204,7 @@ func (h *TicketHandler) GetPendingTickets(c *gin.Context) {
 }
 
 // @Summary Search tickets
-// @Description Search tickets by title or description
+// @Description Full-text search of tickets by title or description, best match first
 // @Tags tickets
 // @Produce json
 // @Param q query string true "Search query"
@@ -214,18 +240,34 @@ func (h *TicketHandler) SearchTickets(c *gin.Context) {
 }
 
 // @Summary Create a new ticket
-// @Description Create a new ticket for a user
+// @Description Create a new ticket for a user, without booking a schedule. Use POST /api/bookings/v1 to book a room. With an Idempotency-Key header a retried request returns the ticket created by the first one (200, Idempotent-Replayed: true) instead of creating another.
 // @Tags tickets
 // @Security BearerAuth
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Unique key of this request, at most 255 characters"
 // @Param ticket body models.CreateTicketRequest true "Ticket data"
 // @Success 201 {object} models.APIResponse
+// @Success 200 {object} models.APIResponse "Replayed request"
 // @Failure 400 {object} models.APIResponse
+// @Failure 422 {object} models.APIResponse "Idempotency key reused for a different request"
+// @Deprecated
 // @Router /api/tickets/v1 [post]
 func (h *TicketHandler) CreateTicket(c *gin.Context) {
 	var req models.CreateTicketRequest
 
+	// Idempotency keys are scoped to the calling user
+	key := c.GetHeader("Idempotency-Key")
+	var callerID uint
+	if key != "" {
+		userID := userIDOf(c)
+		if userID == nil {
+			notAuthenticated(c)
+			return
+		}
+		callerID = *userID
+	}
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.APIResponse{
 			Success: false,
@@ -235,9 +277,13 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 		return
 	}
 
-	ticket, err := h.ticketService.CreateFromRequest(req)
+	ticket, created, err := h.ticketService.CreateFromRequestOnce(callerID, key, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, models.APIResponse{
+		status := http.StatusBadRequest
+		if errors.Is(err, services.ErrIdempotencyKeyReused) {
+			status = http.StatusUnprocessableEntity
+		}
+		c.JSON(status, models.APIResponse{
 			Success: false,
 			Message: "Failed to create ticket",
 			Error:   err.Error(),
@@ -245,6 +291,16 @@ func (h *TicketHandler) CreateTicket(c *gin.Context) {
 		return
 	}
 
+	if !created {
+		c.Header("Idempotent-Replayed", "true")
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Message: "Ticket already created for this idempotency key",
+			Data:    ticket,
+		})
+		return
+	}
+
 	c.JSON(http.StatusCreated, models.APIResponse{
 		Success: true,
 		Message: "Ticket created successfully",
@@ -310,7 +366,7 @@ func (h *TicketHandler) UpdateTicket(c *gin.Context) {
 }
 
 // @Summary Update ticket status
-// @Description Update the status of a ticket
+// @Description Update the status of a ticket. Accepting or rejecting an equipment loan ticket approves or rejects the loan; item conflicts return 409 with the conflicting loans.
 // @Tags tickets
 // @Security BearerAuth
 // @Accept json
@@ -320,6 +376,7 @@ func (h *TicketHandler) UpdateTicket(c *gin.Context) {
 // @Success 200 {object} models.APIResponse
 // @Failure 400 {object} models.APIResponse
 // @Failure 404 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse{data=[]models.ScheduleConflict}
 // @Router /api/tickets/v1/{id}/status [patch]
 func (h *TicketHandler) UpdateTicketStatus(c *gin.Context) {
 	idParam := c.Param("id")
@@ -354,6 +411,27 @@ func (h *TicketHandler) UpdateTicketStatus(c *gin.Context) {
 
 	ticket, err := h.ticketService.UpdateStatusWithAdmin(id, req.Status, req.Reason, adminUser)
 	if err != nil {
+		var conflictErr *services.ScheduleConflictError
+		if errors.As(err, &conflictErr) {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Message: "Ticket schedule conflicts with existing bookings",
+				Data:    conflictErr.Conflicts,
+				Error:   err.Error(),
+			})
+			return
+		}
+		var loanErr *services.LoanConflictError
+		if errors.As(err, &loanErr) {
+			c.JSON(http.StatusConflict, models.APIResponse{
+				Success: false,
+				Message: "Loan items are not available",
+				Data:    loanErr.Conflicts,
+				Error:   err.Error(),
+			})
+			return
+		}
+
 		status := http.StatusBadRequest
 		if err.Error() == "ticket not found" {
 			status = http.StatusNotFound
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"ketukApps/internal/models"
 	"ketukApps/internal/services"
+	"ketukApps/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,7 +25,7 @@ func NewUnblockingHandler(unblockingService *services.UnblockingService) *Unbloc
 
 // CreateUnblocking godoc
 // @Summary Create a new unblocking request
-// @Description Create a new unblocking request for semester unblocking
+// @Description Create a new unblocking request for semester unblocking. A window may be limited to one booking category (kategori) and one role; without them it applies to every category or role. Windows of the same category and role may touch but not overlap.
 // @Tags unblocking
 // @Accept json
 // @Produce json
@@ -30,6 +33,7 @@ func NewUnblockingHandler(unblockingService *services.UnblockingService) *Unbloc
 // @Param unblocking body models.CreateUnblockingRequest true "Unblocking data"
 // @Success 201 {object} models.UnblockingResponse{unblocking=models.Unblocking}
 // @Failure 400 {object} models.APIResponse
+// @Failure 409 {object} models.APIResponse "Overlaps an existing window"
 // @Failure 500 {object} models.APIResponse
 // @Router /api/unblockings/v1 [post]
 func (h *UnblockingHandler) CreateUnblocking(c *gin.Context) {
@@ -44,12 +48,14 @@ func (h *UnblockingHandler) CreateUnblocking(c *gin.Context) {
 		Semester:  req.Semester,
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
+		Kategori:  req.Kategori,
+		Role:      req.Role,
 		UserID:    req.UserID,
 	}
 
 	unblocking, err := h.unblockingService.Create(&unblockingData)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(unblockingWriteStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -60,6 +66,80 @@ func (h *UnblockingHandler) CreateUnblocking(c *gin.Context) {
 	})
 }
 
+// GetEffectiveUnblocking godoc
+// @Summary Get the effective unblocking window
+// @Description Report whether booking of a category by a role is open now (or at the given moment), which window decides it and why. Booking is open while any window for the category (or every category) and the role (or every role) covers the moment; the most specific of them is reported and all of them are listed in covering. When old overlapping windows of the same scope cover the moment, the one created last wins. When booking is closed the next window is included.
+// @Tags unblocking
+// @Produce json
+// @Security BearerAuth
+// @Param at query string false "Moment to evaluate (RFC3339, or YYYY-MM-DD for midnight lab time), defaults to now"
+// @Param kategori query string false "Booking category (Kelas, Lainnya, Praktikum, Skripsi); any category when omitted"
+// @Param role query string false "Role of the booking user (admin, user); defaults to the caller's role"
+// @Success 200 {object} models.APIResponse{data=models.EffectiveUnblocking}
+// @Failure 400 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/unblockings/v1/effective [get]
+func (h *UnblockingHandler) GetEffectiveUnblocking(c *gin.Context) {
+	at := time.Now()
+	if value := c.Query("at"); value != "" {
+		parsed, err := parseDateParam(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse{
+				Success: false,
+				Message: "Invalid at",
+				Error:   err.Error(),
+			})
+			return
+		}
+		if len(value) == len("2006-01-02") {
+			parsed = time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, utils.LabLocation)
+		}
+		at = parsed
+	}
+
+	category := models.Category(c.Query("kategori"))
+	switch category {
+	case "", models.Kelas, models.Lainnya, models.Praktikum, models.Skripsi:
+	default:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid kategori",
+			Error:   "kategori must be one of Kelas, Lainnya, Praktikum, Skripsi",
+		})
+		return
+	}
+
+	role := c.Query("role")
+	switch role {
+	case "admin", "user":
+	case "":
+		role = c.GetString("user_role")
+	default:
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid role",
+			Error:   "role must be one of admin, user",
+		})
+		return
+	}
+
+	effective, err := h.unblockingService.Effective(at, category, role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to resolve the unblocking window",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Effective unblocking window retrieved successfully",
+		Data:    effective,
+	})
+}
+
 // GetUnblockingByID godoc
 // @Summary Get unblocking request by ID
 // @Description Get an unblocking request by its ID
@@ -95,11 +175,19 @@ func (h *UnblockingHandler) GetUnblockingByID(c *gin.Context) {
 
 // GetUnblockingsByUserID godoc
 // @Summary Get unblocking requests by user ID
-// @Description Get all unblocking requests for a specific user
+// @Description Get a page of unblocking requests for a specific user, latest first
 // @Tags unblocking
 // @Produce json
 // @Security BearerAuth
 // @Param user_id path int true "User ID"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, tahun; prefix with - for descending (defaults to -startDate)"
+// @Param tahun query int false "Only windows of this academic year"
+// @Param semester query string false "Only windows of this semester (Ganjil, Genap)"
+// @Param from query string false "Starting at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)"
 // @Success 200 {object} models.UnblockingsResponse{unblockings=[]models.Unblocking}
 // @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
@@ -112,9 +200,15 @@ func (h *UnblockingHandler) GetUnblockingsByUserID(c *gin.Context) {
 		return
 	}
 
-	unblockings, err := h.unblockingService.GetByUserID(userID)
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	unblockings, meta, err := h.unblockingService.GetByUserID(userID, query)
+	if err != nil {
+		c.JSON(unblockingListStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -122,22 +216,39 @@ func (h *UnblockingHandler) GetUnblockingsByUserID(c *gin.Context) {
 		Success:     true,
 		Message:     "Unblocking operation completed successfully",
 		Unblockings: unblockings,
+		Meta:        meta,
 	})
 }
 
 // GetAllUnblockings godoc
 // @Summary Get all unblocking requests
-// @Description Get a list of all unblocking requests
+// @Description Get a page of unblocking requests, latest first
 // @Tags unblocking
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, startDate, endDate, createdAt, tahun; prefix with - for descending (defaults to -startDate)"
+// @Param tahun query int false "Only windows of this academic year"
+// @Param semester query string false "Only windows of this semester (Ganjil, Genap)"
+// @Param from query string false "Starting at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Starting before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Param userId query int false "Only requests of this user"
 // @Success 200 {object} models.UnblockingsResponse{unblockings=[]models.Unblocking}
+// @Failure 400 {object} models.APIResponse
 // @Failure 500 {object} models.APIResponse
 // @Router /api/unblockings/v1 [get]
 func (h *UnblockingHandler) GetAllUnblockings(c *gin.Context) {
-	unblockings, err := h.unblockingService.GetAll()
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	unblockings, meta, err := h.unblockingService.GetAll(query)
+	if err != nil {
+		c.JSON(unblockingListStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -145,5 +256,27 @@ func (h *UnblockingHandler) GetAllUnblockings(c *gin.Context) {
 		Success:     true,
 		Message:     "Unblocking operation completed successfully",
 		Unblockings: unblockings,
+		Meta:        meta,
 	})
 }
+
+// unblockingWriteStatus is 409 for overlapping windows, 400 for invalid ones
+// and fallback otherwise
+func unblockingWriteStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, services.ErrUnblockingOverlap):
+		return http.StatusConflict
+	case err.Error() == "start and end date are required", err.Error() == "end date must be after start date",
+		err.Error() == "kategori must be one of Kelas, Lainnya, Praktikum, Skripsi", err.Error() == "role must be one of admin, user":
+		return http.StatusBadRequest
+	}
+	return fallback
+}
+
+// unblockingListStatus is 400 for bad list parameters and 500 otherwise
+func unblockingListStatus(err error) int {
+	if errors.Is(err, services.ErrInvalidListQuery) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -21,20 +21,30 @@ func NewUserHandler(userService *services.UserService) *UserHandler {
 }
 
 // @Summary Get all users
-// @Description Get a list of all users
+// @Description Get a page of users
 // @Tags users
 // @Security BearerAuth
 // @Produce json
-// @Success 200 {object} models.APIResponse
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, name, email, role, created_at; prefix with - for descending (defaults to id)"
+// @Param role query string false "Only users with this role (admin, user)"
+// @Param from query string false "Registered at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Registered before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.User,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
 // @Router /api/users/v1 [get]
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
-	users, err := h.userService.GetAll()
+	query, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Message: "Failed to retrieve users",
-			Error:   err.Error(),
-		})
+		invalidListQuery(c, err)
+		return
+	}
+
+	users, meta, err := h.userService.GetAll(query)
+	if err != nil {
+		listQueryError(c, "Failed to retrieve users", err)
 		return
 	}
 
@@ -42,6 +52,7 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 		Success: true,
 		Message: "Users retrieved successfully",
 		Data:    users,
+		Meta:    meta,
 	})
 }
 
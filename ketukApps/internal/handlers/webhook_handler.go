@@ -0,0 +1,324 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/services"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// @Summary Get all webhook subscriptions
+// @Description Get every webhook subscription. Secrets are not included.
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=[]models.WebhookSubscription}
+// @Failure 500 {object} models.APIResponse
+// @Router /api/webhooks/v1 [get]
+func (h *WebhookHandler) GetAllWebhooks(c *gin.Context) {
+	subscriptions, err := h.webhookService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.APIResponse{
+			Success: false,
+			Message: "Failed to retrieve webhook subscriptions",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook subscriptions retrieved successfully",
+		Data:    subscriptions,
+	})
+}
+
+// @Summary Get webhook subscription by ID
+// @Description Get a webhook subscription by its ID. The secret is not included.
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} models.APIResponse{data=models.WebhookSubscription}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/webhooks/v1/{id} [get]
+func (h *WebhookHandler) GetWebhookByID(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookService.GetByID(id)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err, http.StatusInternalServerError), models.APIResponse{
+			Success: false,
+			Message: "Webhook subscription not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook subscription retrieved successfully",
+		Data:    subscription,
+	})
+}
+
+// @Summary Create a webhook subscription
+// @Description Subscribe a URL to ticket.created, ticket.status_changed, schedule.created or item.updated events. Every event is POSTed as JSON with X-Ketuk-Event, X-Ketuk-Delivery, X-Ketuk-Timestamp and X-Ketuk-Signature headers; the signature is "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret. A secret is generated when none is given and is only returned in this response.
+// @Tags webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param webhook body models.CreateWebhookSubscriptionRequest true "Subscription data"
+// @Success 201 {object} models.APIResponse{data=models.WebhookSubscriptionWithSecret}
+// @Failure 400 {object} models.APIResponse
+// @Failure 401 {object} models.APIResponse
+// @Router /api/webhooks/v1 [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		notAuthenticated(c)
+		return
+	}
+
+	var req models.CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	subscription, err := h.webhookService.Create(user.ID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Failed to create webhook subscription",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Message: "Webhook subscription created successfully",
+		Data:    subscription,
+	})
+}
+
+// @Summary Update webhook subscription
+// @Description Update a webhook subscription. All fields are optional. With rotateSecret a new secret is generated and returned in this response only; deliveries still pending are signed with it.
+// @Tags webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param updates body models.UpdateWebhookSubscriptionRequest true "Updated subscription data"
+// @Success 200 {object} models.APIResponse{data=models.WebhookSubscriptionWithSecret}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/webhooks/v1/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	subscription, err := h.webhookService.Update(id, req)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err, http.StatusBadRequest), models.APIResponse{
+			Success: false,
+			Message: "Failed to update webhook subscription",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook subscription updated successfully",
+		Data:    subscription,
+	})
+}
+
+// @Summary Delete webhook subscription
+// @Description Delete a webhook subscription together with its delivery log
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} models.APIResponse
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/webhooks/v1/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.Delete(id); err != nil {
+		c.JSON(webhookErrorStatus(err, http.StatusInternalServerError), models.APIResponse{
+			Success: false,
+			Message: "Failed to delete webhook subscription",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook subscription deleted successfully",
+	})
+}
+
+// @Summary Get webhook deliveries
+// @Description Get a page of the delivery log of a subscription, newest first, with the attempts made and the last response code and body
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (defaults to 20 with page or cursor; without page, limit or cursor every row is returned)"
+// @Param cursor query string false "Cursor from meta.nextCursor; requires sort=id or sort=-id"
+// @Param sort query string false "Sort keys: id, createdAt; prefix with - for descending (defaults to -id)"
+// @Param status query string false "Only deliveries with this status (pending, succeeded, failed)"
+// @Param eventType query string false "Only deliveries of this event type"
+// @Param eventId query string false "Only deliveries of this event"
+// @Param from query string false "Created at or after (RFC3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC3339 or YYYY-MM-DD, inclusive day)"
+// @Success 200 {object} models.APIResponse{data=[]models.WebhookDelivery,meta=models.PageMeta}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Failure 500 {object} models.APIResponse
+// @Router /api/webhooks/v1/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		invalidListQuery(c, err)
+		return
+	}
+
+	deliveries, meta, err := h.webhookService.GetDeliveries(id, query)
+	if err != nil {
+		if err.Error() == "webhook subscription not found" {
+			c.JSON(http.StatusNotFound, models.APIResponse{
+				Success: false,
+				Message: "Failed to retrieve webhook deliveries",
+				Error:   err.Error(),
+			})
+			return
+		}
+		listQueryError(c, "Failed to retrieve webhook deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Webhook deliveries retrieved successfully",
+		Data:    deliveries,
+		Meta:    meta,
+	})
+}
+
+// @Summary Redeliver a webhook
+// @Description Queue the event of a delivery to be sent again with a fresh signature. The original delivery stays in the log and the new one refers to it with redeliveryOf.
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 202 {object} models.APIResponse{data=models.WebhookDelivery}
+// @Failure 400 {object} models.APIResponse
+// @Failure 404 {object} models.APIResponse
+// @Router /api/webhooks/v1/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid delivery ID",
+			Error:   "Delivery ID must be a valid integer",
+		})
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(id, deliveryID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "webhook delivery not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, models.APIResponse{
+			Success: false,
+			Message: "Failed to redeliver webhook",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.APIResponse{
+		Success: true,
+		Message: "Webhook queued for redelivery",
+		Data:    delivery,
+	})
+}
+
+// webhookID reads the subscription ID path parameter, responding with 400 when it is invalid
+func webhookID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse{
+			Success: false,
+			Message: "Invalid subscription ID",
+			Error:   "ID must be a valid integer",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// webhookErrorStatus maps a missing subscription to 404 and anything else to fallback
+func webhookErrorStatus(err error, fallback int) int {
+	if err.Error() == "webhook subscription not found" {
+		return http.StatusNotFound
+	}
+	return fallback
+}
@@ -1,9 +1,14 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -22,7 +27,7 @@ func Logger() gin.HandlerFunc {
 			param.ClientIP,
 			param.TimeStamp.Format(time.RFC1123),
 			param.Method,
-			param.Path,
+			redactToken(param.Path),
 			param.Request.Proto,
 			param.StatusCode,
 			param.Latency,
@@ -32,6 +37,107 @@ func Logger() gin.HandlerFunc {
 	})
 }
 
+// tokenQueryParam carries the access token of clients that cannot send an
+// Authorization header, such as the browser EventSource
+const tokenQueryParam = "access_token"
+
+// redactToken hides the access token of a logged request path
+func redactToken(path string) string {
+	i := strings.IndexByte(path, '?')
+	if i < 0 || !strings.Contains(path[i:], tokenQueryParam+"=") {
+		return path
+	}
+	query, err := url.ParseQuery(path[i+1:])
+	if err != nil {
+		return path[:i]
+	}
+	query.Set(tokenQueryParam, "REDACTED")
+	return path[:i+1] + query.Encode()
+}
+
+// TokenFromQuery lets a route authenticate with ?access_token= when the
+// request has no Authorization header. Must be used before AuthRequired.
+func TokenFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query(tokenQueryParam); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		c.Next()
+	}
+}
+
+// PublicOrigin stores the scheme and host clients use to reach the API as
+// "request_origin", for building absolute links. A configured publicURL
+// wins; otherwise the request's own host is used, and X-Forwarded-Proto and
+// X-Forwarded-Host are only honoured when the request comes straight from
+// one of trustedProxies, so clients cannot point links elsewhere.
+func PublicOrigin(publicURL string, trustedProxies []string) (gin.HandlerFunc, error) {
+	publicURL = strings.TrimRight(publicURL, "/")
+	if publicURL != "" {
+		parsed, err := url.Parse(publicURL)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return nil, fmt.Errorf("public URL %q must be an absolute http or https URL", publicURL)
+		}
+	}
+
+	var trusted []*net.IPNet
+	for _, proxy := range trustedProxies {
+		cidr := proxy
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		trusted = append(trusted, network)
+	}
+
+	return func(c *gin.Context) {
+		if publicURL != "" {
+			c.Set("request_origin", publicURL)
+			c.Next()
+			return
+		}
+
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		host := c.Request.Host
+		if fromTrustedProxy(c, trusted) {
+			if proto := c.GetHeader("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+				scheme = proto
+			}
+			if forwarded := c.GetHeader("X-Forwarded-Host"); forwarded != "" {
+				host = forwarded
+			}
+		}
+		c.Set("request_origin", scheme+"://"+host)
+		c.Next()
+	}, nil
+}
+
+// fromTrustedProxy reports whether the direct peer of a request is a trusted proxy
+func fromTrustedProxy(c *gin.Context, trusted []*net.IPNet) bool {
+	ip := net.ParseIP(c.RemoteIP())
+	if ip == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // CORS middleware handles Cross-Origin Resource Sharing
 // Configured for development - allows all origins
 func CORS() gin.HandlerFunc {
@@ -44,7 +150,9 @@ func CORS() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Headers",
 			"Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, "+
 				"Authorization, accept, origin, Cache-Control, X-Requested-With, "+
-				"X-HTTP-Method-Override, Accept, Accept-Language, Content-Language")
+				"X-HTTP-Method-Override, Accept, Accept-Language, Content-Language, "+
+				"Idempotency-Key")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Idempotent-Replayed, Location")
 
 		// Allow all common HTTP methods
 		c.Writer.Header().Set("Access-Control-Allow-Methods",
@@ -237,10 +345,12 @@ func IsOwnerOrAdmin(userIDParam string) gin.HandlerFunc {
 	}
 }
 
-// Check State of unblock In current system
+// Check State of unblock In current system. Routes that do not book anything
+// are open while any window for the user's role is; use CheckBookingWindow
+// on routes that book a category.
 func CheckUnblockState() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if !scheduler.IsUnblockEnabled() {
+		if !scheduler.IsUnblockEnabledForRole(c.GetString("user_role")) {
 			c.JSON(http.StatusForbidden, models.APIResponse{
 				Success: false,
 				Message: "This feature is currently disabled",
@@ -254,6 +364,46 @@ func CheckUnblockState() gin.HandlerFunc {
 	}
 }
 
+// CheckBookingWindow lets a booking through only while a window for the
+// kategori of its JSON body and the user's role is open. A request without a
+// kategori is treated as closed rather than open for any category.
+func CheckBookingWindow() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !scheduler.IsUnblockEnabledFor(requestCategory(c), c.GetString("user_role")) {
+			c.JSON(http.StatusForbidden, models.APIResponse{
+				Success: false,
+				Message: "Booking is closed for this category",
+				Error:   "No unblocking window for this kategori is open",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requestCategory reads the kategori field of a JSON request body, leaving the
+// body in place for the handler. Requests without one get no category.
+func requestCategory(c *gin.Context) models.Category {
+	if c.Request.Body == nil || c.ContentType() != gin.MIMEJSON {
+		return ""
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var fields struct {
+		Kategori models.Category `json:"kategori"`
+	}
+	if json.Unmarshal(body, &fields) != nil {
+		return ""
+	}
+	return fields.Kategori
+}
+
 func CheckUnblockStateReverseTechnique() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if scheduler.IsUnblockEnabled() {
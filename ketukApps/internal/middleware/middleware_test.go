@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/scheduler"
+)
+
+func TestCheckBookingWindowScopesByCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	skripsi := models.Skripsi
+	scheduler.SetOpenWindows([]models.Unblocking{{
+		ID:        1,
+		Kategori:  &skripsi,
+		StartDate: time.Now().Add(-time.Hour),
+		EndDate:   time.Now().Add(time.Hour),
+	}})
+	defer scheduler.SetOpenWindows(nil)
+
+	router := gin.New()
+	router.POST("/tickets", func(c *gin.Context) {
+		c.Set("user_role", "user")
+	}, CheckBookingWindow(), func(c *gin.Context) {
+		var req models.CreateTicketRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"open category", `{"userId":1,"title":"Sidang","description":"Sidang skripsi","kategori":"Skripsi"}`, http.StatusCreated},
+		{"other category", `{"userId":1,"title":"Praktikum","description":"Praktikum jaringan","kategori":"Praktikum"}`, http.StatusForbidden},
+		{"no category", `{"userId":1,"title":"Praktikum","description":"Praktikum jaringan"}`, http.StatusForbidden},
+		{"not json", `kategori=Skripsi`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tickets", strings.NewReader(tt.body))
+			if strings.HasPrefix(tt.body, "{") {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCheckUnblockStateIsOpenWhileAnyWindowForTheRoleIs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	admin := "admin"
+	scheduler.SetOpenWindows([]models.Unblocking{{ID: 1, Role: &admin}})
+	defer scheduler.SetOpenWindows(nil)
+
+	for role, want := range map[string]int{"admin": http.StatusOK, "user": http.StatusForbidden} {
+		router := gin.New()
+		router.GET("/tickets", func(c *gin.Context) {
+			c.Set("user_role", role)
+		}, CheckUnblockState(), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tickets", nil))
+		if w.Code != want {
+			t.Errorf("%s: status = %d, want %d", role, w.Code, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// BookingStatus tracks a booking request through the schedule queue
+type BookingStatus string
+
+const (
+	BookingQueued      BookingStatus = "queued"
+	BookingUnpublished BookingStatus = "unpublished"
+	BookingProcessed   BookingStatus = "processed"
+	BookingRejected    BookingStatus = "rejected"
+)
+
+// BookingRequest represents the booking_requests table. ID is the tracking
+// ID returned to the client and the message ID on the queue.
+// @Description Booking submitted through the queue and its processing status
+type BookingRequest struct {
+	ID             string        `json:"id" gorm:"primaryKey;column:id;type:uuid" example:"7f1c2b9e-5d4a-4c1e-9a43-2f6d1e0b8c71"`
+	UserID         uint          `json:"userId" gorm:"column:user_id;not null" example:"1"`
+	IdempotencyKey *string       `json:"-" gorm:"column:idempotency_key;size:255"`
+	RequestHash    string        `json:"-" gorm:"column:request_hash;size:64;not null"`
+	Title          string        `json:"title" gorm:"column:title;size:255;not null" example:"Praktikum Jaringan Komputer"`
+	Description    string        `json:"description" gorm:"column:description;not null" example:"Praktikum routing statis untuk kelas B"`
+	Kategori       Category      `json:"kategori" gorm:"column:kategori;type:ticket_category;not null" example:"Praktikum"`
+	RoomID         int           `json:"roomId" gorm:"column:room_id;not null" example:"1"`
+	StartDate      time.Time     `json:"startDate" gorm:"column:start_date;not null" example:"2025-03-17T08:00:00Z"`
+	EndDate        time.Time     `json:"endDate" gorm:"column:end_date;not null" example:"2025-03-17T10:00:00Z"`
+	Status         BookingStatus `json:"status" gorm:"column:status;type:booking_status;not null;default:queued" example:"processed"`
+	TicketID       *uint         `json:"ticketId,omitempty" gorm:"column:ticket_id" example:"12"`
+	Reason         string        `json:"reason,omitempty" gorm:"column:reason" example:"requested slot conflicts with 1 existing schedule(s)"`
+	CreatedAt      time.Time     `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time     `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name for BookingRequest
+func (BookingRequest) TableName() string {
+	return "booking_requests"
+}
+
+// CreateBookingRequest is the request body for submitting a booking. Admins
+// may book on behalf of another user with userId.
+// @Description Request body for submitting a booking
+type CreateBookingRequest struct {
+	UserID      uint      `json:"userId,omitempty" example:"1"`
+	Title       string    `json:"title" binding:"required,max=255" example:"Praktikum Jaringan Komputer"`
+	Description string    `json:"description" binding:"required" example:"Praktikum routing statis untuk kelas B"`
+	Kategori    Category  `json:"kategori" binding:"required,oneof=Kelas Lainnya Praktikum Skripsi" example:"Praktikum"`
+	RoomID      int       `json:"roomId,omitempty" example:"1"`
+	StartDate   time.Time `json:"startDate" binding:"required" example:"2025-03-17T08:00:00+07:00"`
+	EndDate     time.Time `json:"endDate" binding:"required" example:"2025-03-17T10:00:00+07:00"`
+}
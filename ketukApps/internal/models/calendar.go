@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// CalendarFeedToken represents the calendar_feed_tokens table.
+// The plain token is only shown once; TokenHash holds its SHA-256 digest.
+type CalendarFeedToken struct {
+	ID         int        `json:"id" gorm:"primaryKey;column:id"`
+	UserID     int        `json:"userId" gorm:"column:user_id;not null"`
+	TokenHash  string     `json:"-" gorm:"column:token_hash;size:64;not null;uniqueIndex"`
+	CreatedAt  time.Time  `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" gorm:"column:last_used_at"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" gorm:"column:revoked_at"`
+	User       *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// TableName overrides the table name for CalendarFeedToken
+func (CalendarFeedToken) TableName() string {
+	return "calendar_feed_tokens"
+}
+
+// CalendarFeedTokenResponse is returned when a feed token is issued
+// @Description Newly issued calendar feed token and subscription URLs
+type CalendarFeedTokenResponse struct {
+	Token       string    `json:"token" example:"3f9c1b..."`
+	UserFeedURL string    `json:"userFeedUrl" example:"https://ketuk.example.com/api/calendar/v1/feeds/3f9c1b.../user.ics"`
+	LabFeedURL  string    `json:"labFeedUrl" example:"https://ketuk.example.com/api/calendar/v1/feeds/3f9c1b.../lab.ics"`
+	CreatedAt   time.Time `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+}
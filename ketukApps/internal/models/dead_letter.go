@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// DeadLetter is a message that failed permanently or ran out of retries
+// @Description Message in the dead-letter queue
+type DeadLetter struct {
+	ID          string     `json:"id" example:"1718000000000000000-3"`
+	Queue       string     `json:"queue" example:"schedule"`
+	Reason      string     `json:"reason" example:"requested slot conflicts with 1 existing schedule(s)"`
+	Attempts    int        `json:"attempts" example:"1"`
+	FailedAt    *time.Time `json:"failedAt,omitempty" example:"2023-01-01T00:00:00Z"`
+	ContentType string     `json:"contentType,omitempty" example:"application/json"`
+	Body        string     `json:"body" example:"{\"userId\":1,\"title\":\"Praktikum\"}"`
+}
+
+// ReplayDeadLettersRequest selects the dead letters to send back to their queue
+// @Description Dead letter IDs to replay; all dead letters when empty
+type ReplayDeadLettersRequest struct {
+	IDs []string `json:"ids" example:"1718000000000000000-3"`
+}
+
+// ReplayDeadLettersResponse reports the outcome of a replay
+// @Description Number of dead letters sent back to their queue
+type ReplayDeadLettersResponse struct {
+	Replayed int `json:"replayed" example:"1"`
+}
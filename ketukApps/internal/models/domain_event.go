@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// DomainEventType names a domain event. It is also the routing key on the
+// topic exchange, so subscribers can bind to e.g. "ticket.*".
+type DomainEventType string
+
+const (
+	DomainTicketCreated       DomainEventType = "ticket.created"
+	DomainTicketStatusChanged DomainEventType = "ticket.status_changed"
+	DomainScheduleCreated     DomainEventType = "schedule.created"
+	DomainUserCreated         DomainEventType = "user.created"
+	DomainItemUpdated         DomainEventType = "item.updated"
+	DomainUnblockOpened       DomainEventType = "unblock.opened"
+	DomainUnblockClosed       DomainEventType = "unblock.closed"
+)
+
+// DomainEvent is the message published to the topic exchange when something
+// changes. Data depends on Type; Version changes when Data does.
+type DomainEvent struct {
+	ID         string                 `json:"id"`
+	Type       DomainEventType        `json:"type"`
+	Version    int                    `json:"version"`
+	Source     string                 `json:"source"`
+	OccurredAt time.Time              `json:"occurredAt"`
+	Data       map[string]interface{} `json:"data"`
+}
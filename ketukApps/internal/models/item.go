@@ -20,7 +20,7 @@ type Item struct {
 	ID         int           `json:"id" gorm:"primaryKey;column:id" example:"1"`
 	Name       string        `json:"name" gorm:"column:name;size:255;not null" example:"PC-001"`
 	Year       *int          `json:"year,omitempty" gorm:"column:year" example:"2023"`
-	Kondisi    string        `json:"kondisi" gorm:"column:kondisi;size:100" example:"Baik"`
+	Kondisi    Kondisi       `json:"kondisi" gorm:"column:kondisi;type:item_kondisi;default:Baik" example:"Baik"`
 	Note       string        `json:"note" gorm:"column:note;type:text" example:"Kondisi normal, ready to use"`
 	CategoryID int           `json:"categoryId" gorm:"column:category_id;not null" example:"1"`
 	CreatedAt  time.Time     `json:"createdAt" gorm:"column:created_at;autoCreateTime" example:"2023-01-01T00:00:00Z"`
@@ -39,11 +39,22 @@ func (Item) TableName() string {
 }
 
 type CreateItemRequest struct {
-	Name       string `json:"name" gorm:"column:name;size:255;not null" example:"PC-001"`
-	Year       *int   `json:"year,omitempty" gorm:"column:year" example:"2023"`
-	Kondisi    string `json:"kondisi" gorm:"column:kondisi;size:100" example:"Baik"`
-	Note       string `json:"note" gorm:"column:note;type:text" example:"Kondisi normal, ready to use"`
-	CategoryID int    `json:"categoryId" gorm:"column:category_id;not null" example:"1"`
+	Name       string  `json:"name" gorm:"column:name;size:255;not null" example:"PC-001"`
+	Year       *int    `json:"year,omitempty" gorm:"column:year" example:"2023"`
+	Kondisi    Kondisi `json:"kondisi" gorm:"column:kondisi;type:item_kondisi" example:"Baik"`
+	Note       string  `json:"note" gorm:"column:note;type:text" example:"Kondisi normal, ready to use"`
+	CategoryID int     `json:"categoryId" gorm:"column:category_id;not null" example:"1"`
+}
+
+// UpdateItemRequest represents request to update an item. All fields are optional.
+// Changing kondisi must follow the allowed transitions and is recorded in the item history.
+type UpdateItemRequest struct {
+	Name          *string  `json:"name,omitempty" example:"PC-001"`
+	Year          *int     `json:"year,omitempty" example:"2023"`
+	Kondisi       *Kondisi `json:"kondisi,omitempty" example:"Rusak Ringan"`
+	KondisiReason string   `json:"kondisiReason,omitempty" example:"Keyboard tidak berfungsi"`
+	Note          *string  `json:"note,omitempty" example:"Kondisi normal, ready to use"`
+	CategoryID    *int     `json:"categoryId,omitempty" example:"1"`
 }
 
 type CreateItemResponse struct {
@@ -62,3 +73,69 @@ type CreateItemCategoryResponse struct {
 	Message  string       `json:"message" example:"Item category created successfully"`
 	Category ItemCategory `json:"category"`
 }
+
+// InventoryFormat is the file format of an inventory import or export
+type InventoryFormat string
+
+const (
+	InventoryCSV  InventoryFormat = "csv"
+	InventoryXLSX InventoryFormat = "xlsx"
+)
+
+// ItemImportAction is what an import does with a row
+type ItemImportAction string
+
+const (
+	ItemImportCreate    ItemImportAction = "create"
+	ItemImportUpdate    ItemImportAction = "update"
+	ItemImportUnchanged ItemImportAction = "unchanged"
+)
+
+// ItemImportRow is the validation result of one inventory row
+// @Description Validation result of a single imported inventory row
+type ItemImportRow struct {
+	Row      int              `json:"row" example:"2"`
+	Name     string           `json:"name" example:"PC-001"`
+	Category string           `json:"category" example:"Komputer"`
+	Year     *int             `json:"year,omitempty" example:"2023"`
+	Kondisi  Kondisi          `json:"kondisi,omitempty" example:"Baik"`
+	Action   ItemImportAction `json:"action,omitempty" example:"create"`
+	ItemID   int              `json:"itemId,omitempty" example:"12"`
+	Errors   []string         `json:"errors,omitempty"`
+}
+
+// ItemImportReport summarises an inventory import. Items are matched by name
+// within their category. Committed is only true when every row was valid and
+// the import was not a dry run.
+// @Description Result of an inventory import
+type ItemImportReport struct {
+	Format            InventoryFormat `json:"format" example:"xlsx"`
+	DryRun            bool            `json:"dryRun" example:"true"`
+	Committed         bool            `json:"committed" example:"false"`
+	TotalRows         int             `json:"totalRows" example:"120"`
+	ValidRows         int             `json:"validRows" example:"118"`
+	Created           int             `json:"created" example:"20"`
+	Updated           int             `json:"updated" example:"5"`
+	CategoriesCreated []string        `json:"categoriesCreated,omitempty"`
+	Rows              []ItemImportRow `json:"rows"`
+}
+
+// ItemAvailability is the current status of an item as shown after scanning its label
+type ItemAvailability string
+
+const (
+	ItemAvailable   ItemAvailability = "available"
+	ItemOnLoan      ItemAvailability = "on_loan"
+	ItemUnavailable ItemAvailability = "unavailable"
+)
+
+// ItemLookup is what a scanned asset tag resolves to. An item is unavailable
+// when its kondisi does not allow lending it out.
+// @Description Item and its current status, resolved from an asset tag
+type ItemLookup struct {
+	AssetTag    string           `json:"assetTag" example:"KTK-000001"`
+	Item        Item             `json:"item"`
+	Status      ItemAvailability `json:"status" example:"available"`
+	CurrentLoan *ItemLoan        `json:"currentLoan,omitempty"`
+	NextLoan    *ItemLoan        `json:"nextLoan,omitempty"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Kondisi defines the lifecycle state of an item
+type Kondisi string
+
+const (
+	KondisiBaik           Kondisi = "Baik"
+	KondisiRusakRingan    Kondisi = "Rusak Ringan"
+	KondisiRusakBerat     Kondisi = "Rusak Berat"
+	KondisiDalamPerbaikan Kondisi = "Dalam Perbaikan"
+	KondisiDihapuskan     Kondisi = "Dihapuskan"
+)
+
+// KondisiTransitions lists the states each kondisi may move to. Dihapuskan
+// (written off) is final; heavily damaged items have to go through repair.
+var KondisiTransitions = map[Kondisi][]Kondisi{
+	KondisiBaik:           {KondisiRusakRingan, KondisiRusakBerat, KondisiDalamPerbaikan, KondisiDihapuskan},
+	KondisiRusakRingan:    {KondisiBaik, KondisiRusakBerat, KondisiDalamPerbaikan, KondisiDihapuskan},
+	KondisiRusakBerat:     {KondisiDalamPerbaikan, KondisiDihapuskan},
+	KondisiDalamPerbaikan: {KondisiBaik, KondisiRusakRingan, KondisiRusakBerat, KondisiDihapuskan},
+	KondisiDihapuskan:     {},
+}
+
+// IsValid reports whether k is one of the known kondisi values
+func (k Kondisi) IsValid() bool {
+	_, ok := KondisiTransitions[k]
+	return ok
+}
+
+// CanTransitionTo reports whether an item may move from k to next
+func (k Kondisi) CanTransitionTo(next Kondisi) bool {
+	for _, allowed := range KondisiTransitions[k] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ItemKondisiHistory represents the item_kondisi_history table
+// @Description Item kondisi change
+type ItemKondisiHistory struct {
+	ID            int       `json:"id" gorm:"primaryKey;column:id" example:"1"`
+	ItemID        int       `json:"itemId" gorm:"column:item_id;not null" example:"1"`
+	FromKondisi   *Kondisi  `json:"fromKondisi" gorm:"column:from_kondisi;type:item_kondisi" example:"Baik"`
+	ToKondisi     Kondisi   `json:"toKondisi" gorm:"column:to_kondisi;type:item_kondisi;not null" example:"Rusak Ringan"`
+	ChangedBy     *uint     `json:"changedBy,omitempty" gorm:"column:changed_by" example:"2"`
+	ChangedByUser *User     `json:"changedByUser,omitempty" gorm:"foreignKey:ChangedBy"`
+	Reason        string    `json:"reason" gorm:"column:reason;type:text;not null" example:"Keyboard tidak berfungsi"`
+	LoanID        *int      `json:"loanId,omitempty" gorm:"column:loan_id" example:"3"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime" example:"2023-01-01T00:00:00Z"`
+}
+
+// TableName overrides the table name for ItemKondisiHistory
+func (ItemKondisiHistory) TableName() string {
+	return "item_kondisi_history"
+}
+
+// ChangeKondisiRequest represents request to move an item to another kondisi
+type ChangeKondisiRequest struct {
+	Kondisi Kondisi `json:"kondisi" binding:"required" example:"Dalam Perbaikan"`
+	Reason  string  `json:"reason" binding:"required" example:"Dikirim ke teknisi untuk ganti PSU"`
+}
@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// LoanStatus defines the lifecycle state of an equipment loan
+type LoanStatus string
+
+const (
+	LoanRequested  LoanStatus = "requested"
+	LoanApproved   LoanStatus = "approved"
+	LoanRejected   LoanStatus = "rejected"
+	LoanCheckedOut LoanStatus = "checked_out"
+	LoanReturned   LoanStatus = "returned"
+)
+
+// ItemLoan represents the item_loans table. Every loan owns a ticket that
+// goes through the regular ticket approval flow.
+// @Description Equipment loan information
+type ItemLoan struct {
+	ID           int            `json:"id" gorm:"primaryKey;column:id" example:"1"`
+	TicketID     uint           `json:"ticketId" gorm:"column:ticket_id;not null" example:"1"`
+	Ticket       *Ticket        `json:"ticket,omitempty" gorm:"foreignKey:TicketID"`
+	UserID       uint           `json:"userId" gorm:"column:user_id;not null" example:"1"`
+	User         *User          `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	StartDate    time.Time      `json:"startDate" gorm:"column:start_date;not null" example:"2023-12-01T08:00:00Z"`
+	EndDate      time.Time      `json:"endDate" gorm:"column:end_date;not null" example:"2023-12-01T16:00:00Z"`
+	Purpose      string         `json:"purpose" gorm:"column:purpose;type:text;not null" example:"Presentasi tugas akhir"`
+	Status       LoanStatus     `json:"status" gorm:"column:status;type:loan_status;default:requested" example:"requested"`
+	IsOverdue    bool           `json:"isOverdue" gorm:"column:is_overdue;not null;default:false" example:"false"`
+	CheckedOutAt *time.Time     `json:"checkedOutAt,omitempty" gorm:"column:checked_out_at" example:"2023-12-01T08:05:00Z"`
+	CheckedOutBy *uint          `json:"checkedOutBy,omitempty" gorm:"column:checked_out_by" example:"2"`
+	ReturnedAt   *time.Time     `json:"returnedAt,omitempty" gorm:"column:returned_at" example:"2023-12-01T15:55:00Z"`
+	ReturnedBy   *uint          `json:"returnedBy,omitempty" gorm:"column:returned_by" example:"2"`
+	ReturnNote   string         `json:"returnNote,omitempty" gorm:"column:return_note;type:text" example:"Semua barang kembali lengkap"`
+	CreatedAt    time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt    time.Time      `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime" example:"2023-01-01T00:00:00Z"`
+	Items        []ItemLoanItem `json:"items" gorm:"foreignKey:LoanID"`
+}
+
+// ItemLoanItem represents the item_loan_items table
+// @Description Item lent in a loan
+type ItemLoanItem struct {
+	LoanID        int      `json:"loanId" gorm:"primaryKey;column:loan_id" example:"1"`
+	ItemID        int      `json:"itemId" gorm:"primaryKey;column:item_id" example:"1"`
+	Item          *Item    `json:"item,omitempty" gorm:"foreignKey:ItemID"`
+	ReturnKondisi *Kondisi `json:"returnKondisi,omitempty" gorm:"column:return_kondisi;type:item_kondisi" example:"Baik"`
+	ReturnNote    string   `json:"returnNote,omitempty" gorm:"column:return_note;type:text" example:"Kabel HDMI sedikit longgar"`
+}
+
+// TableName overrides the table name for ItemLoan
+func (ItemLoan) TableName() string {
+	return "item_loans"
+}
+
+// TableName overrides the table name for ItemLoanItem
+func (ItemLoanItem) TableName() string {
+	return "item_loan_items"
+}
+
+// LoanConflict describes an existing loan that already holds a requested item
+// @Description Loan holding an item during the requested window
+type LoanConflict struct {
+	LoanID    int        `json:"loanId" example:"3"`
+	ItemID    int        `json:"itemId" example:"1"`
+	ItemName  string     `json:"itemName" example:"PC-001"`
+	Status    LoanStatus `json:"status" example:"approved"`
+	StartDate time.Time  `json:"startDate" example:"2023-12-01T08:00:00Z"`
+	EndDate   time.Time  `json:"endDate" example:"2023-12-01T16:00:00Z"`
+}
+
+// CreateLoanRequest represents request to borrow one or more items
+type CreateLoanRequest struct {
+	ItemIDs   []int     `json:"itemIds" binding:"required,min=1,dive,gt=0" example:"1,2"`
+	StartDate time.Time `json:"startDate" binding:"required" example:"2023-12-01T08:00:00Z"`
+	EndDate   time.Time `json:"endDate" binding:"required" example:"2023-12-01T16:00:00Z"`
+	Purpose   string    `json:"purpose" binding:"required" example:"Presentasi tugas akhir"`
+}
+
+// LoanDecisionRequest represents request to approve or reject a loan
+type LoanDecisionRequest struct {
+	Reason string `json:"reason" example:"Barang tersedia"`
+}
+
+// LoanItemReturn records the condition of one item at check-in
+type LoanItemReturn struct {
+	ItemID  int     `json:"itemId" binding:"required" example:"1"`
+	Kondisi Kondisi `json:"kondisi" binding:"required" example:"Baik"`
+	Note    string  `json:"note" example:"Kabel HDMI sedikit longgar"`
+}
+
+// CheckInLoanRequest represents request to check lent items back in
+type CheckInLoanRequest struct {
+	Note  string           `json:"note" binding:"required" example:"Semua barang kembali lengkap"`
+	Items []LoanItemReturn `json:"items" binding:"dive"`
+}
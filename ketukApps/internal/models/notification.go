@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// NotificationChannel is a way of delivering a notification
+type NotificationChannel string
+
+const (
+	ChannelEmail   NotificationChannel = "email"
+	ChannelWebhook NotificationChannel = "webhook"
+	ChannelInApp   NotificationChannel = "in_app"
+)
+
+// NotificationEvent is the kind of event a notification is about
+type NotificationEvent string
+
+const (
+	NotifyTicketCreated       NotificationEvent = "ticket.created"
+	NotifyTicketStatusChanged NotificationEvent = "ticket.status_changed"
+	NotifyBookingClosed       NotificationEvent = "booking.closed"
+)
+
+// Notification represents the notifications table, the in-app channel
+// @Description In-app notification
+type Notification struct {
+	ID        int               `json:"id" gorm:"primaryKey;column:id" example:"1"`
+	UserID    uint              `json:"userId" gorm:"column:user_id;not null" example:"1"`
+	Event     NotificationEvent `json:"event" gorm:"column:event;size:100;not null" example:"ticket.status_changed"`
+	Subject   string            `json:"subject" gorm:"column:subject;size:255;not null" example:"Ticket #12 Status approved"`
+	Body      string            `json:"body" gorm:"column:body;type:text;not null" example:"Your ticket status has been approved."`
+	Data      *string           `json:"data,omitempty" gorm:"column:data;type:jsonb"`
+	ReadAt    *time.Time        `json:"readAt,omitempty" gorm:"column:read_at" example:"2023-01-01T00:00:00Z"`
+	CreatedAt time.Time         `json:"createdAt" gorm:"column:created_at;autoCreateTime" example:"2023-01-01T00:00:00Z"`
+}
+
+// TableName overrides the table name for Notification
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// NotificationPreference represents the notification_preferences table.
+// A missing row means the channel is enabled.
+// @Description Whether a user receives an event on a channel
+type NotificationPreference struct {
+	UserID    uint                `json:"-" gorm:"primaryKey;column:user_id"`
+	Event     NotificationEvent   `json:"event" gorm:"primaryKey;column:event;size:100" binding:"required" example:"ticket.status_changed"`
+	Channel   NotificationChannel `json:"channel" gorm:"primaryKey;column:channel;size:20" binding:"required" example:"email"`
+	Enabled   bool                `json:"enabled" gorm:"column:enabled;not null" example:"false"`
+	UpdatedAt time.Time           `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime" example:"2023-01-01T00:00:00Z"`
+}
+
+// TableName overrides the table name for NotificationPreference
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// UpdateNotificationPreferencesRequest represents the request body for changing notification preferences
+// @Description Channels to enable or disable per event type
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreference `json:"preferences" binding:"required,min=1,dive"`
+}
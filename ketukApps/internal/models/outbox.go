@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// OutboxStatus is the delivery state of an outbox message
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxDelivered OutboxStatus = "delivered"
+	OutboxFailed    OutboxStatus = "failed"
+)
+
+// Outbox message kinds
+const (
+	OutboxNotification = "notification"
+	OutboxEvent        = "event"
+	OutboxBooking      = "booking"
+)
+
+// OutboxMessage represents the outbox table. Messages are written in the
+// same transaction as the change that caused them and delivered later by
+// the outbox relay.
+// @Description Side effect waiting for or done with delivery
+type OutboxMessage struct {
+	ID            int64        `json:"id" gorm:"primaryKey;column:id" example:"1"`
+	Kind          string       `json:"kind" gorm:"column:kind;size:50;not null" example:"notification"`
+	Destination   string       `json:"destination" gorm:"column:destination;size:100;not null" example:"email"`
+	Payload       string       `json:"payload" gorm:"column:payload;type:jsonb;not null"`
+	Status        OutboxStatus `json:"status" gorm:"column:status;type:outbox_status;default:pending" example:"pending"`
+	Attempts      int          `json:"attempts" gorm:"column:attempts;not null;default:0" example:"0"`
+	LastError     *string      `json:"lastError,omitempty" gorm:"column:last_error;type:text"`
+	NextAttemptAt time.Time    `json:"nextAttemptAt" gorm:"column:next_attempt_at;not null" example:"2023-01-01T00:00:00Z"`
+	DeliveredAt   *time.Time   `json:"deliveredAt,omitempty" gorm:"column:delivered_at" example:"2023-01-01T00:00:00Z"`
+	CreatedAt     time.Time    `json:"createdAt" gorm:"column:created_at;autoCreateTime" example:"2023-01-01T00:00:00Z"`
+}
+
+// TableName overrides the table name for OutboxMessage
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// ListQuery holds the pagination, sorting and filters of a list request.
+// Cursor takes precedence over Page; without Page, Limit or Cursor every row
+// is returned. Filters are keyed by query parameter name and rejected unless
+// the endpoint supports them.
+type ListQuery struct {
+	Page    int
+	Limit   int
+	Cursor  string
+	Sort    string
+	Filters map[string]string
+	From    *time.Time
+	To      *time.Time
+}
+
+// PageMeta describes the page returned by a list endpoint. Page and
+// TotalPages are set for page-based requests, NextCursor for cursor-based
+// ones.
+// @Description Pagination information of a list response
+type PageMeta struct {
+	Page       int    `json:"page,omitempty" example:"1"`
+	Limit      int    `json:"limit" example:"20"`
+	Total      int64  `json:"total" example:"134"`
+	TotalPages int    `json:"totalPages,omitempty" example:"7"`
+	HasMore    bool   `json:"hasMore" example:"true"`
+	NextCursor string `json:"nextCursor,omitempty" example:"MTIz"`
+}
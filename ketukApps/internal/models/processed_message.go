@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ProcessedMessage records an idempotency key that has been used. The key is
+// unique per scope; ResourceID points to what the first request created.
+type ProcessedMessage struct {
+	Scope        string    `json:"scope" gorm:"primaryKey;column:scope;size:100"`
+	Key          string    `json:"key" gorm:"primaryKey;column:idempotency_key;size:255"`
+	RequestHash  string    `json:"requestHash" gorm:"column:request_hash;size:64;not null"`
+	ResourceType string    `json:"resourceType" gorm:"column:resource_type;size:50;not null"`
+	ResourceID   *int64    `json:"resourceId,omitempty" gorm:"column:resource_id"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+// TableName overrides the table name for ProcessedMessage
+func (ProcessedMessage) TableName() string {
+	return "processed_messages"
+}
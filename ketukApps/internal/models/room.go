@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Room represents the rooms table (a lab or room that can be booked)
+// @Description Bookable room information
+type Room struct {
+	ID        int       `json:"id" gorm:"primaryKey;column:id" example:"1"`
+	Name      string    `json:"name" gorm:"column:name;size:100;not null;uniqueIndex" example:"Lab 1"`
+	Location  string    `json:"location" gorm:"column:location;size:255" example:"Gedung A Lantai 2"`
+	Capacity  int       `json:"capacity" gorm:"column:capacity;not null;default:0" example:"40"`
+	OpensAt   *string   `json:"opensAt,omitempty" gorm:"column:opens_at;type:time" example:"07:00"`
+	ClosesAt  *string   `json:"closesAt,omitempty" gorm:"column:closes_at;type:time" example:"17:00"`
+	IsActive  bool      `json:"isActive" gorm:"column:is_active;not null;default:true" example:"true"`
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime" example:"2023-01-01T00:00:00Z"`
+}
+
+// TableName overrides the table name for Room
+func (Room) TableName() string {
+	return "rooms"
+}
+
+// CreateRoomRequest represents request to create a room
+type CreateRoomRequest struct {
+	Name     string  `json:"name" binding:"required" example:"Lab 2"`
+	Location string  `json:"location" example:"Gedung A Lantai 3"`
+	Capacity int     `json:"capacity" binding:"min=0" example:"40"`
+	OpensAt  *string `json:"opensAt,omitempty" example:"07:00"`
+	ClosesAt *string `json:"closesAt,omitempty" example:"17:00"`
+	IsActive *bool   `json:"isActive,omitempty" example:"true"`
+}
+
+// UpdateRoomRequest represents request to update a room. All fields are optional.
+type UpdateRoomRequest struct {
+	Name     *string `json:"name,omitempty" example:"Lab 2"`
+	Location *string `json:"location,omitempty" example:"Gedung A Lantai 3"`
+	Capacity *int    `json:"capacity,omitempty" binding:"omitempty,min=0" example:"40"`
+	OpensAt  *string `json:"opensAt,omitempty" example:"07:00"`
+	ClosesAt *string `json:"closesAt,omitempty" example:"17:00"`
+	IsActive *bool   `json:"isActive,omitempty" example:"false"`
+}
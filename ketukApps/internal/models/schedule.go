@@ -11,10 +11,35 @@ type ScheduleTicket struct {
 	UserID      int       `json:"userId" gorm:"column:user_id;not null"`
 	Kategori    Category  `json:"kategori" gorm:"column:kategori;type:ticket_category;not null"`
 	Description string    `json:"description" gorm:"column:description;type:text"`
-	CreatedAt   time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
-	UpdatedAt   time.Time `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
-	User        *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
-	Tickets     []Ticket  `json:"tickets,omitempty" gorm:"foreignKey:IDSchedule;references:IDSchedule"`
+	IsBooked    bool      `json:"isBooked" gorm:"column:is_booked;default:false"`
+	// UnbookedReason is set when the overlap guard migration took the slot away
+	UnbookedReason *string   `json:"unbookedReason,omitempty" gorm:"column:unbooked_reason"`
+	RoomID         int       `json:"roomId" gorm:"column:room_id;not null"`
+	CreatedAt      time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	User           *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Room           *Room     `json:"room,omitempty" gorm:"foreignKey:RoomID"`
+	Tickets        []Ticket  `json:"tickets,omitempty" gorm:"foreignKey:IDSchedule;references:IDSchedule"`
+}
+
+// ScheduleSource identifies which table a conflicting schedule comes from
+type ScheduleSource string
+
+const (
+	ScheduleSourceTicket  ScheduleSource = "ticket"
+	ScheduleSourceReguler ScheduleSource = "reguler"
+)
+
+// ScheduleConflict describes an existing schedule that overlaps a requested slot
+// @Description Existing schedule clashing with the requested time slot
+type ScheduleConflict struct {
+	Source     ScheduleSource `json:"source" example:"ticket"`
+	IDSchedule int            `json:"idSchedule" example:"1"`
+	Title      string         `json:"title" example:"Praktikum Basis Data"`
+	StartDate  time.Time      `json:"startDate" example:"2023-12-01T09:00:00Z"`
+	EndDate    time.Time      `json:"endDate" example:"2023-12-01T11:00:00Z"`
+	UserID     int            `json:"userId" example:"1"`
+	RoomID     int            `json:"roomId" example:"1"`
 }
 
 // SemesterCategory defines the semester type
@@ -25,7 +50,9 @@ const (
 	SemesterGenap  SemesterCategory = "Genap"
 )
 
-// Unblocking represents the unblocking table (semester unblocking)
+// Unblocking represents the unblocking table (semester unblocking).
+// Kategori and Role narrow the window to one booking category or role; nil
+// means every category or role.
 type Unblocking struct {
 	ID        int              `json:"id" gorm:"primaryKey;column:id"`
 	Tahun     int              `json:"tahun" gorm:"column:tahun;not null"`
@@ -34,18 +61,65 @@ type Unblocking struct {
 	StartDate time.Time        `json:"startDate" gorm:"column:start_date;not null"`
 	UserID    int              `json:"userId" gorm:"column:user_id;not null"`
 	EndDate   time.Time        `json:"endDate" gorm:"column:end_date;not null"`
+	Kategori  *Category        `json:"kategori,omitempty" gorm:"column:kategori;type:ticket_category"`
+	Role      *string          `json:"role,omitempty" gorm:"column:role;type:user_role"`
 	User      *User            `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
-// ScheduleReguler represents the schedule_reguler table
+// AppliesTo reports whether the window opens booking of category for role.
+// An empty category or role matches windows of any category or role.
+func (u Unblocking) AppliesTo(category Category, role string) bool {
+	if u.Kategori != nil && category != "" && *u.Kategori != category {
+		return false
+	}
+	if u.Role != nil && role != "" && *u.Role != role {
+		return false
+	}
+	return true
+}
+
+// Scope describes who the window opens booking for, e.g. "Praktikum for user"
+func (u Unblocking) Scope() string {
+	scope := "every category"
+	if u.Kategori != nil {
+		scope = string(*u.Kategori)
+	}
+	if u.Role != nil {
+		scope += " for " + *u.Role
+	}
+	return scope
+}
+
+// ScheduleReguler represents the schedule_reguler table.
+// StartDate/EndDate describe the first occurrence; RRule repeats it weekly.
 type ScheduleReguler struct {
-	IDSchedule int       `json:"idSchedule" gorm:"primaryKey;column:id_schedule"`
-	Title      string    `json:"title" gorm:"column:title;size:255;not null"`
-	StartDate  time.Time `json:"startDate" gorm:"column:start_date;not null"`
-	EndDate    time.Time `json:"endDate" gorm:"column:end_date;not null"`
-	UserID     int       `json:"userId" gorm:"column:user_id;not null"`
-	CreatedAt  time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
-	User       *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	IDSchedule int               `json:"idSchedule" gorm:"primaryKey;column:id_schedule"`
+	Title      string            `json:"title" gorm:"column:title;size:255;not null"`
+	StartDate  time.Time         `json:"startDate" gorm:"column:start_date;not null"`
+	EndDate    time.Time         `json:"endDate" gorm:"column:end_date;not null"`
+	UserID     int               `json:"userId" gorm:"column:user_id;not null"`
+	RRule      string            `json:"rrule,omitempty" gorm:"column:rrule;type:text" example:"FREQ=WEEKLY;BYDAY=MO"`
+	ExDates    []time.Time       `json:"exDates,omitempty" gorm:"column:exdates;type:jsonb;serializer:json"`
+	Tahun      *int              `json:"tahun,omitempty" gorm:"column:tahun" example:"2023"`
+	Semester   *SemesterCategory `json:"semester,omitempty" gorm:"column:semester;type:semester_category" example:"Ganjil"`
+	RoomID     int               `json:"roomId" gorm:"column:room_id;not null" example:"1"`
+	CreatedAt  time.Time         `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	User       *User             `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Room       *Room             `json:"room,omitempty" gorm:"foreignKey:RoomID"`
+}
+
+// ScheduleRegulerOccurrence is a single expanded occurrence of a regular schedule
+// @Description Expanded occurrence of a regular schedule
+type ScheduleRegulerOccurrence struct {
+	IDSchedule int       `json:"idSchedule" example:"1"`
+	Title      string    `json:"title" example:"Praktikum Basis Data"`
+	StartDate  time.Time `json:"startDate" example:"2023-09-04T08:00:00Z"`
+	EndDate    time.Time `json:"endDate" example:"2023-09-04T10:00:00Z"`
+	UserID     int       `json:"userId" example:"1"`
+	Recurring  bool      `json:"recurring" example:"true"`
+	RoomID     int       `json:"roomId" example:"1"`
+	User       *User     `json:"user,omitempty"`
+	Room       *Room     `json:"room,omitempty"`
 }
 
 // TableName overrides the table name for ScheduleTicket
@@ -75,6 +149,21 @@ type UnblockingsResponse struct {
 	Success     bool         `json:"success" example:"true"`
 	Message     string       `json:"message" example:"Unblocking operation completed successfully"`
 	Unblockings []Unblocking `json:"unblockings,omitempty"`
+	Meta        *PageMeta    `json:"meta,omitempty"`
+}
+
+// EffectiveUnblocking explains whether booking is open at a moment and which
+// window decides it
+// @Description Unblocking window in effect at a moment and why
+type EffectiveUnblocking struct {
+	At       time.Time    `json:"at" example:"2023-09-05T10:00:00+07:00"`
+	Open     bool         `json:"open" example:"true"`
+	Window   *Unblocking  `json:"window,omitempty"`
+	Kategori Category     `json:"kategori,omitempty" example:"Praktikum"`
+	Role     string       `json:"role,omitempty" example:"user"`
+	Reason   string       `json:"reason" example:"window 3 (Praktikum) covers this moment"`
+	Covering []Unblocking `json:"covering,omitempty"`
+	Next     *Unblocking  `json:"next,omitempty"`
 }
 
 type CreateUnblockingRequest struct {
@@ -83,21 +172,33 @@ type CreateUnblockingRequest struct {
 	StartDate time.Time        `json:"startDate" binding:"required" example:"2023-09-01T00:00:00Z"`
 	EndDate   time.Time        `json:"endDate" binding:"required" example:"2023-12-31T00:00:00Z"`
 	UserID    int              `json:"userId" binding:"required" example:"1"`
+	Kategori  *Category        `json:"kategori,omitempty" binding:"omitempty,oneof=Kelas Lainnya Praktikum Skripsi" example:"Praktikum"`
+	Role      *string          `json:"role,omitempty" binding:"omitempty,oneof=admin user" example:"user"`
 }
 
 // CreateScheduleRegulerRequest represents request to create schedule reguler
 type CreateScheduleRegulerRequest struct {
-	Title     string    `json:"title" binding:"required" example:"Regular Maintenance Schedule"`
-	StartDate time.Time `json:"startDate" binding:"required" example:"2023-12-01T09:00:00Z"`
-	EndDate   time.Time `json:"endDate" binding:"required" example:"2023-12-01T17:00:00Z"`
-	UserID    int       `json:"userId" binding:"required" example:"1"`
+	Title     string            `json:"title" binding:"required" example:"Regular Maintenance Schedule"`
+	StartDate time.Time         `json:"startDate" binding:"required" example:"2023-12-01T09:00:00Z"`
+	EndDate   time.Time         `json:"endDate" binding:"required" example:"2023-12-01T17:00:00Z"`
+	UserID    int               `json:"userId" binding:"required" example:"1"`
+	RRule     string            `json:"rrule,omitempty" example:"FREQ=WEEKLY;BYDAY=MO,WE"`
+	ExDates   []time.Time       `json:"exDates,omitempty"`
+	Tahun     *int              `json:"tahun,omitempty" example:"2023"`
+	Semester  *SemesterCategory `json:"semester,omitempty" binding:"omitempty,oneof=Ganjil Genap" example:"Ganjil"`
+	RoomID    int               `json:"roomId,omitempty" example:"1"`
 }
 
 // UpdateScheduleRegulerRequest represents request to update schedule reguler
 type UpdateScheduleRegulerRequest struct {
-	Title     *string    `json:"title,omitempty" example:"Updated Schedule Title"`
-	StartDate *time.Time `json:"startDate,omitempty" example:"2023-12-01T09:00:00Z"`
-	EndDate   *time.Time `json:"endDate,omitempty" example:"2023-12-01T17:00:00Z"`
+	Title     *string           `json:"title,omitempty" example:"Updated Schedule Title"`
+	StartDate *time.Time        `json:"startDate,omitempty" example:"2023-12-01T09:00:00Z"`
+	EndDate   *time.Time        `json:"endDate,omitempty" example:"2023-12-01T17:00:00Z"`
+	RRule     *string           `json:"rrule,omitempty" example:"FREQ=WEEKLY;BYDAY=MO;COUNT=16"`
+	ExDates   *[]time.Time      `json:"exDates,omitempty"`
+	Tahun     *int              `json:"tahun,omitempty" example:"2023"`
+	Semester  *SemesterCategory `json:"semester,omitempty" binding:"omitempty,oneof=Ganjil Genap" example:"Ganjil"`
+	RoomID    *int              `json:"roomId,omitempty" example:"1"`
 }
 
 // CreateScheduleTicketRequest represents request to create schedule ticket
@@ -108,6 +209,7 @@ type CreateScheduleTicketRequest struct {
 	UserID      int       `json:"userId" binding:"required" example:"1"`
 	Kategori    Category  `json:"kategori" binding:"required" example:"barang"`
 	Description string    `json:"description" example:"Scheduled network maintenance"`
+	RoomID      int       `json:"roomId,omitempty" example:"1"`
 }
 
 // UpdateScheduleTicketRequest represents request to update schedule ticket
@@ -117,4 +219,40 @@ type UpdateScheduleTicketRequest struct {
 	EndDate     *time.Time `json:"endDate,omitempty" example:"2023-12-01T17:00:00Z"`
 	Kategori    *Category  `json:"kategori,omitempty" example:"barang"`
 	Description *string    `json:"description,omitempty" example:"Updated description"`
+	RoomID      *int       `json:"roomId,omitempty" example:"1"`
+}
+
+// ScheduleImportFormat is the file format of a regular schedule import
+type ScheduleImportFormat string
+
+const (
+	ScheduleImportCSV ScheduleImportFormat = "csv"
+	ScheduleImportICS ScheduleImportFormat = "ics"
+)
+
+// ScheduleImportRow is the validation result of one CSV row or ICS event
+// @Description Validation result of a single imported row
+type ScheduleImportRow struct {
+	Row         int        `json:"row" example:"2"`
+	Title       string     `json:"title" example:"Praktikum Basis Data"`
+	Email       string     `json:"email" example:"dosen@example.com"`
+	Room        string     `json:"room,omitempty" example:"Lab 1"`
+	StartDate   *time.Time `json:"startDate,omitempty" example:"2023-09-04T08:00:00Z"`
+	EndDate     *time.Time `json:"endDate,omitempty" example:"2023-09-04T10:00:00Z"`
+	RRule       string     `json:"rrule,omitempty" example:"FREQ=WEEKLY;BYDAY=MO"`
+	Occurrences int        `json:"occurrences" example:"16"`
+	IDSchedule  int        `json:"idSchedule,omitempty" example:"12"`
+	Errors      []string   `json:"errors,omitempty"`
+}
+
+// ScheduleImportReport summarises a regular schedule import.
+// Committed is only true when every row was valid and the import was not a dry run.
+// @Description Result of a regular schedule import
+type ScheduleImportReport struct {
+	Format    ScheduleImportFormat `json:"format" example:"csv"`
+	DryRun    bool                 `json:"dryRun" example:"true"`
+	Committed bool                 `json:"committed" example:"false"`
+	TotalRows int                  `json:"totalRows" example:"20"`
+	ValidRows int                  `json:"validRows" example:"19"`
+	Rows      []ScheduleImportRow  `json:"rows"`
 }
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// SearchType is the kind of record a search hit points to
+type SearchType string
+
+const (
+	SearchTicket          SearchType = "ticket"
+	SearchItem            SearchType = "item"
+	SearchScheduleTicket  SearchType = "schedule_ticket"
+	SearchScheduleReguler SearchType = "schedule_reguler"
+)
+
+// SearchHit is one ranked search result. Headline and Snippet contain the
+// matched words wrapped in <mark> tags.
+// @Description Ranked full-text search result
+type SearchHit struct {
+	Type      SearchType `json:"type" example:"ticket"`
+	ID        int        `json:"id" example:"12"`
+	Title     string     `json:"title" example:"Peminjaman ruang lab"`
+	Headline  string     `json:"headline" example:"<mark>Peminjaman</mark> ruang lab"`
+	Snippet   string     `json:"snippet,omitempty" example:"untuk <mark>praktikum</mark> jaringan"`
+	Subtitle  string     `json:"subtitle,omitempty" example:"pending"`
+	Rank      float64    `json:"rank" example:"0.6079"`
+	CreatedAt time.Time  `json:"createdAt" example:"2023-01-01T00:00:00Z"`
+}
+
+// SearchResults are the hits of every searched type, best match first
+// @Description Unified full-text search results
+type SearchResults struct {
+	Query  string             `json:"query" example:"lab jaringan"`
+	Total  int                `json:"total" example:"3"`
+	Counts map[SearchType]int `json:"counts"`
+	Hits   []SearchHit        `json:"hits"`
+}
@@ -11,7 +11,10 @@ type Ticket struct {
 	Title       string       `json:"title" gorm:"column:title;size:100;not null" example:"Room Booking Request"`
 	Description string       `json:"description" gorm:"column:description;type:text" example:"Need to book conference room for meeting"`
 	Status      TicketStatus `json:"status" gorm:"column:status;type:ticket_status;default:pending" example:"pending"`
+	Kategori    Category     `json:"kategori" gorm:"column:category;type:ticket_category;default:Lainnya" example:"Praktikum"`
 	IDSchedule  *int         `json:"idSchedule,omitempty" gorm:"column:id_schedule" example:"1"`
+	RoomID      *int         `json:"roomId,omitempty" gorm:"column:room_id" example:"1"`
+	Room        *Room        `json:"room,omitempty" gorm:"foreignKey:RoomID"`
 	CreatedAt   time.Time    `json:"createdAt" gorm:"column:created_at;autoCreateTime" example:"2023-01-01T00:00:00Z"`
 	UpdatedAt   time.Time    `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime" example:"2023-01-01T00:00:00Z"`
 	ApprovedAt  *time.Time   `json:"approvedAt,omitempty" gorm:"column:approved_at" example:"2023-01-02T00:00:00Z"`
@@ -40,9 +43,11 @@ const (
 // CreateTicketRequest is the request body for creating a new ticket
 // @Description Request body for creating a new ticket
 type CreateTicketRequest struct {
-	UserID      uint   `json:"userId" binding:"required" example:"1"`
-	Title       string `json:"title" binding:"required" example:"Room Booking Request"`
-	Description string `json:"description" binding:"required" example:"Need to book conference room for meeting"`
+	UserID      uint     `json:"userId" binding:"required" example:"1"`
+	Title       string   `json:"title" binding:"required" example:"Room Booking Request"`
+	Description string   `json:"description" binding:"required" example:"Need to book conference room for meeting"`
+	Kategori    Category `json:"kategori" binding:"required,oneof=Kelas Lainnya Praktikum Skripsi" example:"Praktikum"`
+	RoomID      int      `json:"roomId,omitempty" example:"1"`
 }
 
 // UpdateTicketRequest is the request body for updating a ticket
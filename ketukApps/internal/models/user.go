@@ -14,10 +14,17 @@ type User struct {
 	Email     string    `json:"email" binding:"required,email" gorm:"uniqueIndex;size:255;not null" example:"john.doe@example.com"`
 	Password  string    `json:"-" gorm:"size:255"` // Password hash, not included in JSON responses
 	Role      string    `json:"role" gorm:"type:user_role;default:user" example:"user"`
+	Locale    string    `json:"locale" gorm:"size:5;default:id" example:"id"` // Language of notifications, id or en
 	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
 
+// Notification languages
+const (
+	LocaleID = "id"
+	LocaleEN = "en"
+)
+
 // CreateUserRequest represents the request body for creating a new user
 // @Description Request body for creating a new user
 type CreateUserRequest struct {
@@ -29,9 +36,10 @@ type CreateUserRequest struct {
 // UpdateUserRequest represents the request body for updating a user
 // @Description Request body for updating user information
 type UpdateUserRequest struct {
-	Name  string `json:"name,omitempty" example:"Jane Doe"`
-	Email string `json:"email,omitempty" example:"jane.doe@example.com"`
-	Role  string `json:"role,omitempty" example:"admin"`
+	Name   string `json:"name,omitempty" example:"Jane Doe"`
+	Email  string `json:"email,omitempty" example:"jane.doe@example.com"`
+	Role   string `json:"role,omitempty" example:"admin"`
+	Locale string `json:"locale,omitempty" binding:"omitempty,oneof=id en" example:"en"`
 }
 
 // APIResponse represents a standard API response
@@ -40,15 +48,25 @@ type APIResponse struct {
 	Success bool        `json:"success" example:"true"`
 	Message string      `json:"message" example:"Operation completed successfully"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    *PageMeta   `json:"meta,omitempty"`
 	Error   string      `json:"error,omitempty" example:""`
 }
 
 // HealthResponse represents a health check response
 // @Description Health check response format
 type HealthResponse struct {
-	Status    string `json:"status" example:"healthy"`
-	Timestamp string `json:"timestamp" example:"2023-01-01T00:00:00Z"`
-	Version   string `json:"version" example:"1.0.0"`
+	Status     string                     `json:"status" example:"healthy"`
+	Timestamp  string                     `json:"timestamp" example:"2023-01-01T00:00:00Z"`
+	Version    string                     `json:"version" example:"1.0.0"`
+	Components map[string]ComponentHealth `json:"components,omitempty"`
+}
+
+// ComponentHealth describes the state of a dependency such as the message queue
+// @Description State of a dependency
+type ComponentHealth struct {
+	Status string `json:"status" example:"connected"`
+	Since  string `json:"since" example:"2023-01-01T00:00:00Z"`
+	Error  string `json:"error,omitempty" example:""`
 }
 
 // RefreshTokenRequest represents the request body for refreshing token
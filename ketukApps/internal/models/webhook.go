@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// WebhookEventTypes are the domain events webhooks can subscribe to
+var WebhookEventTypes = []DomainEventType{
+	DomainTicketCreated,
+	DomainTicketStatusChanged,
+	DomainScheduleCreated,
+	DomainItemUpdated,
+}
+
+// WebhookSubscription represents the webhook_subscriptions table. Secret is
+// never returned after the subscription is created.
+// @Description URL that receives signed domain events
+type WebhookSubscription struct {
+	ID          int               `json:"id" gorm:"primaryKey;column:id" example:"1"`
+	URL         string            `json:"url" gorm:"column:url;not null" example:"https://lab.example.ac.id/hooks/ketuk"`
+	Description string            `json:"description,omitempty" gorm:"column:description;size:255" example:"Inventory dashboard"`
+	EventTypes  []DomainEventType `json:"eventTypes" gorm:"column:event_types;type:jsonb;serializer:json;not null" example:"ticket.created,item.updated"`
+	Secret      string            `json:"-" gorm:"column:secret;size:255;not null"`
+	IsActive    bool              `json:"isActive" gorm:"column:is_active;not null" example:"true"`
+	CreatedBy   *uint             `json:"createdBy,omitempty" gorm:"column:created_by" example:"1"`
+	CreatedAt   time.Time         `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time         `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name for WebhookSubscription
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookSubscriptionWithSecret is returned once, when a subscription is
+// created or its secret is rotated
+// @Description Webhook subscription including its signing secret
+type WebhookSubscriptionWithSecret struct {
+	WebhookSubscription
+	Secret string `json:"secret" example:"3f9a6c1e0b7d4e2a9c5f8b1d6e3a7c0f"`
+}
+
+// CreateWebhookSubscriptionRequest represents request to create a webhook
+// subscription. A secret is generated when none is given.
+// @Description Request body for creating a webhook subscription
+type CreateWebhookSubscriptionRequest struct {
+	URL         string            `json:"url" binding:"required,url" example:"https://lab.example.ac.id/hooks/ketuk"`
+	Description string            `json:"description" binding:"max=255" example:"Inventory dashboard"`
+	EventTypes  []DomainEventType `json:"eventTypes" binding:"required,min=1" example:"ticket.created,item.updated"`
+	Secret      string            `json:"secret,omitempty" binding:"omitempty,min=16,max=255" example:"3f9a6c1e0b7d4e2a9c5f8b1d6e3a7c0f"`
+	IsActive    *bool             `json:"isActive,omitempty" example:"true"`
+}
+
+// UpdateWebhookSubscriptionRequest represents request to update a webhook
+// subscription. All fields are optional; rotateSecret generates a new secret.
+// @Description Request body for updating a webhook subscription
+type UpdateWebhookSubscriptionRequest struct {
+	URL          *string           `json:"url,omitempty" binding:"omitempty,url" example:"https://lab.example.ac.id/hooks/ketuk"`
+	Description  *string           `json:"description,omitempty" binding:"omitempty,max=255" example:"Inventory dashboard"`
+	EventTypes   []DomainEventType `json:"eventTypes,omitempty" binding:"omitempty,min=1" example:"ticket.created"`
+	IsActive     *bool             `json:"isActive,omitempty" example:"false"`
+	RotateSecret bool              `json:"rotateSecret,omitempty" example:"false"`
+}
+
+// WebhookDeliveryStatus is the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookPending   WebhookDeliveryStatus = "pending"
+	WebhookSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery represents the webhook_deliveries table: one event sent to
+// one subscription, with the outcome of its last attempt
+// @Description Delivery of an event to a webhook subscription
+type WebhookDelivery struct {
+	ID             int64                 `json:"id" gorm:"primaryKey;column:id" example:"1"`
+	SubscriptionID int                   `json:"subscriptionId" gorm:"column:subscription_id;not null" example:"1"`
+	EventID        string                `json:"eventId" gorm:"column:event_id;type:uuid;not null" example:"7f1c2b9e-5d4a-4c1e-9a43-2f6d1e0b8c71"`
+	EventType      DomainEventType       `json:"eventType" gorm:"column:event_type;size:100;not null" example:"ticket.created"`
+	Payload        string                `json:"payload" gorm:"column:payload;type:jsonb;not null"`
+	Status         WebhookDeliveryStatus `json:"status" gorm:"column:status;type:webhook_delivery_status;not null;default:pending" example:"succeeded"`
+	Attempts       int                   `json:"attempts" gorm:"column:attempts;not null;default:0" example:"1"`
+	ResponseCode   *int                  `json:"responseCode,omitempty" gorm:"column:response_code" example:"200"`
+	ResponseBody   *string               `json:"responseBody,omitempty" gorm:"column:response_body;type:text"`
+	LastError      *string               `json:"lastError,omitempty" gorm:"column:last_error;type:text"`
+	DurationMs     *int                  `json:"durationMs,omitempty" gorm:"column:duration_ms" example:"142"`
+	RedeliveryOf   *int64                `json:"redeliveryOf,omitempty" gorm:"column:redelivery_of" example:"1"`
+	NextAttemptAt  time.Time             `json:"nextAttemptAt" gorm:"column:next_attempt_at;not null"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty" gorm:"column:delivered_at"`
+	CreatedAt      time.Time             `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt      time.Time             `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName overrides the table name for WebhookDelivery
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
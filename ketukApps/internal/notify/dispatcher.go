@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"ketukApps/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// DefaultRoutes lists the channels each event is delivered on
+var DefaultRoutes = map[models.NotificationEvent][]models.NotificationChannel{
+	models.NotifyTicketCreated:       {models.ChannelEmail, models.ChannelInApp, models.ChannelWebhook},
+	models.NotifyTicketStatusChanged: {models.ChannelEmail, models.ChannelInApp, models.ChannelWebhook},
+	models.NotifyBookingClosed:       {models.ChannelEmail, models.ChannelInApp, models.ChannelWebhook},
+}
+
+// Preferences tells whether a user wants an event on a channel
+type Preferences interface {
+	Enabled(ctx context.Context, userID uint, event models.NotificationEvent, channel models.NotificationChannel) (bool, error)
+}
+
+// Dispatcher sends a notification to every channel routed for its event,
+// skipping channels the recipient switched off
+type Dispatcher struct {
+	notifiers map[models.NotificationChannel]Notifier
+	routes    map[models.NotificationEvent][]models.NotificationChannel
+	prefs     Preferences
+}
+
+// NewDispatcher creates a dispatcher using DefaultRoutes. prefs may be nil
+// to send on every routed channel.
+func NewDispatcher(prefs Preferences, notifiers ...Notifier) *Dispatcher {
+	d := &Dispatcher{
+		notifiers: make(map[models.NotificationChannel]Notifier),
+		routes:    make(map[models.NotificationEvent][]models.NotificationChannel),
+		prefs:     prefs,
+	}
+	for event, channels := range DefaultRoutes {
+		d.routes[event] = channels
+	}
+	for _, notifier := range notifiers {
+		d.Register(notifier)
+	}
+	return d
+}
+
+// Register adds or replaces the notifier of a channel
+func (d *Dispatcher) Register(notifier Notifier) {
+	d.notifiers[notifier.Channel()] = notifier
+}
+
+// Route sets the channels an event is delivered on
+func (d *Dispatcher) Route(event models.NotificationEvent, channels ...models.NotificationChannel) {
+	d.routes[event] = channels
+}
+
+// Channels returns the channels an event is delivered on
+func (d *Dispatcher) Channels(event models.NotificationEvent) []models.NotificationChannel {
+	return d.routes[event]
+}
+
+// Dispatch delivers n on its routed channels. A failing channel does not
+// stop the others; all failures are returned together.
+func (d *Dispatcher) Dispatch(ctx context.Context, n Notification) error {
+	var errs []error
+	for _, channel := range d.routes[n.Event] {
+		if err := d.Deliver(ctx, channel, n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Deliver sends n on a single channel unless the recipient switched it off.
+// Channels without a registered notifier are skipped.
+func (d *Dispatcher) Deliver(ctx context.Context, channel models.NotificationChannel, n Notification) error {
+	notifier, ok := d.notifiers[channel]
+	if !ok {
+		return nil
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+
+	if d.prefs != nil && n.UserID != 0 {
+		enabled, err := d.prefs.Enabled(ctx, n.UserID, n.Event, channel)
+		if err != nil {
+			return fmt.Errorf("failed to read preferences: %w", err)
+		}
+		if !enabled {
+			return nil
+		}
+	}
+
+	if err := notifier.Notify(ctx, n); err != nil {
+		return err
+	}
+	log.Printf("Sent %s notification to user %d via %s", n.Event, n.UserID, channel)
+	return nil
+}
+
+// PreferenceStore reads preferences from the notification_preferences table
+type PreferenceStore struct {
+	db *gorm.DB
+}
+
+func NewPreferenceStore(db *gorm.DB) *PreferenceStore {
+	return &PreferenceStore{
+		db: db,
+	}
+}
+
+func (p *PreferenceStore) Enabled(ctx context.Context, userID uint, event models.NotificationEvent, channel models.NotificationChannel) (bool, error) {
+	var prefs []models.NotificationPreference
+	err := p.db.WithContext(ctx).
+		Where("user_id = ? AND event = ? AND channel = ?", userID, event, channel).
+		Limit(1).
+		Find(&prefs).Error
+	if err != nil {
+		return false, err
+	}
+	if len(prefs) == 0 {
+		return true, nil
+	}
+	return prefs[0].Enabled, nil
+}
@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"ketukApps/internal/models"
+)
+
+// failingNotifier fails every delivery on its channel
+type failingNotifier struct {
+	channel models.NotificationChannel
+}
+
+func (f failingNotifier) Channel() models.NotificationChannel {
+	return f.channel
+}
+
+func (f failingNotifier) Notify(ctx context.Context, n Notification) error {
+	return errors.New("smtp server unreachable")
+}
+
+// optOuts disables the listed event/channel pairs for every user
+type optOuts map[models.NotificationEvent]map[models.NotificationChannel]bool
+
+func (o optOuts) Enabled(ctx context.Context, userID uint, event models.NotificationEvent, channel models.NotificationChannel) (bool, error) {
+	return !o[event][channel], nil
+}
+
+func TestDispatchRoutesEventsToTheirChannels(t *testing.T) {
+	email := NewLogNotifier(models.ChannelEmail)
+	inApp := NewLogNotifier(models.ChannelInApp)
+	webhook := NewLogNotifier(models.ChannelWebhook)
+	d := NewDispatcher(nil, email, inApp, webhook)
+	d.Route(models.NotifyBookingClosed, models.ChannelInApp)
+
+	ctx := context.Background()
+	if err := d.Dispatch(ctx, Notification{Event: models.NotifyTicketCreated, UserID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Dispatch(ctx, Notification{Event: models.NotifyBookingClosed, UserID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Events without a route are not sent anywhere
+	if err := d.Dispatch(ctx, Notification{Event: "ticket.deleted", UserID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		notifier *LogNotifier
+		want     []models.NotificationEvent
+	}{
+		{email, []models.NotificationEvent{models.NotifyTicketCreated}},
+		{inApp, []models.NotificationEvent{models.NotifyTicketCreated, models.NotifyBookingClosed}},
+		{webhook, []models.NotificationEvent{models.NotifyTicketCreated}},
+	}
+	for _, tt := range tests {
+		sent := tt.notifier.Sent()
+		if len(sent) != len(tt.want) {
+			t.Fatalf("%s: expected %d notifications, got %d", tt.notifier.Channel(), len(tt.want), len(sent))
+		}
+		for i, n := range sent {
+			if n.Event != tt.want[i] || n.CreatedAt.IsZero() {
+				t.Errorf("%s: unexpected notification %+v", tt.notifier.Channel(), n)
+			}
+		}
+	}
+}
+
+func TestDispatchSkipsChannelsTheUserSwitchedOff(t *testing.T) {
+	email := NewLogNotifier(models.ChannelEmail)
+	inApp := NewLogNotifier(models.ChannelInApp)
+	prefs := optOuts{models.NotifyTicketStatusChanged: {models.ChannelEmail: true}}
+	d := NewDispatcher(prefs, email, inApp)
+
+	ctx := context.Background()
+	if err := d.Dispatch(ctx, Notification{Event: models.NotifyTicketStatusChanged, UserID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Dispatch(ctx, Notification{Event: models.NotifyTicketCreated, UserID: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sent := email.Sent(); len(sent) != 1 || sent[0].Event != models.NotifyTicketCreated {
+		t.Errorf("expected only ticket.created by email, got %+v", sent)
+	}
+	if sent := inApp.Sent(); len(sent) != 2 {
+		t.Errorf("expected both notifications in the app, got %+v", sent)
+	}
+}
+
+func TestDispatchDeliversOtherChannelsWhenOneFails(t *testing.T) {
+	inApp := NewLogNotifier(models.ChannelInApp)
+	webhook := NewLogNotifier(models.ChannelWebhook)
+	d := NewDispatcher(nil, failingNotifier{models.ChannelEmail}, inApp, webhook)
+
+	err := d.Dispatch(context.Background(), Notification{Event: models.NotifyTicketCreated, UserID: 1})
+	if err == nil || !strings.Contains(err.Error(), "email: smtp server unreachable") {
+		t.Fatalf("expected the email failure to be reported, got %v", err)
+	}
+	if len(inApp.Sent()) != 1 || len(webhook.Sent()) != 1 {
+		t.Errorf("expected in-app and webhook delivery despite the failure, got %d and %d", len(inApp.Sent()), len(webhook.Sent()))
+	}
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+
+	"ketukApps/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// InAppNotifier stores notifications for users to read in the app
+type InAppNotifier struct {
+	db *gorm.DB
+}
+
+func NewInAppNotifier(db *gorm.DB) *InAppNotifier {
+	return &InAppNotifier{
+		db: db,
+	}
+}
+
+func (a *InAppNotifier) Channel() models.NotificationChannel {
+	return models.ChannelInApp
+}
+
+func (a *InAppNotifier) Notify(ctx context.Context, n Notification) error {
+	subject, body := n.Message()
+	row := models.Notification{
+		UserID:  n.UserID,
+		Event:   n.Event,
+		Subject: subject,
+		Body:    body,
+	}
+	if len(n.Data) > 0 {
+		data, err := json.Marshal(n.Data)
+		if err != nil {
+			return err
+		}
+		dataStr := string(data)
+		row.Data = &dataStr
+	}
+
+	return a.db.WithContext(ctx).Create(&row).Error
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"ketukApps/internal/models"
+)
+
+// LogNotifier only logs notifications and keeps them in memory. It stands in
+// for a real channel in development and tests.
+type LogNotifier struct {
+	channel models.NotificationChannel
+
+	mu   sync.Mutex
+	sent []Notification
+}
+
+func NewLogNotifier(channel models.NotificationChannel) *LogNotifier {
+	return &LogNotifier{
+		channel: channel,
+	}
+}
+
+func (l *LogNotifier) Channel() models.NotificationChannel {
+	return l.channel
+}
+
+func (l *LogNotifier) Notify(ctx context.Context, n Notification) error {
+	subject, _ := n.Message()
+	log.Printf("[notify:%s] %s to user %d: %s", l.channel, n.Event, n.UserID, subject)
+
+	l.mu.Lock()
+	l.sent = append(l.sent, n)
+	l.mu.Unlock()
+	return nil
+}
+
+// Sent returns the notifications received so far
+func (l *LogNotifier) Sent() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Notification(nil), l.sent...)
+}
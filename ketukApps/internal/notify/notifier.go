@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+	"time"
+
+	"ketukApps/internal/models"
+)
+
+// Notification is the intent to tell a user about an event. Services fill in
+// the event, the recipient and the event data; each channel decides how to
+// present it.
+type Notification struct {
+	Event     models.NotificationEvent `json:"event"`
+	UserID    uint                     `json:"userId"`
+	Email     string                   `json:"email,omitempty"`
+	Name      string                   `json:"name,omitempty"`
+	Locale    string                   `json:"locale,omitempty"`
+	Data      map[string]interface{}   `json:"data,omitempty"`
+	CreatedAt time.Time                `json:"createdAt"`
+}
+
+// Notifier delivers notifications over one channel
+type Notifier interface {
+	Channel() models.NotificationChannel
+	Notify(ctx context.Context, n Notification) error
+}
@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"ketukApps/internal/models"
+)
+
+// SMTPNotifier sends notifications as HTML email with a plain text alternative
+type SMTPNotifier struct {
+	auth smtp.Auth
+	host string
+	port string
+	from string
+}
+
+func NewSMTPNotifier(auth smtp.Auth, host, port, from string) *SMTPNotifier {
+	return &SMTPNotifier{
+		auth: auth,
+		host: host,
+		port: port,
+		from: from,
+	}
+}
+
+func (s *SMTPNotifier) Channel() models.NotificationChannel {
+	return models.ChannelEmail
+}
+
+func (s *SMTPNotifier) Notify(ctx context.Context, n Notification) error {
+	if n.Email == "" {
+		return errors.New("recipient has no email address")
+	}
+
+	email, err := Render(n)
+	if err != nil {
+		return err
+	}
+	return sendMultipartEmail([]string{n.Email}, email.Subject, email.Text, email.HTML, s.auth, s.host, s.port, s.from)
+}
+
+// sendMultipartEmail sends an email with plain text and HTML alternatives
+func sendMultipartEmail(to []string, subject, text, html string, auth smtp.Auth, host, port, from string) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(strings.ReplaceAll(part.content, "\n", "\r\n"))); err != nil {
+			return err
+		}
+		if err := qp.Close(); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	msg := "From: " + from + "\r\n" +
+		"To: " + strings.Join(to, ", ") + "\r\n" +
+		"Subject: " + mime.QEncoding.Encode("UTF-8", subject) + "\r\n" +
+		"Date: " + time.Now().Format(time.RFC1123Z) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/alternative; boundary=" + writer.Boundary() + "\r\n\r\n" +
+		body.String()
+
+	return smtp.SendMail(host+":"+port, auth, from, to, []byte(msg))
+}
@@ -0,0 +1,249 @@
+package notify
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
+)
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// templateDir holds admin overrides of the embedded templates. A file there
+// with the same name as an embedded template replaces it. Templates are read
+// on every render, so edits take effect without a restart.
+var templateDir string
+
+// SetTemplateDir sets the directory searched for template overrides
+func SetTemplateDir(dir string) {
+	templateDir = dir
+}
+
+// Email is a rendered notification
+// @Description Rendered email with its plain text and HTML alternatives
+type Email struct {
+	Subject string `json:"subject" example:"Tiket #12 Disetujui"`
+	Text    string `json:"text" example:"Halo Budi, ..."`
+	HTML    string `json:"html" example:"<!DOCTYPE html>..."`
+}
+
+// templateData is what templates see as "."
+type templateData struct {
+	Name   string
+	Email  string
+	Locale string
+	Data   map[string]interface{}
+}
+
+// Render renders the email of a notification in the recipient's locale
+func Render(n Notification) (*Email, error) {
+	locale := normalizeLocale(n.Locale)
+	base := fmt.Sprintf("%s.%s", n.Event, locale)
+	data := templateData{Name: n.Name, Email: n.Email, Locale: locale, Data: n.Data}
+	if data.Data == nil {
+		data.Data = map[string]interface{}{}
+	}
+	funcs := templateFuncs(locale)
+
+	textSource, err := readTemplate(base + ".txt")
+	if err != nil {
+		return nil, err
+	}
+	textTmpl, err := texttemplate.New(base).Funcs(texttemplate.FuncMap(funcs)).Parse(textSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s.txt: %w", base, err)
+	}
+
+	email := &Email{}
+	if email.Subject, err = executeText(textTmpl, "subject", data); err != nil {
+		return nil, err
+	}
+	if email.Text, err = executeText(textTmpl, "body", data); err != nil {
+		return nil, err
+	}
+
+	htmlTmpl := htmltemplate.New(base).Funcs(htmltemplate.FuncMap(funcs))
+	for _, name := range []string{"layout.html", base + ".html", base + ".txt"} {
+		source, err := readTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := htmlTmpl.Parse(source); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+	}
+	var html bytes.Buffer
+	if err := htmlTmpl.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render %s.html: %w", base, err)
+	}
+	email.HTML = html.String()
+
+	return email, nil
+}
+
+// Message renders the subject and plain text body of a notification, for
+// channels that do not send HTML
+func (n Notification) Message() (string, string) {
+	email, err := Render(n)
+	if err != nil {
+		return string(n.Event), fmt.Sprintf("%v", n.Data)
+	}
+	return email.Subject, email.Text
+}
+
+func executeText(tmpl *texttemplate.Template, name string, data templateData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render %s of %s: %w", name, tmpl.Name(), err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// readTemplate reads a template from the override directory, falling back
+// to the embedded copy
+func readTemplate(name string) (string, error) {
+	if templateDir != "" {
+		source, err := os.ReadFile(filepath.Join(templateDir, name))
+		if err == nil {
+			return string(source), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	source, err := embeddedTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("template %s not found", name)
+		}
+		return "", err
+	}
+	return string(source), nil
+}
+
+func normalizeLocale(locale string) string {
+	if locale == models.LocaleEN {
+		return models.LocaleEN
+	}
+	return models.LocaleID
+}
+
+var monthNames = map[string][]string{
+	models.LocaleID: {"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+	models.LocaleEN: {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+}
+
+var dayNames = map[string][]string{
+	models.LocaleID: {"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"},
+	models.LocaleEN: {"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+}
+
+var statusNames = map[string]map[string]string{
+	models.LocaleID: {
+		string(models.StatusPending):  "Menunggu",
+		string(models.StatusAccepted): "Disetujui",
+		string(models.StatusRejected): "Ditolak",
+	},
+	models.LocaleEN: {
+		string(models.StatusPending):  "Pending",
+		string(models.StatusAccepted): "Approved",
+		string(models.StatusRejected): "Rejected",
+	},
+}
+
+// templateFuncs are the helpers available to templates:
+//
+//	date    formats a time in Asia/Jakarta, e.g. "Senin, 2 Januari 2006 15:04 WIB"
+//	status  translates a ticket status
+//	rows    pairs up label/value arguments for the "details" table
+func templateFuncs(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"date": func(value interface{}) string {
+			t, ok := toTime(value)
+			if !ok {
+				return fmt.Sprint(value)
+			}
+			t = t.In(utils.LabLocation)
+			return fmt.Sprintf("%s, %d %s %d %s WIB",
+				dayNames[locale][t.Weekday()], t.Day(), monthNames[locale][t.Month()-1], t.Year(), t.Format("15:04"))
+		},
+		"status": func(value interface{}) string {
+			status := fmt.Sprint(value)
+			if name, ok := statusNames[locale][status]; ok {
+				return name
+			}
+			return status
+		},
+		"rows": func(values ...interface{}) [][2]interface{} {
+			var rows [][2]interface{}
+			for i := 0; i+1 < len(values); i += 2 {
+				rows = append(rows, [2]interface{}{values[i], values[i+1]})
+			}
+			return rows
+		},
+	}
+}
+
+// toTime accepts a time or, once the notification has been through JSON,
+// an RFC3339 string
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// SampleData returns example notification data used to preview templates
+func SampleData(event models.NotificationEvent) (map[string]interface{}, bool) {
+	start := time.Date(2025, time.March, 10, 8, 0, 0, 0, utils.LabLocation)
+	switch event {
+	case models.NotifyTicketCreated:
+		return map[string]interface{}{
+			"ticketId":    12,
+			"title":       "Praktikum Jaringan Komputer",
+			"description": "Praktikum routing statis untuk kelas B",
+			"status":      string(models.StatusPending),
+			"startDate":   start,
+			"endDate":     start.Add(2 * time.Hour),
+		}, true
+	case models.NotifyTicketStatusChanged:
+		return map[string]interface{}{
+			"ticketId":    12,
+			"title":       "Praktikum Jaringan Komputer",
+			"description": "Praktikum routing statis untuk kelas B",
+			"oldStatus":   string(models.StatusPending),
+			"newStatus":   string(models.StatusAccepted),
+			"reason":      "",
+			"processedBy": "Admin Lab (admin@example.com)",
+		}, true
+	case models.NotifyBookingClosed:
+		return map[string]interface{}{
+			"title":     "Praktikum Jaringan Komputer",
+			"startDate": start,
+			"endDate":   start.Add(2 * time.Hour),
+			"nextOpen":  start.AddDate(0, 0, -7),
+		}, true
+	}
+	return nil, false
+}
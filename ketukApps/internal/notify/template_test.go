@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ketukApps/internal/models"
+)
+
+func sampleNotification(t *testing.T, event models.NotificationEvent, locale string) Notification {
+	t.Helper()
+	data, ok := SampleData(event)
+	if !ok {
+		t.Fatalf("no sample data for %s", event)
+	}
+	return Notification{Event: event, UserID: 1, Name: "Budi", Email: "budi@example.com", Locale: locale, Data: data}
+}
+
+func TestRenderLocales(t *testing.T) {
+	tests := []struct {
+		event   models.NotificationEvent
+		locale  string
+		subject string
+		text    []string
+	}{
+		{models.NotifyTicketCreated, models.LocaleID, "Tiket Baru Dibuat: Praktikum Jaringan Komputer",
+			[]string{"Halo Budi,", "Status: Menunggu", "Mulai: Senin, 10 Maret 2025 08:00 WIB"}},
+		{models.NotifyTicketCreated, models.LocaleEN, "New Ticket Created: Praktikum Jaringan Komputer",
+			[]string{"Hello Budi,", "Status: Pending", "Start: Monday, 10 March 2025 08:00 WIB"}},
+		{models.NotifyTicketStatusChanged, models.LocaleID, "Tiket #12 Disetujui",
+			[]string{"Status Sebelumnya: Menunggu", "Alasan: -"}},
+		{models.NotifyTicketStatusChanged, models.LocaleEN, "Ticket #12 Approved",
+			[]string{"Previous Status: Pending", "Reason: N/A"}},
+		// Unknown locales fall back to Indonesian
+		{models.NotifyTicketStatusChanged, "fr", "Tiket #12 Disetujui", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.event)+"/"+tt.locale, func(t *testing.T) {
+			email, err := Render(sampleNotification(t, tt.event, tt.locale))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if email.Subject != tt.subject {
+				t.Errorf("expected subject %q, got %q", tt.subject, email.Subject)
+			}
+			for _, want := range tt.text {
+				if !strings.Contains(email.Text, want) {
+					t.Errorf("expected text to contain %q, got:\n%s", want, email.Text)
+				}
+			}
+			if !strings.Contains(email.HTML, "<title>"+tt.subject+"</title>") {
+				t.Errorf("expected the HTML layout to carry the subject, got:\n%s", email.HTML)
+			}
+		})
+	}
+}
+
+func TestRenderEveryEventInBothLocales(t *testing.T) {
+	for event := range DefaultRoutes {
+		for _, locale := range []string{models.LocaleID, models.LocaleEN} {
+			email, err := Render(sampleNotification(t, event, locale))
+			if err != nil {
+				t.Errorf("%s/%s: %v", event, locale, err)
+				continue
+			}
+			if email.Subject == "" || email.Text == "" || !strings.Contains(email.HTML, `<html lang="`+locale+`">`) {
+				t.Errorf("%s/%s: incomplete email %+v", event, locale, email)
+			}
+		}
+	}
+}
+
+func TestRenderEscapesHTML(t *testing.T) {
+	n := sampleNotification(t, models.NotifyTicketCreated, models.LocaleEN)
+	n.Data["title"] = "<script>alert(1)</script>"
+
+	email, err := Render(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(email.HTML, "<script>") {
+		t.Errorf("expected the title to be escaped in HTML, got:\n%s", email.HTML)
+	}
+	if !strings.Contains(email.Text, "<script>alert(1)</script>") {
+		t.Errorf("expected the plain text to keep the title, got:\n%s", email.Text)
+	}
+}
+
+func TestSetTemplateDirOverridesTemplates(t *testing.T) {
+	dir := t.TempDir()
+	override := `{{define "subject"}}[Lab] Ticket #{{.Data.ticketId}} is now {{status .Data.newStatus}}{{end}}
+{{- define "body"}}Hi {{.Name}}, see you at the lab.{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "ticket.status_changed.en.txt"), []byte(override), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	SetTemplateDir(dir)
+	defer SetTemplateDir("")
+
+	email, err := Render(sampleNotification(t, models.NotifyTicketStatusChanged, models.LocaleEN))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if email.Subject != "[Lab] Ticket #12 is now Approved" || email.Text != "Hi Budi, see you at the lab." {
+		t.Errorf("expected the override to be used, got %q / %q", email.Subject, email.Text)
+	}
+	// Templates without an override still come from the embedded copies
+	if !strings.Contains(email.HTML, "Your ticket status has been changed to") {
+		t.Errorf("expected the embedded HTML template, got:\n%s", email.HTML)
+	}
+
+	email, err = Render(sampleNotification(t, models.NotifyTicketStatusChanged, models.LocaleID))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if email.Subject != "Tiket #12 Disetujui" {
+		t.Errorf("expected the other locale to be unaffected, got %q", email.Subject)
+	}
+
+	// A broken override is reported rather than silently ignored
+	if err := os.WriteFile(filepath.Join(dir, "ticket.status_changed.en.txt"), []byte(`{{define "subject"}}{{.Data.ticketId`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Render(sampleNotification(t, models.NotifyTicketStatusChanged, models.LocaleEN)); err == nil {
+		t.Error("expected an error for a template that does not parse")
+	}
+}
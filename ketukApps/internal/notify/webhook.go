@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ketukApps/internal/models"
+)
+
+// WebhookNotifier posts notifications as JSON to a fixed URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// webhookPayload is the body posted to the webhook
+type webhookPayload struct {
+	Notification
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+func (w *WebhookNotifier) Channel() models.NotificationChannel {
+	return models.ChannelWebhook
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	subject, body := n.Message()
+	payload, err := json.Marshal(webhookPayload{Notification: n, Subject: subject, Body: body})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"ketukApps/internal/models"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// BookingPublisher puts bookings submitted over HTTP on the schedule queue
+type BookingPublisher struct {
+	queue  string
+	policy RetryPolicy
+
+	mu       sync.Mutex
+	declared bool
+}
+
+func NewBookingPublisher(queue string, policy RetryPolicy) *BookingPublisher {
+	return &BookingPublisher{
+		queue:  queue,
+		policy: policy,
+	}
+}
+
+// Publish sends booking as a schedule.requested v2 message. The tracking ID
+// is the message ID, so the worker deduplicates redeliveries and reports the
+// outcome back to the booking.
+func (p *BookingPublisher) Publish(ctx context.Context, booking *models.BookingRequest) error {
+	payload := ScheduleRequestV2Payload{
+		UserID:      booking.UserID,
+		Title:       booking.Title,
+		Description: booking.Description,
+		Category:    booking.Kategori,
+		RoomID:      booking.RoomID,
+	}
+	payload.Slot.Start = booking.StartDate
+	payload.Slot.End = booking.EndDate
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	body, err := json.Marshal(Envelope{
+		Type:       MessageTypeScheduleRequested,
+		Version:    ScheduleRequestV2,
+		ID:         booking.ID,
+		OccurredAt: now,
+		Payload:    rawPayload,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := p.declare(ctx); err != nil {
+		return err
+	}
+	return RabbitMQClient.Publish(ctx, "", p.queue, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    booking.ID,
+		Timestamp:    now,
+		Body:         body,
+	})
+}
+
+// declare makes sure the schedule queue exists before the first publish. The
+// default exchange confirms a message for a missing queue and drops it, so a
+// booking submitted before the worker first started would be lost.
+func (p *BookingPublisher) declare(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.declared {
+		return nil
+	}
+
+	if err := RabbitMQClient.WaitReady(ctx); err != nil {
+		return err
+	}
+	ch, err := RabbitMQClient.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	if err := declareTopology(ch, p.queue, p.policy); err != nil {
+		return err
+	}
+	p.declared = true
+	return nil
+}
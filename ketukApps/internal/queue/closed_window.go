@@ -0,0 +1,190 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ketukApps/config"
+	"ketukApps/internal/models"
+	"ketukApps/internal/services"
+	"ketukApps/internal/utils"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ClosedWindowPolicy decides what happens to booking requests that arrive
+// while the unblocking window is closed
+type ClosedWindowPolicy string
+
+const (
+	// ClosedWindowPark holds the request in a parking queue until the next
+	// window opens, then processes it as usual
+	ClosedWindowPark ClosedWindowPolicy = "park"
+	// ClosedWindowReject drops the request and tells the user booking is closed
+	ClosedWindowReject ClosedWindowPolicy = "reject"
+)
+
+const headerParkedUntil = "x-parked-until"
+
+// minParkDelay keeps a message parked at least this long. It covers the gap
+// between a window opening and the unblock job noticing it.
+const minParkDelay = time.Minute
+
+// maxParkDelay caps how long a message stays parked at once. Requests of
+// different categories wait for different windows, and RabbitMQ only expires
+// the head of the parking queue, so a long wait must not hold back a short
+// one for more than this; a request that comes back early is parked again.
+const maxParkDelay = time.Hour
+
+func parkedQueueName(queue string) string {
+	return queue + ".parked"
+}
+
+// ClosedWindow applies the closed-window policy to booking requests
+type ClosedWindow struct {
+	Policy        ClosedWindowPolicy
+	unblockings   *services.UnblockingService
+	notifications *services.NotificationService
+	users         *services.UserService
+}
+
+func NewClosedWindow(cfg *config.Config, unblockingService *services.UnblockingService, notificationService *services.NotificationService, userService *services.UserService) ClosedWindow {
+	policy := ClosedWindowPolicy(strings.ToLower(strings.TrimSpace(cfg.Queue.ClosedWindow)))
+	if policy != ClosedWindowReject {
+		if policy != ClosedWindowPark {
+			log.Printf("Unknown closed window policy %q, parking requests", cfg.Queue.ClosedWindow)
+		}
+		policy = ClosedWindowPark
+	}
+	return ClosedWindow{
+		Policy:        policy,
+		unblockings:   unblockingService,
+		notifications: notificationService,
+		users:         userService,
+	}
+}
+
+// role returns the role of the user who requested a booking. Unknown users
+// get no role, for which booking is always closed.
+func (w ClosedWindow) role(request *ScheduleTicketMessage) string {
+	user, err := w.users.GetByID(request.UserID)
+	if err != nil {
+		if err.Error() != "user not found" {
+			log.Printf("Failed to look up the role of user %d: %s", request.UserID, err)
+		}
+		return ""
+	}
+	return user.Role
+}
+
+// BookingClosedReply is sent to the reply-to queue of a rejected request
+type BookingClosedReply struct {
+	Status   string     `json:"status"`
+	Reason   string     `json:"reason"`
+	NextOpen *time.Time `json:"nextOpen,omitempty"`
+}
+
+// closedReason is recorded on bookings rejected because booking is closed
+const closedReason = "booking is closed"
+
+// handle parks or rejects a request that arrived while booking of its
+// category is closed for role, the role of its requester.
+// Requests are rejected when there is no window to park them for. It returns
+// whether the request was rejected; the caller acks the delivery unless an
+// error is returned.
+func (w ClosedWindow) handle(ch *amqp.Channel, d amqp.Delivery, queue string, request *ScheduleTicketMessage, role string) (bool, error) {
+	window, err := w.unblockings.NextWindow(time.Now(), request.Category, role)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up the next unblocking window: %w", err)
+	}
+
+	var nextOpen *time.Time
+	if window != nil {
+		start := utils.InLabTime(window.StartDate)
+		nextOpen = &start
+	}
+
+	if w.Policy == ClosedWindowPark && nextOpen != nil {
+		if err := park(ch, d, queue, *nextOpen); err != nil {
+			return false, fmt.Errorf("failed to park message: %w", err)
+		}
+		return false, nil
+	}
+
+	if err := w.reject(ch, d, request, nextOpen); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// park moves a message to the parking queue until the window opens, or for
+// maxParkDelay when that is sooner. Parked messages expire back into the work
+// queue.
+func park(ch *amqp.Channel, d amqp.Delivery, queue string, until time.Time) error {
+	delay := time.Until(until)
+	if delay < minParkDelay {
+		delay = minParkDelay
+	}
+	if delay > maxParkDelay {
+		delay = maxParkDelay
+	}
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[headerParkedUntil] = until.UTC().Format(time.RFC3339)
+
+	log.Printf("Booking is closed, parking message for %s until %s", delay.Round(time.Second), until.Format(time.RFC3339))
+	return publishConfirmedWith(ch, "", parkedQueueName(queue), d, headers, strconv.FormatInt(delay.Milliseconds(), 10))
+}
+
+// reject notifies the requesting user and answers the reply-to queue, if any
+func (w ClosedWindow) reject(ch *amqp.Channel, d amqp.Delivery, request *ScheduleTicketMessage, nextOpen *time.Time) error {
+	log.Printf("Booking is closed, rejecting request %q of user %d", request.Title, request.UserID)
+
+	err := w.notifications.NotifyBookingClosed(request.UserID, request.Title, request.StartDate, request.EndDate, nextOpen)
+	if err != nil {
+		if err.Error() != "user not found" {
+			return err
+		}
+		log.Printf("Not notifying user %d of rejected booking: %s", request.UserID, err)
+	}
+
+	if d.ReplyTo == "" {
+		return nil
+	}
+	body, err := json.Marshal(BookingClosedReply{
+		Status:   string(models.StatusRejected),
+		Reason:   closedReason,
+		NextOpen: nextOpen,
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, "", d.ReplyTo, false, false, amqp.Publishing{
+		ContentType:   "application/json",
+		CorrelationId: d.CorrelationId,
+		Timestamp:     time.Now(),
+		Body:          body,
+	})
+	if err != nil {
+		// The user has been notified; a lost reply is not worth a retry
+		log.Printf("Failed to reply to %s: %s", d.ReplyTo, err)
+		return nil
+	}
+	if confirm != nil {
+		if ok, err := confirm.WaitContext(ctx); err != nil {
+			log.Printf("Reply to %s was not confirmed: %s", d.ReplyTo, err)
+		} else if !ok {
+			log.Printf("Broker rejected the reply to %s", d.ReplyTo)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"ketukApps/internal/models"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DeadLetters inspects and replays the messages of the dead-letter queue.
+// Every call uses its own channel so it never interferes with the consumers.
+type DeadLetters struct {
+	queue string
+}
+
+func NewDeadLetters(policy RetryPolicy) *DeadLetters {
+	return &DeadLetters{
+		queue: policy.DeadLetterQueue,
+	}
+}
+
+// List returns up to limit dead letters, oldest first, leaving them in the queue
+func (dl *DeadLetters) List(limit int) ([]models.DeadLetter, error) {
+	ch, err := dl.channel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+
+	deliveries, err := dl.fetch(ch, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]models.DeadLetter, 0, len(deliveries))
+	for _, d := range deliveries {
+		letters = append(letters, toDeadLetter(d))
+	}
+
+	// Put everything back where it was
+	if len(deliveries) > 0 {
+		if err := ch.Nack(deliveries[len(deliveries)-1].DeliveryTag, true, true); err != nil {
+			return nil, fmt.Errorf("failed to return dead letters to the queue: %w", err)
+		}
+	}
+	return letters, nil
+}
+
+// Replay sends dead letters back to their original queue with their attempt
+// count reset. ids selects the letters to replay; an empty list replays all.
+func (dl *DeadLetters) Replay(ids []string) (int, error) {
+	ch, err := dl.channel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+	if err := ch.Confirm(false); err != nil {
+		return 0, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	// Only look at what is queued now, so replays that fail again are not picked up
+	q, err := ch.QueueDeclarePassive(dl.queue, true, false, false, false, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect dead-letter queue: %w", err)
+	}
+	deliveries, err := dl.fetch(ch, q.Messages)
+	if err != nil {
+		return 0, err
+	}
+
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	replayed := 0
+	var replayErr error
+	for _, d := range deliveries {
+		letter := toDeadLetter(d)
+		if len(wanted) > 0 && !wanted[letter.ID] {
+			continue
+		}
+		if letter.Queue == "" {
+			replayErr = errors.Join(replayErr, fmt.Errorf("dead letter %s has no original queue", letter.ID))
+			continue
+		}
+
+		headers := amqp.Table{}
+		for k, v := range d.Headers {
+			headers[k] = v
+		}
+		for _, k := range []string{headerAttempts, headerFailureReason, headerOriginalQueue, headerFailedAt, headerDeadLetterID, "x-death", "x-first-death-exchange", "x-first-death-queue", "x-first-death-reason", "x-last-death-exchange", "x-last-death-queue", "x-last-death-reason"} {
+			delete(headers, k)
+		}
+
+		if err := publishConfirmed(ch, "", letter.Queue, d, headers); err != nil {
+			replayErr = errors.Join(replayErr, fmt.Errorf("failed to replay dead letter %s: %w", letter.ID, err))
+			continue
+		}
+		if err := d.Ack(false); err != nil {
+			replayErr = errors.Join(replayErr, err)
+			continue
+		}
+		replayed++
+	}
+	// Unacked letters return to the queue when the channel closes
+	return replayed, replayErr
+}
+
+func (dl *DeadLetters) channel() (*amqp.Channel, error) {
+	if RabbitMQClient == nil {
+		return nil, errors.New("message queue is not connected")
+	}
+	return RabbitMQClient.Channel()
+}
+
+// fetch gets up to limit messages without acking them
+func (dl *DeadLetters) fetch(ch *amqp.Channel, limit int) ([]amqp.Delivery, error) {
+	var deliveries []amqp.Delivery
+	for len(deliveries) < limit {
+		d, ok, err := ch.Get(dl.queue, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dead-letter queue: %w", err)
+		}
+		if !ok {
+			break
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+func toDeadLetter(d amqp.Delivery) models.DeadLetter {
+	letter := models.DeadLetter{
+		ID:          headerString(d, headerDeadLetterID),
+		Queue:       headerString(d, headerOriginalQueue),
+		Reason:      headerString(d, headerFailureReason),
+		Attempts:    attemptsOf(d),
+		ContentType: d.ContentType,
+		Body:        string(d.Body),
+	}
+	if failedAt, err := time.Parse(time.RFC3339, headerString(d, headerFailedAt)); err == nil {
+		letter.FailedAt = &failedAt
+	}
+
+	// Messages the broker dead-lettered itself (rejected or expired) carry
+	// x-death instead of our headers
+	if letter.Queue == "" {
+		letter.Queue = headerString(d, "x-first-death-queue")
+	}
+	if letter.Reason == "" {
+		letter.Reason = headerString(d, "x-first-death-reason")
+	}
+	if letter.ID == "" {
+		letter.ID = d.MessageId
+	}
+	return letter
+}
+
+func headerString(d amqp.Delivery, key string) string {
+	if value, ok := d.Headers[key].(string); ok {
+		return value
+	}
+	return ""
+}
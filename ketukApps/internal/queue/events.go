@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// EventPublisher publishes domain events to the topic exchange. Subscribers
+// bind their own queues with routing keys such as "ticket.*" or "user.created".
+type EventPublisher struct {
+	exchange string
+
+	mu       sync.Mutex
+	declared bool
+}
+
+func NewEventPublisher(exchange string) *EventPublisher {
+	return &EventPublisher{
+		exchange: exchange,
+	}
+}
+
+// PublishEvent publishes an encoded event and waits for the broker to
+// confirm it. It fails right away while RabbitMQ is down, so the outbox relay
+// backs off instead of holding its batch.
+func (p *EventPublisher) PublishEvent(ctx context.Context, routingKey, messageID string, body []byte) error {
+	select {
+	case <-RabbitMQClient.Ready():
+	default:
+		return errors.New("message queue is not connected")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	if err := p.declare(ctx); err != nil {
+		return err
+	}
+	return RabbitMQClient.Publish(ctx, p.exchange, routingKey, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    messageID,
+		Type:         routingKey,
+		Timestamp:    time.Now(),
+		Body:         body,
+	})
+}
+
+// declare makes sure the durable topic exchange exists before the first publish
+func (p *EventPublisher) declare(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.declared {
+		return nil
+	}
+
+	if err := RabbitMQClient.WaitReady(ctx); err != nil {
+		return err
+	}
+	ch, err := RabbitMQClient.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	if err := ch.ExchangeDeclare(p.exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare event exchange: %w", err)
+	}
+	p.declared = true
+	return nil
+}
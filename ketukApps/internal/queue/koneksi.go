@@ -1,9 +1,14 @@
 package queue
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"ketukApps/config"
+	"ketukApps/internal/models"
 	"log"
 	"net/url"
+	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -11,11 +16,49 @@ import (
 
 var RabbitMQClient *RabbitMQ
 
+// ConnectionState is where the RabbitMQ connection manager currently stands
+type ConnectionState string
+
+const (
+	StateConnecting   ConnectionState = "connecting"
+	StateConnected    ConnectionState = "connected"
+	StateReconnecting ConnectionState = "reconnecting"
+	StateClosed       ConnectionState = "closed"
+)
+
+// Reconnect delays double after every failed attempt
+const (
+	reconnectMinDelay = time.Second
+	reconnectMaxDelay = 30 * time.Second
+)
+
+// ErrClosed is returned once the connection manager has been shut down
+var ErrClosed = errors.New("message queue connection closed")
+
+// RabbitMQ owns the broker connection. It watches for the connection to
+// close and reconnects with backoff; consumers wait on Ready and open their
+// channels again, publishers go through Publish which recovers its channel.
 type RabbitMQ struct {
-	Conn    *amqp.Connection
-	Channel *amqp.Channel
+	url      string
+	config   amqp.Config
+	prefetch int
+
+	mu        sync.RWMutex
+	conn      *amqp.Connection
+	state     ConnectionState
+	since     time.Time
+	lastError string
+	ready     chan struct{}
+	done      chan struct{}
+
+	// Publishes share one confirm-mode channel
+	pubMu     sync.Mutex
+	publisher *amqp.Channel
 }
 
+// NewRabbitMQConnection starts the connection manager. It does not wait for
+// the broker: the API keeps serving while RabbitMQ is down and consumers
+// start as soon as the first connection succeeds.
 func NewRabbitMQConnection(cfg *config.Config) (err error) {
 	url := url.URL{
 		Scheme: "amqp",
@@ -24,25 +67,214 @@ func NewRabbitMQConnection(cfg *config.Config) (err error) {
 	}
 
 	amqpConfig := amqp.Config{
-		Heartbeat: 60 * time.Second,
+		Vhost:     cfg.Queue.VHost,
+		Heartbeat: time.Duration(cfg.Queue.HeartbeatInterval) * time.Second,
 		Locale:    "id_ID",
-		
+		Dial:      amqp.DefaultDial(time.Duration(cfg.Queue.ConnectionTimeout) * time.Second),
 	}
 
-	conn, err := amqp.DialConfig(url.String(), amqpConfig)
-	if err != nil {
-		log.Fatalf("Failed to connect to RabbitMQ: %s", err)
+	prefetch := cfg.Queue.Prefetch
+	if prefetch < 1 {
+		prefetch = 1
+	}
+
+	RabbitMQClient = &RabbitMQ{
+		url:      url.String(),
+		config:   amqpConfig,
+		prefetch: prefetch,
+		state:    StateConnecting,
+		since:    time.Now(),
+		ready:    make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go RabbitMQClient.run()
+	return nil
+}
+
+// run keeps the connection up until Close is called
+func (r *RabbitMQ) run() {
+	delay := reconnectMinDelay
+	for {
+		conn, err := amqp.DialConfig(r.url, r.config)
+		if err != nil {
+			r.setError(err)
+			log.Printf("Failed to connect to RabbitMQ, retrying in %s: %s", delay, err)
+			select {
+			case <-time.After(delay):
+			case <-r.done:
+				return
+			}
+			delay *= 2
+			if delay > reconnectMaxDelay {
+				delay = reconnectMaxDelay
+			}
+			continue
+		}
+		delay = reconnectMinDelay
+
+		closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		r.setConnected(conn)
+		log.Println("Connected to RabbitMQ")
+
+		select {
+		case amqpErr := <-closed:
+			r.setDisconnected(amqpErr)
+			log.Printf("RabbitMQ connection lost, reconnecting: %v", amqpErr)
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *RabbitMQ) setConnected(conn *amqp.Connection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.conn = conn
+	r.state = StateConnected
+	r.since = time.Now()
+	r.lastError = ""
+	close(r.ready)
+}
+
+func (r *RabbitMQ) setDisconnected(amqpErr *amqp.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.conn = nil
+	r.state = StateReconnecting
+	r.since = time.Now()
+	if amqpErr != nil {
+		r.lastError = amqpErr.Error()
+	}
+	r.ready = make(chan struct{})
+}
+
+func (r *RabbitMQ) setError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastError = err.Error()
+}
+
+// Ready returns a channel that is closed while the broker is connected
+func (r *RabbitMQ) Ready() <-chan struct{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ready
+}
+
+// WaitReady blocks until the broker is connected, ctx is done or the manager is closed
+func (r *RabbitMQ) WaitReady(ctx context.Context) error {
+	select {
+	case <-r.Ready():
+		return nil
+	case <-r.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
+// Channel opens a new channel on the current connection. Channels die with
+// their connection; callers open a new one after reconnecting.
+func (r *RabbitMQ) Channel() (*amqp.Channel, error) {
+	r.mu.RLock()
+	conn := r.conn
+	r.mu.RUnlock()
+	if conn == nil || conn.IsClosed() {
+		return nil, errors.New("message queue is not connected")
+	}
 	ch, err := conn.Channel()
 	if err != nil {
-		log.Fatalf("Failed to open a RabbitMQ channel: %s", err)
+		return nil, fmt.Errorf("failed to open a channel: %w", err)
 	}
+	return ch, nil
+}
 
-	RabbitMQClient = &RabbitMQ{
-		Conn:    conn,
-		Channel: ch,
+// ConsumerChannel opens a channel for a consumer, limited to the configured
+// number of unacknowledged deliveries. Without the limit a consumer that
+// reconnects takes every message waiting on its queue at once.
+func (r *RabbitMQ) ConsumerChannel() (*amqp.Channel, error) {
+	ch, err := r.Channel()
+	if err != nil {
+		return nil, err
+	}
+	if err := ch.Qos(r.prefetch, 0, false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("failed to set prefetch: %w", err)
+	}
+	return ch, nil
+}
+
+// Publish waits for the broker to be connected, publishes msg and waits for
+// the broker's confirmation
+func (r *RabbitMQ) Publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error {
+	if err := r.WaitReady(ctx); err != nil {
+		return err
+	}
+
+	r.pubMu.Lock()
+	defer r.pubMu.Unlock()
+
+	if r.publisher == nil || r.publisher.IsClosed() {
+		ch, err := r.Channel()
+		if err != nil {
+			return err
+		}
+		if err := ch.Confirm(false); err != nil {
+			ch.Close()
+			return fmt.Errorf("failed to enable publisher confirms: %w", err)
+		}
+		r.publisher = ch
+	}
+
+	confirm, err := r.publisher.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, msg)
+	if err != nil {
+		return err
+	}
+	ok, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("broker rejected the message")
 	}
-	log.Println("Connected to RabbitMQ")
 	return nil
 }
+
+// Status reports the connection state for the health check
+func (r *RabbitMQ) Status() models.ComponentHealth {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return models.ComponentHealth{
+		Status: string(r.state),
+		Since:  r.since.Format(time.RFC3339),
+		Error:  r.lastError,
+	}
+}
+
+// Close stops reconnecting and closes the connection
+func (r *RabbitMQ) Close() {
+	r.mu.Lock()
+	select {
+	case <-r.done:
+		r.mu.Unlock()
+		return
+	default:
+	}
+	close(r.done)
+	r.state = StateClosed
+	r.since = time.Now()
+	conn := r.conn
+	r.conn = nil
+	r.mu.Unlock()
+
+	r.pubMu.Lock()
+	if r.publisher != nil {
+		r.publisher.Close()
+		r.publisher = nil
+	}
+	r.pubMu.Unlock()
+
+	if conn != nil {
+		conn.Close()
+	}
+}
@@ -0,0 +1,216 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ketukApps/internal/models"
+	"strings"
+	"time"
+)
+
+// MessageTypeScheduleRequested is the envelope type of booking requests
+const MessageTypeScheduleRequested = "schedule.requested"
+
+// Supported versions of the schedule.requested payload
+const (
+	ScheduleRequestV1 = 1
+	ScheduleRequestV2 = 2
+)
+
+const maxTitleLength = 255
+
+// Envelope wraps every message published to the schedule queue. Payload is
+// decoded according to Type and Version, so publishers can move to a new
+// version while the worker still accepts the old one.
+type Envelope struct {
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	ID         string          `json:"id,omitempty"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// ScheduleRequestV2Payload is version 2 of a booking request. The requester
+// no longer chooses the ticket status, the room is required and the booked
+// time is grouped in slot.
+type ScheduleRequestV2Payload struct {
+	UserID      uint            `json:"userId"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Category    models.Category `json:"category"`
+	RoomID      int             `json:"roomId"`
+	Slot        struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	} `json:"slot"`
+}
+
+// ValidationError lists everything wrong with a message
+type ValidationError struct {
+	Type    string
+	Version int
+	Fields  []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s v%d message: %s", e.Type, e.Version, strings.Join(e.Fields, "; "))
+}
+
+func (e *ValidationError) add(format string, args ...interface{}) {
+	e.Fields = append(e.Fields, fmt.Sprintf(format, args...))
+}
+
+// parseScheduleRequest decodes and validates a booking request. Bodies without
+// an envelope are read as v1 payloads, as published before envelopes existed;
+// like then, fields they do not know are ignored. Versioned messages are
+// decoded strictly. Every error it returns is permanent.
+func parseScheduleRequest(body []byte) (*ScheduleTicketMessage, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, &PermanentError{Err: errors.New("empty message body")}
+	}
+
+	var probe struct {
+		Type *string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil || probe.Type == nil {
+		// Not an envelope; fall back to a bare v1 payload
+		message, err := decodeV1(body, false)
+		if err != nil {
+			return nil, &PermanentError{Err: err}
+		}
+		return message, nil
+	}
+
+	var envelope Envelope
+	if err := decodeJSON(body, &envelope, true); err != nil {
+		return nil, &PermanentError{Err: fmt.Errorf("invalid message envelope: %w", err)}
+	}
+	if envelope.Type != MessageTypeScheduleRequested {
+		return nil, &PermanentError{Err: fmt.Errorf("unsupported message type %q", envelope.Type)}
+	}
+	if len(envelope.Payload) == 0 {
+		return nil, &PermanentError{Err: fmt.Errorf("%s v%d message has no payload", envelope.Type, envelope.Version)}
+	}
+
+	var message *ScheduleTicketMessage
+	var err error
+	switch envelope.Version {
+	case ScheduleRequestV1:
+		message, err = decodeV1(envelope.Payload, true)
+	case ScheduleRequestV2:
+		message, err = decodeV2(envelope.Payload)
+	default:
+		err = fmt.Errorf("unsupported %s version %d", envelope.Type, envelope.Version)
+	}
+	if err != nil {
+		return nil, &PermanentError{Err: err}
+	}
+	if message.IdempotencyKey == "" {
+		message.IdempotencyKey = envelope.ID
+	}
+	return message, nil
+}
+
+func decodeV1(raw []byte, strict bool) (*ScheduleTicketMessage, error) {
+	var message ScheduleTicketMessage
+	if err := decodeJSON(raw, &message, strict); err != nil {
+		return nil, fmt.Errorf("invalid %s v%d payload: %w", MessageTypeScheduleRequested, ScheduleRequestV1, err)
+	}
+
+	v := &ValidationError{Type: MessageTypeScheduleRequested, Version: ScheduleRequestV1}
+	validateRequest(v, &message)
+	switch models.TicketStatus(message.Status) {
+	case "", models.StatusPending, models.StatusAccepted, models.StatusRejected:
+	default:
+		v.add("status %q is not one of pending, accepted, rejected", message.Status)
+	}
+	if len(v.Fields) > 0 {
+		return nil, v
+	}
+	return &message, nil
+}
+
+func decodeV2(raw []byte) (*ScheduleTicketMessage, error) {
+	var payload ScheduleRequestV2Payload
+	if err := decodeJSON(raw, &payload, true); err != nil {
+		return nil, fmt.Errorf("invalid %s v%d payload: %w", MessageTypeScheduleRequested, ScheduleRequestV2, err)
+	}
+
+	message := &ScheduleTicketMessage{
+		UserID:      payload.UserID,
+		Title:       payload.Title,
+		Description: payload.Description,
+		Status:      string(models.StatusPending),
+		Category:    payload.Category,
+		StartDate:   payload.Slot.Start,
+		EndDate:     payload.Slot.End,
+		RoomID:      payload.RoomID,
+	}
+
+	v := &ValidationError{Type: MessageTypeScheduleRequested, Version: ScheduleRequestV2}
+	validateRequest(v, message)
+	if payload.RoomID <= 0 {
+		v.add("roomId is required")
+	}
+	if len(v.Fields) > 0 {
+		return nil, v
+	}
+	return message, nil
+}
+
+// validateRequest checks the fields every version shares
+func validateRequest(v *ValidationError, message *ScheduleTicketMessage) {
+	message.Title = strings.TrimSpace(message.Title)
+	message.Description = strings.TrimSpace(message.Description)
+
+	if message.UserID == 0 {
+		v.add("userId is required")
+	}
+	if message.Title == "" {
+		v.add("title is required")
+	} else if len(message.Title) > maxTitleLength {
+		v.add("title must be at most %d characters", maxTitleLength)
+	}
+	if message.Description == "" {
+		v.add("description is required")
+	}
+	switch message.Category {
+	case models.Kelas, models.Lainnya, models.Praktikum, models.Skripsi:
+	case "":
+		v.add("category is required")
+	default:
+		v.add("category %q is not one of Kelas, Lainnya, Praktikum, Skripsi", message.Category)
+	}
+	switch {
+	case message.StartDate.IsZero() || message.EndDate.IsZero():
+		v.add("start and end date are required")
+	case !message.EndDate.After(message.StartDate):
+		v.add("end date must be after start date")
+	}
+	if message.RoomID < 0 {
+		v.add("roomId must be positive")
+	}
+}
+
+// decodeJSON unmarshals raw into v. Strict decoding refuses unknown fields so
+// typos and fields of another version are reported instead of silently dropped.
+func decodeJSON(raw []byte, v interface{}, strict bool) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			problemChar := ""
+			if int(syntaxErr.Offset) < len(raw) {
+				problemChar = string(raw[syntaxErr.Offset])
+			}
+			return fmt.Errorf("JSON syntax error at offset %d (char: %q): %w", syntaxErr.Offset, problemChar, err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package queue
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseScheduleRequestUnknownFields(t *testing.T) {
+	v1 := `{"userId":1,"title":"Praktikum","description":"Routing statis","category":"Praktikum",` +
+		`"startDate":"2025-03-17T08:00:00+07:00","endDate":"2025-03-17T10:00:00+07:00","source":"sia"}`
+	v2 := `{"userId":1,"title":"Praktikum","description":"Routing statis","category":"Praktikum","roomId":1,` +
+		`"slot":{"start":"2025-03-17T08:00:00+07:00","end":"2025-03-17T10:00:00+07:00"},"source":"sia"}`
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "bare v1 ignores them", body: v1},
+		{name: "enveloped v1 rejects them", body: `{"type":"schedule.requested","version":1,"id":"a","payload":` + v1 + `}`, wantErr: `unknown field "source"`},
+		{name: "v2 rejects them", body: `{"type":"schedule.requested","version":2,"id":"b","payload":` + v2 + `}`, wantErr: `unknown field "source"`},
+		{name: "envelope rejects them", body: `{"type":"schedule.requested","version":2,"id":"c","source":"sia","payload":{}}`, wantErr: `unknown field "source"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := parseScheduleRequest([]byte(tt.body))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if message.Title != "Praktikum" || message.UserID != 1 {
+					t.Errorf("unexpected message %+v", message)
+				}
+				return
+			}
+			var permanent *PermanentError
+			if err == nil || !errors.As(err, &permanent) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected a permanent error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
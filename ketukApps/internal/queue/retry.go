@@ -0,0 +1,334 @@
+package queue
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"ketukApps/config"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Headers used to track retries and dead letters
+const (
+	headerAttempts      = "x-attempts"
+	headerFailureReason = "x-failure-reason"
+	headerOriginalQueue = "x-original-queue"
+	headerFailedAt      = "x-failed-at"
+	headerDeadLetterID  = "x-dead-letter-id"
+)
+
+// publishTimeout bounds how long a retry or dead-letter publish waits for the broker
+const publishTimeout = 10 * time.Second
+
+// RetryPolicy controls how failed messages are retried. Attempt n waits
+// BaseDelay * 2^(n-1) in a delay queue before going back to the work queue;
+// after MaxAttempts the message is moved to the dead-letter queue.
+type RetryPolicy struct {
+	MaxAttempts        int
+	BaseDelay          time.Duration
+	DeadLetterExchange string
+	DeadLetterQueue    string
+}
+
+// minRetryBaseDelay is the shortest first retry delay. A zero delay would
+// expire retries straight back into the work queue and hot-loop on failures.
+const minRetryBaseDelay = time.Second
+
+func NewRetryPolicy(cfg *config.Config) RetryPolicy {
+	maxAttempts := cfg.Queue.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	baseDelay := time.Duration(cfg.Queue.RetryBaseDelay) * time.Second
+	if baseDelay < minRetryBaseDelay {
+		log.Printf("QUEUE_RETRY_BASE_DELAY must be at least %s, using %s", minRetryBaseDelay, minRetryBaseDelay)
+		baseDelay = minRetryBaseDelay
+	}
+	return RetryPolicy{
+		MaxAttempts:        maxAttempts,
+		BaseDelay:          baseDelay,
+		DeadLetterExchange: cfg.Queue.Exchanges.DeadLetter,
+		DeadLetterQueue:    cfg.Queue.Queues.DeadLetters,
+	}
+}
+
+// delay is how long a message waits before its next attempt
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	return p.BaseDelay << (attempt - 1)
+}
+
+// retryQueueName names the delay queue of a retry after delay. The delay is
+// part of the name because RabbitMQ refuses to redeclare a queue with another
+// TTL, so changing the backoff settings declares new queues instead.
+func retryQueueName(queue string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%dms", queue, delay.Milliseconds())
+}
+
+// workQueueArgs are the arguments of the work queue: anything the consumer
+// rejects outright is dead-lettered
+func workQueueArgs(queue string, policy RetryPolicy) amqp.Table {
+	return amqp.Table{
+		amqp.QueueTypeArg:           amqp.QueueTypeClassic,
+		amqp.ConsumerTimeoutArg:     600_000 * 6, // 1 jam
+		"x-dead-letter-exchange":    policy.DeadLetterExchange,
+		"x-dead-letter-routing-key": queue,
+	}
+}
+
+// declareTopology declares the durable work queue, one delay queue per retry
+// delay and the dead-letter exchange and queue. Delay queues have a fixed TTL
+// and dead-letter expired messages back to the work queue, so no plugin is
+// needed. Anything the consumer rejects outright also ends up in the
+// dead-letter queue. The parking queue works the same way with a TTL per
+// message. The work queue must already have been migrated by
+// migrateWorkQueue.
+func declareTopology(ch *amqp.Channel, queue string, policy RetryPolicy) error {
+	if err := ch.ExchangeDeclare(policy.DeadLetterExchange, amqp.ExchangeDirect, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+	if _, err := ch.QueueDeclare(policy.DeadLetterQueue, true, false, false, false, amqp.Table{
+		amqp.QueueTypeArg: amqp.QueueTypeClassic,
+	}); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+	if err := ch.QueueBind(policy.DeadLetterQueue, queue, policy.DeadLetterExchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, workQueueArgs(queue, policy)); err != nil {
+		return fmt.Errorf("failed to declare a queue: %w", err)
+	}
+
+	for attempt := 1; attempt < policy.MaxAttempts; attempt++ {
+		if _, err := ch.QueueDeclare(retryQueueName(queue, policy.delay(attempt)), true, false, false, false, amqp.Table{
+			amqp.QueueTypeArg:           amqp.QueueTypeClassic,
+			amqp.QueueMessageTTLArg:     policy.delay(attempt).Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queue,
+		}); err != nil {
+			return fmt.Errorf("failed to declare retry queue: %w", err)
+		}
+	}
+
+	return declareParkingQueue(ch, queue)
+}
+
+// declareParkingQueue declares the queue where requests received while
+// booking is closed wait for the window to open
+func declareParkingQueue(ch *amqp.Channel, queue string) error {
+	if _, err := ch.QueueDeclare(parkedQueueName(queue), true, false, false, false, amqp.Table{
+		amqp.QueueTypeArg:           amqp.QueueTypeClassic,
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": queue,
+	}); err != nil {
+		return fmt.Errorf("failed to declare parking queue: %w", err)
+	}
+	return nil
+}
+
+// migrateWorkQueue makes sure the work queue can be declared with
+// workQueueArgs. Queues created before retries existed were neither durable
+// nor dead-lettered, and RabbitMQ refuses to redeclare a queue with other
+// settings. Such a queue is drained into the parking queue, from where its
+// messages expire back into the work queue after minParkDelay, then deleted
+// and declared again. Deleting fails while consumers of an older release are
+// still attached; the worker then tries again later.
+func migrateWorkQueue(queue string, policy RetryPolicy) error {
+	ch, err := RabbitMQClient.Channel()
+	if err != nil {
+		return err
+	}
+	_, err = ch.QueueDeclare(queue, true, false, false, false, workQueueArgs(queue, policy))
+	ch.Close()
+	var amqpErr *amqp.Error
+	if !errors.As(err, &amqpErr) || amqpErr.Code != amqp.PreconditionFailed {
+		return err
+	}
+
+	log.Printf("Queue %s was declared with other settings, moving its messages to %s and declaring it again", queue, parkedQueueName(queue))
+	ch, err = RabbitMQClient.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := declareParkingQueue(ch, queue); err != nil {
+		return err
+	}
+	if err := ch.Confirm(false); err != nil {
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+	expiration := strconv.FormatInt(minParkDelay.Milliseconds(), 10)
+	moved := 0
+	for {
+		d, ok, err := ch.Get(queue, false)
+		if err != nil {
+			return fmt.Errorf("failed to drain queue %s: %w", queue, err)
+		}
+		if !ok {
+			break
+		}
+		if err := publishConfirmedWith(ch, "", parkedQueueName(queue), d, d.Headers, expiration); err != nil {
+			d.Nack(false, true)
+			return fmt.Errorf("failed to move message out of queue %s: %w", queue, err)
+		}
+		d.Ack(false)
+		moved++
+	}
+
+	if _, err := ch.QueueDelete(queue, true, true, false); err != nil {
+		return fmt.Errorf("failed to delete queue %s, it still has consumers or messages: %w", queue, err)
+	}
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, workQueueArgs(queue, policy)); err != nil {
+		return fmt.Errorf("failed to declare a queue: %w", err)
+	}
+	log.Printf("Queue %s declared again, %d message(s) return from %s within %s", queue, moved, parkedQueueName(queue), minParkDelay)
+	return nil
+}
+
+// PermanentError marks a failure that retrying cannot fix, such as a
+// malformed message or a slot that is already booked
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// isTransient reports whether err is worth retrying: lost connections,
+// timeouts, deadlocks and other database conditions that pass by themselves
+func isTransient(err error) bool {
+	var permanentErr *PermanentError
+	if errors.As(err, &permanentErr) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) || pgconn.SafeToRetry(err) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 08 connection exception, 40 transaction rollback (deadlock,
+		// serialization), 53 insufficient resources, 57 operator intervention
+		for _, class := range []string{"08", "40", "53", "57"} {
+			if strings.HasPrefix(pgErr.Code, class) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// attemptsOf returns how many times a message has already failed
+func attemptsOf(d amqp.Delivery) int {
+	switch v := d.Headers[headerAttempts].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+// handleFailure schedules a failed message for another attempt, or moves it
+// to the dead-letter queue when the failure is permanent or attempts ran out.
+// The delivery is acked once the copy is confirmed by the broker; if that
+// fails it is rejected and the work queue's dead-lettering takes over.
+// It reports whether the message was given up on.
+func handleFailure(ch *amqp.Channel, d amqp.Delivery, queue string, policy RetryPolicy, failure error) bool {
+	attempts := attemptsOf(d) + 1
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[headerAttempts] = int32(attempts)
+	headers[headerFailureReason] = failure.Error()
+
+	exchange, routingKey := "", retryQueueName(queue, policy.delay(attempts))
+	deadLettered := !isTransient(failure) || attempts >= policy.MaxAttempts
+	if deadLettered {
+		exchange, routingKey = policy.DeadLetterExchange, queue
+		headers[headerOriginalQueue] = queue
+		headers[headerFailedAt] = time.Now().UTC().Format(time.RFC3339)
+		headers[headerDeadLetterID] = deadLetterID(d)
+		log.Printf("Moving message to dead-letter queue after %d attempt(s): %s", attempts, failure)
+	} else {
+		log.Printf("Retrying message in %s (attempt %d of %d): %s", policy.delay(attempts), attempts+1, policy.MaxAttempts, failure)
+	}
+
+	if err := publishConfirmed(ch, exchange, routingKey, d, headers); err != nil {
+		log.Printf("Failed to republish failed message, rejecting it: %s", err)
+		d.Nack(false, false)
+		return true
+	}
+	d.Ack(false)
+	return deadLettered
+}
+
+// publishConfirmed republishes a delivery with new headers and waits for the broker's confirmation
+func publishConfirmed(ch *amqp.Channel, exchange, routingKey string, d amqp.Delivery, headers amqp.Table) error {
+	return publishConfirmedWith(ch, exchange, routingKey, d, headers, "")
+}
+
+// publishConfirmedWith is publishConfirmed with a per-message TTL in
+// milliseconds; an empty expiration keeps the message until it is consumed
+func publishConfirmedWith(ch *amqp.Channel, exchange, routingKey string, d amqp.Delivery, headers amqp.Table, expiration string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	confirm, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, amqp.Publishing{
+		Headers:       headers,
+		ContentType:   d.ContentType,
+		DeliveryMode:  amqp.Persistent,
+		CorrelationId: d.CorrelationId,
+		MessageId:     d.MessageId,
+		Timestamp:     d.Timestamp,
+		Expiration:    expiration,
+		Body:          d.Body,
+	})
+	if err != nil {
+		return err
+	}
+	if confirm == nil {
+		return errors.New("channel is not in confirm mode")
+	}
+	ok, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("broker rejected the message")
+	}
+	return nil
+}
+
+// deadLetterID identifies a dead letter for replay. The publisher's message
+// ID is used when there is one.
+func deadLetterID(d amqp.Delivery) string {
+	if d.MessageId != "" {
+		return d.MessageId
+	}
+	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), d.DeliveryTag)
+}
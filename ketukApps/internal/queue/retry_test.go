@@ -0,0 +1,38 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"ketukApps/config"
+)
+
+func TestNewRetryPolicyClampsSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseDelay   int
+		maxAttempts int
+		wantDelay   time.Duration
+		wantMax     int
+	}{
+		{"configured", 5, 3, 5 * time.Second, 3},
+		{"zero delay", 0, 3, time.Second, 3},
+		{"negative delay", -2, 3, time.Second, 3},
+		{"no attempts", 5, 0, 5 * time.Second, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Queue.RetryBaseDelay = tt.baseDelay
+			cfg.Queue.MaxAttempts = tt.maxAttempts
+
+			policy := NewRetryPolicy(cfg)
+			if policy.BaseDelay != tt.wantDelay || policy.MaxAttempts != tt.wantMax {
+				t.Errorf("expected %s and %d attempts, got %s and %d", tt.wantDelay, tt.wantMax, policy.BaseDelay, policy.MaxAttempts)
+			}
+			if policy.delay(1) <= 0 {
+				t.Errorf("expected a positive first retry delay, got %s", policy.delay(1))
+			}
+		})
+	}
+}
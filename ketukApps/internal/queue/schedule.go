@@ -1,39 +1,58 @@
 package queue
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"ketukApps/internal/models"
 	"ketukApps/internal/scheduler"
 	"ketukApps/internal/services"
-	"ketukApps/internal/utils"
 	"log"
-	"net/smtp"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-func SchduleWorker(name string, ticketService *services.TicketService, scheduleService *services.ScheduleService, smtpAuth *smtp.Auth, smtpEmail string) error {
+// SchduleWorker books the schedule requests published to the queue. Failures
+// are retried with backoff or dead-lettered according to policy; requests
+// that arrive while booking is closed are handled by closed.
+// The worker survives broker restarts: when its channel closes it waits for
+// the connection manager to reconnect and consumes again.
+func SchduleWorker(name string, ticketService *services.TicketService, bookingService *services.BookingService, policy RetryPolicy, closed ClosedWindow) error {
 	for {
-		msgs, err := ConsumerSchedule(name)
-		if err != nil {
-			log.Printf("Failed to start consumer: %s", err)
+		if err := RabbitMQClient.WaitReady(context.Background()); err != nil {
+			if errors.Is(err, ErrClosed) {
+				return nil
+			}
 			return err
 		}
 
+		ch, msgs, err := ConsumerSchedule(name, policy)
+		if err != nil {
+			log.Printf("Failed to start consumer, retrying in %s: %s", consumerRetryDelay, err)
+			time.Sleep(consumerRetryDelay)
+			continue
+		}
+
+		// fail retries or dead-letters a delivery. Bookings submitted over HTTP
+		// are marked rejected once their message is given up on.
+		fail := func(d amqp.Delivery, key string, err error) {
+			if handleFailure(ch, d, name, policy, err) {
+				markRejected(bookingService, key, err.Error())
+			}
+		}
+
 		for d := range msgs {
 			log.Printf("Received a message: %s", d.Body)
-			// Process the message here
-			// Parse message body to get both schedule and ticket data
-			requestData, err := parseBodyToJSON(d.Body)
+			// Decode and validate the envelope (or bare v1 payload) to get both
+			// schedule and ticket data; invalid messages are dead-lettered
+			requestData, err := parseScheduleRequest(d.Body)
 			if err != nil {
-				log.Printf("Failed to parse message body: %s", err)
-				log.Printf("Raw message body (hex): %x", d.Body)
-				log.Printf("Raw message body (string): %q", string(d.Body))
-				d.Nack(false, false)
+				log.Printf("Rejected message: %s", err)
+				fail(d, d.MessageId, err)
 				continue
 			}
+			key := messageKey(d, requestData)
 			log.Printf("Parsed RequestData: %+v", requestData)
 			scheduleTicket := &models.ScheduleTicket{
 				Title:       requestData.Title,
@@ -42,98 +61,134 @@ func SchduleWorker(name string, ticketService *services.TicketService, scheduleS
 				UserID:      int(requestData.UserID),
 				Kategori:    requestData.Category,
 				Description: requestData.Description,
+				RoomID:      requestData.RoomID,
 			}
 
-			if !scheduler.IsUnblockEnabled() {
-				log.Printf("no no ya lagi di block")
+			// Outside the unblocking windows for its category and requester the
+			// request is parked or rejected
+			role := closed.role(requestData)
+			if !scheduler.IsUnblockEnabledFor(requestData.Category, role) {
+				rejected, err := closed.handle(ch, d, name, requestData, role)
+				if err != nil {
+					log.Printf("Failed to handle request while booking is closed: %s", err)
+					fail(d, key, err)
+					continue
+				}
+				if rejected {
+					markRejected(bookingService, key, closedReason)
+				}
+				d.Ack(false)
 				continue
 			}
 
-			savedSchedule, err := scheduleService.CreateScheduleTicket(scheduleTicket)
-			if err != nil {
-				log.Printf("Failed to save schedule_ticket to database: %s", err)
-				d.Nack(false, false)
-				continue
-			}
-			log.Printf("Successfully saved schedule_ticket to database with ID: %d", savedSchedule.IDSchedule)
 			ticket := &models.Ticket{
 				UserID:      requestData.UserID,
 				Title:       requestData.Title,
 				Description: requestData.Description,
 				Status:      models.TicketStatus(requestData.Status),
-				IDSchedule:  &savedSchedule.IDSchedule,
+				Kategori:    requestData.Category,
 			}
 
-			savedTicket, err := ticketService.CreateFromModel(ticket)
+			// Schedule, ticket, audit entry, notification and the message key are
+			// written in one transaction; the outbox relay sends the notification
+			// afterwards. A redelivered message finds its key and is only acked.
+			savedTicket, created, err := ticketService.CreateWithScheduleOnce(name, key, d.Body, scheduleTicket, ticket)
 			if err != nil {
-				log.Printf("Failed to save ticket to database: %s", err)
-				d.Nack(false, false)
+				var conflictErr *services.ScheduleConflictError
+				if errors.As(err, &conflictErr) {
+					log.Printf("Rejected schedule request %q (%s - %s): %s", scheduleTicket.Title,
+						scheduleTicket.StartDate.Format(time.RFC3339), scheduleTicket.EndDate.Format(time.RFC3339), err)
+					for _, conflict := range conflictErr.Conflicts {
+						log.Printf("  conflicts with %s schedule #%d %q (%s - %s)", conflict.Source, conflict.IDSchedule,
+							conflict.Title, conflict.StartDate.Format(time.RFC3339), conflict.EndDate.Format(time.RFC3339))
+					}
+				} else if err.Error() == "user not found" {
+					err = &PermanentError{Err: fmt.Errorf("invalid %s message: userId %d does not exist", MessageTypeScheduleRequested, requestData.UserID)}
+					log.Printf("Rejected message: %s", err)
+				} else {
+					log.Printf("Failed to save schedule_ticket and ticket to database: %s", err)
+				}
+				fail(d, key, err)
+				continue
+			}
+			if !created {
+				log.Printf("Skipping already processed message %q, booked as ticket %d", key, savedTicket.ID)
+				d.Ack(false)
 				continue
 			}
 
 			log.Printf("Successfully saved ticket to database with ID: %d, linked to schedule ID: %d", savedTicket.ID, *savedTicket.IDSchedule)
 
-			// Send notification email
-			subject := "New Ticket Created: " + savedTicket.Title
-			body := fmt.Sprintf("A new ticket has been created with the following details:\n\nTitle: %s\nDescription: %s\nStatus: %s\nSchedule Start: %s\nSchedule End: %s\n",
-				savedTicket.Title,
-				savedTicket.Description,
-				savedTicket.Status,
-				savedSchedule.StartDate.Format(time.RFC1123),
-				savedSchedule.EndDate.Format(time.RFC1123),
-			)
-			to := []string{savedTicket.User.Email}
-
-			err = utils.SendEmail(to, subject, body, *smtpAuth, "smtp.gmail.com", smtpEmail)
-			if err != nil {
-				log.Printf("Failed to send notification email: %s", err)
-			}
-
 			// Acknowledge the message after successful processing
 			d.Ack(false)
 		}
+
+		log.Printf("Consumer of %s stopped, waiting for RabbitMQ", name)
+		ch.Close()
 	}
 }
 
-func ConsumerSchedule(name string) (<-chan amqp.Delivery, error) {
-	q, err := RabbitMQClient.Channel.QueueDeclare(
-		name,
-		false, // durable
-		false, // delete when unused
-		false, // exclusive
-		false, // no-wait
-		amqp.Table{
-			amqp.QueueTypeArg:       amqp.QueueTypeClassic,
-			amqp.ConsumerTimeoutArg: 600_000 * 6, // 1 jam
-		},
-	)
+// markRejected records the rejection of a booking submitted over HTTP
+func markRejected(bookingService *services.BookingService, key, reason string) {
+	if key == "" {
+		return
+	}
+	if err := bookingService.MarkRejected(key, reason); err != nil {
+		log.Printf("Failed to mark booking %s as rejected: %s", key, err)
+	}
+}
+
+// ConsumerSchedule opens a channel, (re)declares the queue topology and starts
+// consuming. The deliveries channel closes when the channel or connection dies.
+func ConsumerSchedule(name string, policy RetryPolicy) (*amqp.Channel, <-chan amqp.Delivery, error) {
+	if err := migrateWorkQueue(name, policy); err != nil {
+		return nil, nil, err
+	}
+
+	ch, err := RabbitMQClient.ConsumerChannel()
 	if err != nil {
-		return nil, fmt.Errorf("failed to declare a queue: %w", err)
+		return nil, nil, err
+	}
+
+	if err := declareTopology(ch, name, policy); err != nil {
+		ch.Close()
+		return nil, nil, err
+	}
+
+	// Retries and dead letters are only acked once the broker confirms the copy
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
 	}
 
-	msgs, err := RabbitMQClient.Channel.Consume(
-		q.Name, // queue
-		"",     // consumer
-		false,  // auto-ack
-		false,  // exclusive
-		false,  // no-local
-		false,  // no-wait
-		nil,    // args
+	msgs, err := ch.Consume(
+		name,  // queue
+		"",    // consumer
+		false, // auto-ack
+		false, // exclusive
+		false, // no-local
+		false, // no-wait
+		nil,   // args
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register a consumer: %w", err)
+		ch.Close()
+		return nil, nil, fmt.Errorf("failed to register a consumer: %w", err)
 	}
 
-	return msgs, nil
+	return ch, msgs, nil
 }
 
+// consumerRetryDelay is how long the worker waits before consuming again after a failure
+const consumerRetryDelay = 5 * time.Second
+
 func WorkerSchedule() {
 	log.Println("Worker started, waiting for messages...")
 
 }
 
-// ScheduleTicketMessage represents the message format from RabbitMQ
-// It contains all the data needed to create both schedule_ticket and ticket
+// ScheduleTicketMessage is the v1 payload of a schedule.requested message and
+// the form every version is converted to. It contains all the data needed to
+// create both schedule_ticket and ticket
 type ScheduleTicketMessage struct {
 	UserID      uint            `json:"userId"`
 	Title       string          `json:"title"`
@@ -142,35 +197,23 @@ type ScheduleTicketMessage struct {
 	Category    models.Category `json:"category"`
 	StartDate   time.Time       `json:"startDate"`
 	EndDate     time.Time       `json:"endDate"`
+	// RoomID may be omitted when only one room is active
+	RoomID int `json:"roomId,omitempty"`
+	// IdempotencyKey is used when the publisher does not set the AMQP message ID
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
 }
 
-func parseBodyToJSON(body []byte) (*ScheduleTicketMessage, error) {
-	if len(body) == 0 {
-		return nil, fmt.Errorf("empty message body")
-	}
-	var message ScheduleTicketMessage
-	err := json.Unmarshal(body, &message)
-	if err != nil {
-		// Try to identify the problematic character
-		if jsonErr, ok := err.(*json.SyntaxError); ok {
-			problemChar := ""
-			if int(jsonErr.Offset) < len(body) {
-				problemChar = string(body[jsonErr.Offset])
-			}
-			return nil, fmt.Errorf("JSON syntax error at offset %d (char: %q): %w", jsonErr.Offset, problemChar, err)
-		}
-		return nil, fmt.Errorf("failed to parse message body: %w", err)
+// messageKey returns the idempotency key of a request: the AMQP message ID,
+// or else the key in the body. Messages without either are not deduplicated.
+func messageKey(d amqp.Delivery, message *ScheduleTicketMessage) string {
+	if d.MessageId != "" {
+		return d.MessageId
 	}
-	return &message, nil
+	return message.IdempotencyKey
 }
 
 func CloseRabbitMQ() {
 	if RabbitMQClient != nil {
-		if RabbitMQClient.Channel != nil {
-			RabbitMQClient.Channel.Close()
-		}
-		if RabbitMQClient.Conn != nil {
-			RabbitMQClient.Conn.Close()
-		}
+		RabbitMQClient.Close()
 	}
 }
@@ -0,0 +1,88 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ketukApps/internal/models"
+	"ketukApps/internal/stream"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// StreamConsumer feeds hub with the domain events of every instance. Each
+// instance binds its own exclusive queue to the topic exchange, so an event
+// published anywhere reaches the clients connected to any instance. Like the
+// schedule worker it consumes again after the broker reconnects.
+func StreamConsumer(exchange string, hub *stream.Hub) error {
+	for {
+		if err := RabbitMQClient.WaitReady(context.Background()); err != nil {
+			if errors.Is(err, ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		ch, msgs, err := consumeStream(exchange)
+		if err != nil {
+			log.Printf("Failed to start stream consumer, retrying in %s: %s", consumerRetryDelay, err)
+			time.Sleep(consumerRetryDelay)
+			continue
+		}
+
+		for d := range msgs {
+			var event models.DomainEvent
+			if err := json.Unmarshal(d.Body, &event); err != nil {
+				log.Printf("Dropping undecodable %s event: %s", d.RoutingKey, err)
+			} else {
+				hub.Publish(event)
+			}
+			if err := d.Ack(false); err != nil {
+				log.Printf("Failed to ack %s event: %s", d.RoutingKey, err)
+			}
+		}
+
+		ch.Close()
+		log.Println("Stream consumer stopped, waiting for the message queue to reconnect")
+	}
+}
+
+// consumeStream declares a server-named queue that is deleted with its
+// connection and binds it to the events the stream forwards. Messages are
+// acked once handed to the hub, so prefetch bounds how many are in flight; a
+// client that misses one refetches on reconnect.
+func consumeStream(exchange string) (*amqp.Channel, <-chan amqp.Delivery, error) {
+	ch, err := RabbitMQClient.ConsumerChannel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ch.ExchangeDeclare(exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		ch.Close()
+		return nil, nil, fmt.Errorf("failed to declare event exchange: %w", err)
+	}
+	q, err := ch.QueueDeclare("", false, true, true, false, amqp.Table{
+		// Events older than this are stale for a live stream
+		"x-message-ttl": int32(time.Minute / time.Millisecond),
+	})
+	if err != nil {
+		ch.Close()
+		return nil, nil, fmt.Errorf("failed to declare stream queue: %w", err)
+	}
+	for _, key := range stream.RoutingKeys {
+		if err := ch.QueueBind(q.Name, key, exchange, false, nil); err != nil {
+			ch.Close()
+			return nil, nil, fmt.Errorf("failed to bind stream queue to %s: %w", key, err)
+		}
+	}
+
+	msgs, err := ch.Consume(q.Name, "", false, true, false, false, nil)
+	if err != nil {
+		ch.Close()
+		return nil, nil, fmt.Errorf("failed to consume stream queue: %w", err)
+	}
+	return ch, msgs, nil
+}
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"ketukApps/internal/models"
 	"log"
 
 	"github.com/go-co-op/gocron/v2"
@@ -10,6 +11,10 @@ import (
 type Scheduler struct {
 	Client gocron.Scheduler
 	db     *gorm.DB
+
+	// unblockWindows are the windows the unblock job last found open, by
+	// ID; nil until the first run
+	unblockWindows map[int]models.Unblocking
 }
 
 func NewScheduler(db *gorm.DB) (*Scheduler, error) {
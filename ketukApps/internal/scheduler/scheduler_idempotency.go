@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"fmt"
+	"ketukApps/internal/models"
+	"log"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+)
+
+// RegisterIdempotencyCleanupJob deletes idempotency keys older than
+// retentionDays every night. A request or message retried after that is
+// processed again.
+func (s *Scheduler) RegisterIdempotencyCleanupJob(retentionDays int) error {
+	if s.Client == nil {
+		return fmt.Errorf("scheduler not initialized")
+	}
+	if retentionDays < 1 {
+		return fmt.Errorf("idempotency retention must be at least 1 day, got %d", retentionDays)
+	}
+
+	_, err := s.Client.NewJob(
+		gocron.CronJob(
+			"30 2 * * *",
+			false,
+		),
+		gocron.NewTask(
+			func() {
+				s.purgeProcessedMessagesTask(retentionDays)
+			},
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register idempotency cleanup job: %w", err)
+	}
+	log.Printf("Idempotency cleanup job registered to run daily, keeping keys for %d day(s)", retentionDays)
+	return nil
+}
+
+// purgeProcessedMessagesTask deletes processed_messages rows past the retention window
+func (s *Scheduler) purgeProcessedMessagesTask(retentionDays int) {
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	result := s.db.Where("created_at < ?", cutoff).Delete(&models.ProcessedMessage{})
+	if result.Error != nil {
+		log.Printf("Failed to purge processed messages: %v\n", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Purged %d processed message(s) older than %d day(s).\n", result.RowsAffected, retentionDays)
+	}
+}
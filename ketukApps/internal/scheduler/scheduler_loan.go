@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"fmt"
+	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
+	"log"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+)
+
+func (s *Scheduler) RegisterOverdueLoanJob() error {
+	if s.Client == nil {
+		return fmt.Errorf("scheduler not initialized")
+	}
+
+	_, err := s.Client.NewJob(
+		gocron.CronJob(
+			"*/5 * * * *",
+			false,
+		),
+		gocron.NewTask(
+			func() {
+				s.flagOverdueLoansTask()
+			},
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register overdue loan job: %w", err)
+	}
+	log.Println("Overdue loan job registered to run every 5 minutes")
+	return nil
+}
+
+// flagOverdueLoansTask marks checked out loans whose end date has passed
+func (s *Scheduler) flagOverdueLoansTask() {
+	// Loan windows are stored as lab wall-clock times
+	now := utils.LabWallClock(time.Now())
+	result := s.db.Model(&models.ItemLoan{}).
+		Where("status = ? AND NOT is_overdue AND end_date < ?", models.LoanCheckedOut, now).
+		Update("is_overdue", true)
+	if result.Error != nil {
+		log.Printf("Failed to flag overdue loans: %v\n", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Flagged %d loan(s) as overdue.\n", result.RowsAffected)
+	}
+}
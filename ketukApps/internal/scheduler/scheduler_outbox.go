@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"ketukApps/internal/services"
+	"log"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+)
+
+// RegisterOutboxRelayJob delivers pending outbox messages every 15 seconds
+func (s *Scheduler) RegisterOutboxRelayJob(relay *services.OutboxService) error {
+	if s.Client == nil {
+		return fmt.Errorf("scheduler not initialized")
+	}
+
+	_, err := s.Client.NewJob(
+		gocron.DurationJob(
+			15*time.Second,
+		),
+		gocron.NewTask(
+			func() {
+				s.relayOutboxTask(relay)
+			},
+		),
+		// A slow channel must not start a second relay run in this process
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register outbox relay job: %w", err)
+	}
+	log.Println("Outbox relay job registered to run every 15 seconds")
+	return nil
+}
+
+func (s *Scheduler) relayOutboxTask(relay *services.OutboxService) {
+	delivered, err := relay.RelayPending(context.Background())
+	if err != nil {
+		log.Printf("Failed to relay outbox messages: %v\n", err)
+		return
+	}
+	if delivered > 0 {
+		log.Printf("Delivered %d outbox message(s).\n", delivered)
+	}
+}
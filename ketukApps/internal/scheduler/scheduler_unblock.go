@@ -3,6 +3,7 @@ package scheduler
 import (
 	"fmt"
 	"ketukApps/internal/models"
+	"ketukApps/internal/services"
 	"log"
 	"time"
 
@@ -34,25 +35,55 @@ func (s *Scheduler) RegisterUnblockJob() error {
 
 func (s *Scheduler) unblockUsersTask() {
 	log.Println("Running unblock users task...")
-	var unblockings []models.Unblocking
 	loc, err := time.LoadLocation("Asia/Jakarta")
 	if err != nil {
 		log.Printf("Failed to load location: %v\n", err)
 		return
 	}
 	time.Local = loc
-	s.db.Where("start_date <= ?", time.Now().Local()).Where("end_date >= ?", time.Now().Local()).Find(&unblockings)
-	log.Printf("Found %d unblockings to process.\n", len(unblockings))
-	if len(unblockings) > 1 {
-		log.Println("Multiple unblockings found, skipping to avoid conflicts.")
-		DisableUnblock()
-	} else if len(unblockings) == 1 {
-		for _, unblocking := range unblockings {
-			log.Printf("Processing unblocking : %v\n", unblocking)
-			EnableUnblock()
+	unblockings := services.NewUnblockingService(s.db)
+	windows, err := unblockings.OpenWindows(time.Now())
+	if err != nil {
+		log.Printf("Failed to look up open unblocking windows: %v\n", err)
+		return
+	}
+	SetOpenWindows(windows)
+	if len(windows) == 0 {
+		log.Println("No unblocking window is open, booking is closed.")
+	}
+	for _, window := range windows {
+		log.Printf("Unblocking window %d is open for %s\n", window.ID, window.Scope())
+	}
+	s.recordUnblockTransitions(unblockings, windows)
+}
+
+// recordUnblockTransitions publishes unblock.closed for the windows that
+// closed since the last run and unblock.opened for those that opened. The
+// state found at startup is not a transition.
+func (s *Scheduler) recordUnblockTransitions(unblockings *services.UnblockingService, windows []models.Unblocking) {
+	previous := s.unblockWindows
+	s.unblockWindows = make(map[int]models.Unblocking, len(windows))
+	for _, window := range windows {
+		s.unblockWindows[window.ID] = window
+	}
+	if previous == nil {
+		return
+	}
+
+	for id, window := range previous {
+		if _, ok := s.unblockWindows[id]; ok {
+			continue
+		}
+		if err := unblockings.RecordTransition(false, &window); err != nil {
+			log.Printf("Failed to record that unblocking %d closed: %v\n", id, err)
+		}
+	}
+	for _, window := range windows {
+		if _, ok := previous[window.ID]; ok {
+			continue
+		}
+		if err := unblockings.RecordTransition(true, &window); err != nil {
+			log.Printf("Failed to record that unblocking %d opened: %v\n", window.ID, err)
 		}
-	} else {
-		log.Println("No unblockings to process at this time.")
-		DisableUnblock()
 	}
 }
@@ -1,21 +1,52 @@
 package scheduler
 
-import "sync/atomic"
+import (
+	"ketukApps/internal/models"
+	"sync/atomic"
+)
 
-var unblockState atomic.Bool
+// openWindows holds the unblocking windows the unblock job last found open
+var openWindows atomic.Pointer[[]models.Unblocking]
 
-func init() {
-	unblockState.Store(false)
+// IsUnblockEnabled reports whether booking is open for any category or role
+func IsUnblockEnabled() bool {
+	windows := openWindows.Load()
+	return windows != nil && len(*windows) > 0
 }
 
-func IsUnblockEnabled() bool {
-	return unblockState.Load()
+// IsUnblockEnabledForRole reports whether any window opens booking of some
+// category for role
+func IsUnblockEnabledForRole(role string) bool {
+	windows := openWindows.Load()
+	if windows == nil || role == "" {
+		return false
+	}
+	for _, window := range *windows {
+		if window.AppliesTo("", role) {
+			return true
+		}
+	}
+	return false
 }
 
-func EnableUnblock() {
-	unblockState.Store(true)
+// IsUnblockEnabledFor reports whether booking of category is open for role.
+// A request without a category or role is closed: only a window that
+// applies to both can open it.
+func IsUnblockEnabledFor(category models.Category, role string) bool {
+	windows := openWindows.Load()
+	if windows == nil || category == "" || role == "" {
+		return false
+	}
+	for _, window := range *windows {
+		if window.AppliesTo(category, role) {
+			return true
+		}
+	}
+	return false
 }
 
-func DisableUnblock() {
-	unblockState.Store(false)
+// SetOpenWindows replaces the windows booking is open for. The unblock job
+// calls it after every run.
+func SetOpenWindows(windows []models.Unblocking) {
+	openWindows.Store(&windows)
 }
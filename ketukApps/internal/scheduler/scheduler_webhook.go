@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"ketukApps/internal/services"
+	"log"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+)
+
+// RegisterWebhookRelayJob posts pending webhook deliveries every 15 seconds
+func (s *Scheduler) RegisterWebhookRelayJob(webhooks *services.WebhookService) error {
+	if s.Client == nil {
+		return fmt.Errorf("scheduler not initialized")
+	}
+
+	_, err := s.Client.NewJob(
+		gocron.DurationJob(
+			15*time.Second,
+		),
+		gocron.NewTask(
+			func() {
+				s.relayWebhooksTask(webhooks)
+			},
+		),
+		// A slow receiver must not start a second relay run in this process
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register webhook relay job: %w", err)
+	}
+	log.Println("Webhook relay job registered to run every 15 seconds")
+	return nil
+}
+
+func (s *Scheduler) relayWebhooksTask(webhooks *services.WebhookService) {
+	succeeded, err := webhooks.DeliverPending(context.Background())
+	if err != nil {
+		log.Printf("Failed to deliver webhooks: %v\n", err)
+		return
+	}
+	if succeeded > 0 {
+		log.Printf("Delivered %d webhook(s).\n", succeeded)
+	}
+}
@@ -82,24 +82,32 @@ func (s *AuditService) LogTicketEvent(
 	return nil
 }
 
-// GetTicketEventLogs retrieves all event logs for a specific ticket
-func (s *AuditService) GetTicketEventLogs(ticketID int) ([]models.TicketEventLog, error) {
+// eventLogListSpec lists the filters and sort keys of audit log lists
+var eventLogListSpec = listSpec{
+	filters: map[string]listFilter{
+		"action": enumFilter("action", models.EventCreated, models.EventUpdated, models.EventStatusChanged,
+			models.EventDeleted, models.EventAssigned, models.EventCommented, models.EventApproved, models.EventRejected),
+	},
+	sorts: map[string]string{
+		"createdAt": "created_at",
+		"action":    "action",
+	},
+	defaultSort: "-id",
+	dateColumn:  "created_at",
+}
+
+// GetTicketEventLogs retrieves a page of event logs for a specific ticket, newest first
+func (s *AuditService) GetTicketEventLogs(ticketID int, q models.ListQuery) ([]models.TicketEventLog, *models.PageMeta, error) {
 	var logs []models.TicketEventLog
-	err := s.db.Where("ticket_id = ?", ticketID).
-		Order("created_at DESC").
-		Preload("User").
-		Find(&logs).Error
-	return logs, err
+	meta, err := listPage(s.db.Model(&models.TicketEventLog{}).Where("ticket_id = ?", ticketID), eventLogListSpec, q, &logs, "User")
+	return logs, meta, err
 }
 
-// GetEventLogsByUser retrieves all event logs by a specific user
-func (s *AuditService) GetEventLogsByUser(userID int) ([]models.TicketEventLog, error) {
+// GetEventLogsByUser retrieves a page of event logs by a specific user, newest first
+func (s *AuditService) GetEventLogsByUser(userID int, q models.ListQuery) ([]models.TicketEventLog, *models.PageMeta, error) {
 	var logs []models.TicketEventLog
-	err := s.db.Where("user_id = ?", userID).
-		Order("created_at DESC").
-		Preload("Ticket").
-		Find(&logs).Error
-	return logs, err
+	meta, err := listPage(s.db.Model(&models.TicketEventLog{}).Where("user_id = ?", userID), eventLogListSpec, q, &logs, "Ticket")
+	return logs, meta, err
 }
 
 // CompareTickets compares two ticket objects and returns the changes
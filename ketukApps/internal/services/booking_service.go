@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ketukApps/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BookingPublisher puts a booking on the schedule queue
+type BookingPublisher interface {
+	Publish(ctx context.Context, booking *models.BookingRequest) error
+}
+
+type BookingService struct {
+	db *gorm.DB
+}
+
+func NewBookingService(db *gorm.DB) *BookingService {
+	return &BookingService{
+		db: db,
+	}
+}
+
+// Create validates a booking and records it as queued. With an idempotency
+// key a retried request returns the booking of the first one and created is
+// false. The caller publishes the booking to the schedule queue.
+func (s *BookingService) Create(userID uint, key string, req models.CreateBookingRequest) (booking *models.BookingRequest, created bool, err error) {
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, false, fmt.Errorf("idempotency key must be at most %d characters", MaxIdempotencyKeyLength)
+	}
+	if err := validateScheduleRange(req.StartDate, req.EndDate); err != nil {
+		return nil, false, err
+	}
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, errors.New("user not found")
+		}
+		return nil, false, err
+	}
+	roomID, err := resolveRoomID(s.db, req.RoomID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := checkRoomOpen(s.db, roomID, req.StartDate, req.EndDate); err != nil {
+		return nil, false, err
+	}
+
+	req.UserID = userID
+	hash, err := requestHash(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	booking = &models.BookingRequest{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		RequestHash: hash,
+		Title:       req.Title,
+		Description: req.Description,
+		Kategori:    req.Kategori,
+		RoomID:      roomID,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Status:      models.BookingQueued,
+	}
+	if key == "" {
+		if err := s.db.Create(booking).Error; err != nil {
+			return nil, false, err
+		}
+		return booking, true, nil
+	}
+
+	booking.IdempotencyKey = &key
+	result := s.db.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "user_id"}, {Name: "idempotency_key"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "idempotency_key IS NOT NULL"}}},
+		DoNothing:   true,
+	}).Create(booking)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return booking, true, nil
+	}
+
+	var existing models.BookingRequest
+	if err := s.db.Where("user_id = ? AND idempotency_key = ?", userID, key).First(&existing).Error; err != nil {
+		return nil, false, err
+	}
+	if existing.RequestHash != hash {
+		return nil, false, ErrIdempotencyKeyReused
+	}
+	return &existing, false, nil
+}
+
+// GetByID returns a booking by its tracking ID
+func (s *BookingService) GetByID(id string) (*models.BookingRequest, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("booking not found")
+	}
+	var booking models.BookingRequest
+	if err := s.db.First(&booking, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("booking not found")
+		}
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// MarkUnpublished records that publishing a booking failed and leaves it to
+// the outbox relay to publish again. The broker may still have received the
+// message, which the worker deduplicates by tracking ID.
+func (s *BookingService) MarkUnpublished(id string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.BookingRequest{}).
+			Where("id = ? AND status = ?", id, models.BookingQueued).
+			Update("status", models.BookingUnpublished)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// The worker already reported on it
+			return nil
+		}
+
+		payload, err := json.Marshal(map[string]string{"id": id})
+		if err != nil {
+			return err
+		}
+		message := models.OutboxMessage{
+			Kind:          models.OutboxBooking,
+			Destination:   id,
+			Payload:       string(payload),
+			Status:        models.OutboxPending,
+			NextAttemptAt: time.Now(),
+		}
+		if err := tx.Create(&message).Error; err != nil {
+			return fmt.Errorf("failed to write outbox message: %w", err)
+		}
+		return nil
+	})
+}
+
+// MarkRejected records why the worker turned a booking down. Messages that
+// did not come from the bookings endpoint are ignored.
+func (s *BookingService) MarkRejected(id, reason string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil
+	}
+	return s.db.Model(&models.BookingRequest{}).
+		Where("id = ? AND status <> ?", id, models.BookingProcessed).
+		Updates(map[string]interface{}{
+			"status": models.BookingRejected,
+			"reason": reason,
+		}).Error
+}
+
+// markBookingProcessed links the booking with tracking ID id to its ticket,
+// inside the transaction that created the ticket
+func markBookingProcessed(tx *gorm.DB, id string, ticketID uint) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil
+	}
+	return tx.Model(&models.BookingRequest{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":    models.BookingProcessed,
+			"ticket_id": ticketID,
+			"reason":    "",
+		}).Error
+}
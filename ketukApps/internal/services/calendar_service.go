@@ -0,0 +1,221 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// calendarFeedHistory is how far back booked schedule tickets appear in feeds
+const calendarFeedHistory = 180 * 24 * time.Hour
+
+type CalendarService struct {
+	db *gorm.DB
+}
+
+func NewCalendarService(db *gorm.DB) *CalendarService {
+	return &CalendarService{
+		db: db,
+	}
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateFeedToken issues a new feed token for a user, revoking any previous one.
+// The plain token is returned once and never stored.
+func (s *CalendarService) CreateFeedToken(userID int) (string, *models.CalendarFeedToken, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to generate feed token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	feedToken := models.CalendarFeedToken{
+		UserID:    userID,
+		TokenHash: hashFeedToken(token),
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.CalendarFeedToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(&feedToken).Error
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return token, &feedToken, nil
+}
+
+// RevokeFeedToken revokes the user's active feed token
+func (s *CalendarService) RevokeFeedToken(userID int) error {
+	result := s.db.Model(&models.CalendarFeedToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("calendar feed token not found")
+	}
+	return nil
+}
+
+// GetUserByFeedToken resolves an active feed token to its owner
+func (s *CalendarService) GetUserByFeedToken(token string) (*models.User, error) {
+	var feedToken models.CalendarFeedToken
+	err := s.db.Preload("User").
+		Where("token_hash = ? AND revoked_at IS NULL", hashFeedToken(token)).
+		First(&feedToken).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid calendar feed token")
+		}
+		return nil, err
+	}
+	if feedToken.User == nil {
+		return nil, errors.New("invalid calendar feed token")
+	}
+
+	// The feed is still served when only the usage timestamp fails to save
+	if err := s.db.Model(&feedToken).Update("last_used_at", time.Now()).Error; err != nil {
+		log.Printf("Failed to record use of calendar feed token #%d: %s", feedToken.ID, err)
+	}
+	return feedToken.User, nil
+}
+
+// UserFeed renders the regular schedules and booked schedule tickets of a user
+func (s *CalendarService) UserFeed(user *models.User) (string, error) {
+	events, err := s.feedEvents(s.db.Where("user_id = ?", user.ID))
+	if err != nil {
+		return "", err
+	}
+	calendar := utils.ICalendar{Name: "Ketuk - " + user.Name, Events: events}
+	return calendar.String(), nil
+}
+
+// LabFeed renders every regular schedule and booked schedule ticket
+func (s *CalendarService) LabFeed() (string, error) {
+	events, err := s.feedEvents(s.db)
+	if err != nil {
+		return "", err
+	}
+	calendar := utils.ICalendar{Name: "Ketuk - Lab", Events: events}
+	return calendar.String(), nil
+}
+
+// feedEvents builds VEVENTs for the schedules matched by scope
+func (s *CalendarService) feedEvents(scope *gorm.DB) ([]utils.ICalEvent, error) {
+	var regulers []models.ScheduleReguler
+	if err := scope.Session(&gorm.Session{}).Preload("User").Preload("Room").Order("start_date").Find(&regulers).Error; err != nil {
+		return nil, err
+	}
+
+	var tickets []models.ScheduleTicket
+	if err := scope.Session(&gorm.Session{}).Preload("User").Preload("Room").
+		Where("is_booked AND end_date > ?", time.Now().Add(-calendarFeedHistory)).
+		Order("start_date").
+		Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+
+	semesters := newSemesterEnds(s.db)
+	events := make([]utils.ICalEvent, 0, len(regulers)+len(tickets))
+	for _, r := range regulers {
+		event, err := s.regulerEvent(semesters, r)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	for _, t := range tickets {
+		events = append(events, utils.ICalEvent{
+			UID:          fmt.Sprintf("schedule-ticket-%d@ketuk", t.IDSchedule),
+			Summary:      t.Title,
+			Description:  scheduleEventDescription("Booking: "+string(t.Kategori), t.Description, t.User),
+			Location:     roomName(t.Room),
+			Start:        t.StartDate,
+			End:          t.EndDate,
+			LastModified: t.UpdatedAt,
+		})
+	}
+	return events, nil
+}
+
+// regulerEvent converts a regular schedule into a VEVENT. Open-ended rules
+// get an UNTIL at the end of their semester so clients don't repeat them forever.
+func (s *CalendarService) regulerEvent(semesters *semesterEnds, r models.ScheduleReguler) (utils.ICalEvent, error) {
+	event := utils.ICalEvent{
+		UID:          fmt.Sprintf("schedule-reguler-%d@ketuk", r.IDSchedule),
+		Summary:      r.Title,
+		Description:  scheduleEventDescription("Regular schedule", "", r.User),
+		Location:     roomName(r.Room),
+		Start:        r.StartDate,
+		End:          r.EndDate,
+		LastModified: r.CreatedAt,
+	}
+	if r.RRule == "" {
+		return event, nil
+	}
+
+	rule, err := utils.ParseRRule(r.RRule)
+	if err != nil {
+		return event, fmt.Errorf("schedule reguler %d has an invalid rrule: %w", r.IDSchedule, err)
+	}
+	if rule.Until != nil {
+		until := utils.InLabTime(*rule.Until)
+		rule.Until = &until
+	}
+	if !rule.Bounded() {
+		end, err := semesters.end(r.Tahun, r.Semester)
+		if err != nil {
+			return event, err
+		}
+		if end != nil {
+			until := utils.InLabTime(*end)
+			rule.Until = &until
+		}
+	}
+	event.RRule = rule.String()
+
+	// EXDATE must match the occurrence start, so keep the series' time of day
+	for _, exDate := range r.ExDates {
+		event.ExDates = append(event.ExDates, time.Date(
+			exDate.Year(), exDate.Month(), exDate.Day(),
+			r.StartDate.Hour(), r.StartDate.Minute(), r.StartDate.Second(), 0, r.StartDate.Location(),
+		))
+	}
+	return event, nil
+}
+
+func scheduleEventDescription(kind, description string, user *models.User) string {
+	lines := []string{kind}
+	if user != nil {
+		lines = append(lines, "Booked by: "+user.Name)
+	}
+	if description != "" {
+		lines = append(lines, "", description)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func roomName(room *models.Room) string {
+	if room == nil {
+		return ""
+	}
+	return room.Name
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ketukApps/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// domainEventVersion is the version of every event's data
+const domainEventVersion = 1
+
+// domainEventSource identifies this application to subscribers
+const domainEventSource = "ketuk"
+
+// EventPublisher sends an encoded domain event with its type as routing key
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, routingKey, messageID string, body []byte) error
+}
+
+// enqueueEvent writes a domain event to the outbox, and a delivery for every
+// webhook subscribed to it, using tx so it is only published when the change
+// that caused it is committed
+func enqueueEvent(tx *gorm.DB, eventType models.DomainEventType, data map[string]interface{}) error {
+	return enqueueEventWithID(tx, uuid.NewString(), eventType, data)
+}
+
+// enqueueEventWithID is enqueueEvent for events whose ID is derived from what
+// happened, so every instance that notices it publishes the same ID
+func enqueueEventWithID(tx *gorm.DB, id string, eventType models.DomainEventType, data map[string]interface{}) error {
+	event := models.DomainEvent{
+		ID:         id,
+		Type:       eventType,
+		Version:    domainEventVersion,
+		Source:     domainEventSource,
+		OccurredAt: time.Now(),
+		Data:       data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	message := models.OutboxMessage{
+		Kind:          models.OutboxEvent,
+		Destination:   string(eventType),
+		Payload:       string(payload),
+		Status:        models.OutboxPending,
+		NextAttemptAt: event.OccurredAt,
+	}
+	if err := tx.Create(&message).Error; err != nil {
+		return fmt.Errorf("failed to write outbox message: %w", err)
+	}
+	return enqueueWebhooks(tx, event, message.Payload)
+}
+
+func ticketCreatedEvent(ticket *models.Ticket) map[string]interface{} {
+	return map[string]interface{}{
+		"ticketId":    ticket.ID,
+		"userId":      ticket.UserID,
+		"title":       ticket.Title,
+		"description": ticket.Description,
+		"status":      ticket.Status,
+		"kategori":    ticket.Kategori,
+		"scheduleId":  ticket.IDSchedule,
+		"roomId":      ticket.RoomID,
+		"createdAt":   ticket.CreatedAt,
+	}
+}
+
+func userCreatedEvent(user *models.User) map[string]interface{} {
+	return map[string]interface{}{
+		"userId":    user.ID,
+		"name":      user.Name,
+		"email":     user.Email,
+		"role":      user.Role,
+		"createdAt": user.CreatedAt,
+	}
+}
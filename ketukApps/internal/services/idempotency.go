@@ -0,0 +1,86 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"ketukApps/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxIdempotencyKeyLength is the longest idempotency key accepted
+const MaxIdempotencyKeyLength = 255
+
+// ErrIdempotencyKeyReused is returned when a key comes back with a different request
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+// Resource types recorded for idempotency keys
+const idempotentTicket = "ticket"
+
+// Idempotency scopes. API keys are scoped per user so clients cannot collide.
+const scopeQueuePrefix = "queue:"
+
+func apiTicketsScope(userID uint) string {
+	return fmt.Sprintf("api:tickets:%d", userID)
+}
+
+// requestHash fingerprints a request so a reused key can be told apart from a retry
+func requestHash(request interface{}) (string, error) {
+	var raw []byte
+	switch v := request.(type) {
+	case []byte:
+		raw = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		raw = encoded
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// claimIdempotencyKey records key inside tx. It returns the earlier record
+// when the key was already processed; the insert waits for a concurrent
+// transaction holding the same key, so only one of them gets to create.
+func claimIdempotencyKey(tx *gorm.DB, scope, key, hash, resourceType string) (*models.ProcessedMessage, error) {
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, fmt.Errorf("idempotency key must be at most %d characters", MaxIdempotencyKeyLength)
+	}
+
+	claim := models.ProcessedMessage{
+		Scope:        scope,
+		Key:          key,
+		RequestHash:  hash,
+		ResourceType: resourceType,
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&claim)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	var processed models.ProcessedMessage
+	if err := tx.Where("scope = ? AND idempotency_key = ?", scope, key).First(&processed).Error; err != nil {
+		return nil, err
+	}
+	if processed.RequestHash != hash || processed.ResourceType != resourceType {
+		return nil, ErrIdempotencyKeyReused
+	}
+	return &processed, nil
+}
+
+// completeIdempotencyKey stores the ID of the record created under key
+func completeIdempotencyKey(tx *gorm.DB, scope, key string, resourceID int64) error {
+	return tx.Model(&models.ProcessedMessage{}).
+		Where("scope = ? AND idempotency_key = ?", scope, key).
+		Update("resource_id", resourceID).Error
+}
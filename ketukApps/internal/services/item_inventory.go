@@ -0,0 +1,489 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"ketukApps/internal/models"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// inventoryColumns are the spreadsheet header names; the first two are required
+var inventoryColumns = []string{"name", "category", "year", "kondisi", "note", "specification"}
+
+// minInventoryYear is the oldest procurement year accepted by the import
+const minInventoryYear = 1970
+
+// inventorySheet is the worksheet written by the XLSX export
+const inventorySheet = "Inventory"
+
+// formulaPrefixes are the leading characters that make spreadsheet apps treat
+// a cell as a formula
+const formulaPrefixes = "=+-@"
+
+// errInventoryImportRejected rolls back an import that is a dry run or has invalid rows
+var errInventoryImportRejected = errors.New("inventory import rejected")
+
+// itemImportCandidate is a parsed row waiting for validation
+type itemImportCandidate struct {
+	report        *models.ItemImportRow
+	year          string
+	kondisi       string
+	note          string
+	specification string
+	category      *models.ItemCategory
+	existing      *models.Item
+}
+
+func (c *itemImportCandidate) fail(format string, args ...interface{}) {
+	c.report.Errors = append(c.report.Errors, fmt.Sprintf(format, args...))
+}
+
+func (c *itemImportCandidate) valid() bool {
+	return len(c.report.Errors) == 0
+}
+
+// ExportItems writes every item with its category as CSV or XLSX
+func (s *ItemService) ExportItems(w io.Writer, format models.InventoryFormat) error {
+	var items []models.Item
+	if err := s.db.Joins("Category").
+		Order(`"Category".category_name, items.name`).
+		Find(&items).Error; err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(items)+1)
+	rows = append(rows, inventoryColumns)
+	for _, item := range items {
+		year := ""
+		if item.Year != nil {
+			year = strconv.Itoa(*item.Year)
+		}
+		category, specification := "", ""
+		if item.Category != nil {
+			category, specification = item.Category.CategoryName, item.Category.Specification
+		}
+		rows = append(rows, []string{
+			escapeInventoryCell(item.Name),
+			escapeInventoryCell(category),
+			year,
+			string(item.Kondisi),
+			escapeInventoryCell(item.Note),
+			escapeInventoryCell(specification),
+		})
+	}
+
+	switch format {
+	case models.InventoryCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case models.InventoryXLSX:
+		return writeInventoryXLSX(w, rows)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// escapeInventoryCell quotes free text that would otherwise run as a formula
+// when the export is opened in a spreadsheet app
+func escapeInventoryCell(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeInventoryCell drops the quote added by escapeInventoryCell so an
+// exported file can be imported again unchanged
+func unescapeInventoryCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func writeInventoryXLSX(w io.Writer, rows [][]string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", inventorySheet); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		cells := make([]interface{}, len(row))
+		for j, value := range row {
+			cells[j] = value
+		}
+		// Keep years numeric so spreadsheet filters and sorting work
+		if i > 0 && row[2] != "" {
+			if year, err := strconv.Atoi(row[2]); err == nil {
+				cells[2] = year
+			}
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := f.SetSheetRow(inventorySheet, cell, &cells); err != nil {
+			return err
+		}
+	}
+
+	header, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	if err := f.SetRowStyle(inventorySheet, 1, 1, header); err != nil {
+		return err
+	}
+	if err := f.SetPanes(inventorySheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	for col, width := range map[string]float64{"A": 20, "B": 20, "C": 8, "D": 16, "E": 40, "F": 40} {
+		if err := f.SetColWidth(inventorySheet, col, col, width); err != nil {
+			return err
+		}
+	}
+
+	return f.Write(w)
+}
+
+// ImportItems validates every row of a CSV or XLSX inventory and, unless dryRun
+// is set, upserts all of them in a single transaction. Items are matched by
+// name within their category. Unknown categories are rejected unless
+// createCategories is set. Nothing is written when any row is invalid. The
+// returned error is only set for unreadable files.
+func (s *ItemService) ImportItems(r io.Reader, format models.InventoryFormat, createCategories, dryRun bool, changedBy *uint) (*models.ItemImportReport, error) {
+	var records [][]string
+	var err error
+	switch format {
+	case models.InventoryCSV:
+		records, err = readInventoryCSV(r)
+	case models.InventoryXLSX:
+		records, err = readInventoryXLSX(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := parseInventoryRecords(records)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("import file contains no items")
+	}
+
+	report := &models.ItemImportReport{
+		Format:    format,
+		DryRun:    dryRun,
+		TotalRows: len(candidates),
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		created, err := validateInventoryImport(tx, candidates, createCategories)
+		if err != nil {
+			return err
+		}
+		report.CategoriesCreated = created
+		for _, c := range candidates {
+			if c.valid() {
+				report.ValidRows++
+			}
+		}
+		if dryRun || report.ValidRows != report.TotalRows {
+			return errInventoryImportRejected
+		}
+
+		categoryIDs := make(map[int]bool)
+		for _, c := range candidates {
+			categoryIDs[c.category.ID] = true
+			if err := applyInventoryRow(tx, c, changedBy); err != nil {
+				return fmt.Errorf("row %d: %w", c.report.Row, err)
+			}
+		}
+
+		// The per-row trigger already counts inserts; recounting the touched
+		// categories also repairs any drift from before the import
+		ids := make([]int, 0, len(categoryIDs))
+		for id := range categoryIDs {
+			ids = append(ids, id)
+		}
+		return tx.Exec(`UPDATE items_category
+			SET quantity = (SELECT COUNT(*) FROM items WHERE items.category_id = items_category.id)
+			WHERE id IN ?`, ids).Error
+	})
+	if err != nil && !errors.Is(err, errInventoryImportRejected) {
+		return nil, err
+	}
+	report.Committed = err == nil
+
+	report.Rows = make([]models.ItemImportRow, 0, len(candidates))
+	for _, c := range candidates {
+		switch c.report.Action {
+		case models.ItemImportCreate:
+			report.Created++
+		case models.ItemImportUpdate:
+			report.Updated++
+		}
+		report.Rows = append(report.Rows, *c.report)
+	}
+	return report, nil
+}
+
+// validateInventoryImport resolves categories and existing items and checks
+// each row. It returns the names of categories it had to create.
+func validateInventoryImport(tx *gorm.DB, candidates []*itemImportCandidate, createCategories bool) ([]string, error) {
+	var categories []models.ItemCategory
+	if err := tx.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*models.ItemCategory, len(categories))
+	for i := range categories {
+		byName[strings.ToLower(categories[i].CategoryName)] = &categories[i]
+	}
+
+	var created []string
+	for _, c := range candidates {
+		if c.report.Name == "" {
+			c.fail("name is required")
+		}
+		if c.report.Category == "" {
+			c.fail("category is required")
+		} else if category, ok := byName[strings.ToLower(c.report.Category)]; ok {
+			c.category = category
+		} else if createCategories {
+			category := &models.ItemCategory{CategoryName: c.report.Category, Specification: c.specification}
+			if err := tx.Create(category).Error; err != nil {
+				return nil, err
+			}
+			byName[strings.ToLower(c.report.Category)] = category
+			c.category = category
+			created = append(created, category.CategoryName)
+		} else {
+			c.fail("no category named %s", c.report.Category)
+		}
+
+		if c.year != "" {
+			year, err := strconv.Atoi(c.year)
+			if err != nil {
+				c.fail("year %q is not a number", c.year)
+			} else if maxYear := time.Now().Year(); year < minInventoryYear || year > maxYear {
+				c.fail("year must be between %d and %d", minInventoryYear, maxYear)
+			} else {
+				c.report.Year = &year
+			}
+		}
+
+		if c.kondisi != "" {
+			kondisi, ok := parseKondisi(c.kondisi)
+			if !ok {
+				c.fail("invalid kondisi %q", c.kondisi)
+			}
+			c.report.Kondisi = kondisi
+		}
+	}
+
+	// Match existing items by name within each category
+	seen := make(map[string]int)
+	for _, c := range candidates {
+		if c.category == nil || c.report.Name == "" {
+			continue
+		}
+		key := fmt.Sprintf("%d/%s", c.category.ID, strings.ToLower(c.report.Name))
+		if row, ok := seen[key]; ok {
+			c.fail("duplicates row %d", row)
+			continue
+		}
+		seen[key] = c.report.Row
+
+		var matches []models.Item
+		if err := tx.Where("category_id = ? AND LOWER(name) = LOWER(?)", c.category.ID, c.report.Name).
+			Order("id").Limit(2).Find(&matches).Error; err != nil {
+			return nil, err
+		}
+		switch len(matches) {
+		case 0:
+			c.report.Action = models.ItemImportCreate
+			if c.report.Kondisi == "" {
+				c.report.Kondisi = models.KondisiBaik
+			}
+		case 1:
+			c.existing = &matches[0]
+			c.report.ItemID = c.existing.ID
+			// Blank cells keep the current values of an existing item
+			if c.report.Kondisi == "" {
+				c.report.Kondisi = c.existing.Kondisi
+			}
+			if c.year == "" {
+				c.report.Year = c.existing.Year
+			}
+			if c.note == "" {
+				c.note = c.existing.Note
+			}
+			if c.report.Kondisi != c.existing.Kondisi && !c.existing.Kondisi.CanTransitionTo(c.report.Kondisi) {
+				c.fail("kondisi cannot change from %s to %s", c.existing.Kondisi, c.report.Kondisi)
+			}
+			c.report.Action = models.ItemImportUnchanged
+			if inventoryRowChanges(c) {
+				c.report.Action = models.ItemImportUpdate
+			}
+		default:
+			c.fail("more than one item named %s in category %s", c.report.Name, c.category.CategoryName)
+		}
+	}
+
+	for _, c := range candidates {
+		if !c.valid() {
+			c.report.Action = ""
+		}
+	}
+	return created, nil
+}
+
+func inventoryRowChanges(c *itemImportCandidate) bool {
+	item := c.existing
+	if c.report.Kondisi != item.Kondisi || c.note != item.Note {
+		return true
+	}
+	if (c.report.Year == nil) != (item.Year == nil) {
+		return true
+	}
+	return c.report.Year != nil && *c.report.Year != *item.Year
+}
+
+// applyInventoryRow creates or updates the item of a validated row
+func applyInventoryRow(tx *gorm.DB, c *itemImportCandidate, changedBy *uint) error {
+	switch c.report.Action {
+	case models.ItemImportCreate:
+		item := models.Item{
+			Name:       c.report.Name,
+			Year:       c.report.Year,
+			Kondisi:    c.report.Kondisi,
+			Note:       c.note,
+			CategoryID: c.category.ID,
+		}
+		if err := tx.Omit("Category").Create(&item).Error; err != nil {
+			return err
+		}
+		c.report.ItemID = item.ID
+		return tx.Create(&models.ItemKondisiHistory{
+			ItemID:    item.ID,
+			ToKondisi: item.Kondisi,
+			ChangedBy: changedBy,
+			Reason:    "Item registered by inventory import",
+		}).Error
+	case models.ItemImportUpdate:
+		item, err := findItemForUpdate(tx, c.existing.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(item).Updates(map[string]interface{}{
+			"year": c.report.Year,
+			"note": c.note,
+		}).Error; err != nil {
+			return err
+		}
+		return changeItemKondisi(tx, item, c.report.Kondisi, "Updated by inventory import", changedBy, nil)
+	}
+	return nil
+}
+
+// parseKondisi matches a spreadsheet value against the kondisi enum, ignoring case
+func parseKondisi(value string) (models.Kondisi, bool) {
+	for kondisi := range models.KondisiTransitions {
+		if strings.EqualFold(string(kondisi), strings.Join(strings.Fields(value), " ")) {
+			return kondisi, true
+		}
+	}
+	return "", false
+}
+
+func readInventoryCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV file is empty")
+	}
+	return records, nil
+}
+
+// readInventoryXLSX reads the first worksheet of a workbook
+func readInventoryXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("XLSX file has no worksheets")
+	}
+	records, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("XLSX worksheet is empty")
+	}
+	return records, nil
+}
+
+// parseInventoryRecords turns spreadsheet rows into candidates. The first row
+// is the header; column names are case-insensitive, see inventoryColumns.
+func parseInventoryRecords(records [][]string) ([]*itemImportCandidate, error) {
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range inventoryColumns[:2] {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("header is missing column %q", required)
+		}
+	}
+
+	var candidates []*itemImportCandidate
+	for i, record := range records[1:] {
+		field := func(name string) string {
+			if j, ok := columns[name]; ok && j < len(record) {
+				return unescapeInventoryCell(strings.TrimSpace(record[j]))
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		candidates = append(candidates, &itemImportCandidate{
+			report: &models.ItemImportRow{
+				Row:      i + 2,
+				Name:     field("name"),
+				Category: field("category"),
+			},
+			year:          field("year"),
+			kondisi:       field("kondisi"),
+			note:          field("note"),
+			specification: field("specification"),
+		})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import "testing"
+
+func TestInventoryCellEscaping(t *testing.T) {
+	tests := []struct {
+		value   string
+		escaped string
+	}{
+		{"=HYPERLINK(\"http://example.com\")", "'=HYPERLINK(\"http://example.com\")"},
+		{"+62 812", "'+62 812"},
+		{"-", "'-"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"Proyektor Epson", "Proyektor Epson"},
+		{"'quoted", "'quoted"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		escaped := escapeInventoryCell(tt.value)
+		if escaped != tt.escaped {
+			t.Errorf("expected %q to be exported as %q, got %q", tt.value, tt.escaped, escaped)
+		}
+		if got := unescapeInventoryCell(escaped); got != tt.value {
+			t.Errorf("expected %q to be imported as %q, got %q", escaped, tt.value, got)
+		}
+	}
+}
+
+func TestParseInventoryRecordsStripsFormulaQuotes(t *testing.T) {
+	records := [][]string{
+		{"Name", "Category", "Year", "Kondisi", "Note", "Specification"},
+		{"'=cmd|' /C calc'!A0", "Proyektor", "2020", "Baik", "'-rusak ringan", "'quoted"},
+	}
+
+	candidates, err := parseInventoryRecords(records)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := candidates[0]
+	if c.report.Name != "=cmd|' /C calc'!A0" || c.note != "-rusak ringan" || c.specification != "'quoted" {
+		t.Errorf("unexpected fields name=%q note=%q specification=%q", c.report.Name, c.note, c.specification)
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// LookupItem resolves a scanned asset tag to the item and its current status
+func (s *ItemService) LookupItem(id int) (*models.ItemLookup, error) {
+	item, err := s.GetItemByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := &models.ItemLookup{
+		AssetTag: utils.AssetTag(item.ID),
+		Item:     *item,
+		Status:   models.ItemAvailable,
+	}
+	if !isLoanable(item.Kondisi) {
+		lookup.Status = models.ItemUnavailable
+	}
+
+	current, err := findItemLoan(s.db, item.ID, "l.status = ?", models.LoanCheckedOut)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		lookup.CurrentLoan = current
+		lookup.Status = models.ItemOnLoan
+	}
+
+	lookup.NextLoan, err = findItemLoan(s.db, item.ID, "l.status = ? AND l.end_date > ?",
+		models.LoanApproved, utils.LabWallClock(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	return lookup, nil
+}
+
+// GetItemsForLabels returns a category and its items that still need a label,
+// ordered by name. Written-off items are left out.
+func (s *ItemService) GetItemsForLabels(categoryID int) (*models.ItemCategory, []models.Item, error) {
+	category, err := s.GetItemCategoryByID(categoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var items []models.Item
+	if err := s.db.Where("category_id = ? AND kondisi <> ?", categoryID, models.KondisiDihapuskan).
+		Order("name, id").
+		Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+	return category, items, nil
+}
+
+// findItemLoan returns the earliest loan of an item matching the condition, or nil
+func findItemLoan(db *gorm.DB, itemID int, query string, args ...interface{}) (*models.ItemLoan, error) {
+	var loan models.ItemLoan
+	err := db.Table("item_loans AS l").
+		Select("l.*").
+		Preload("User").
+		Joins("JOIN item_loan_items li ON li.loan_id = l.id AND li.item_id = ?", itemID).
+		Where(query, args...).
+		Order("l.start_date").
+		Take(&loan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
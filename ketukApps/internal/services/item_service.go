@@ -2,10 +2,14 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 
 	"ketukApps/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ItemService struct {
@@ -101,11 +105,30 @@ func (s *ItemService) DeleteItemCategory(id int) error {
 
 // Item methods
 
-// GetAllItems returns all items
-func (s *ItemService) GetAllItems() ([]models.Item, error) {
+// itemListSpec lists the filters and sort keys of item lists
+var itemListSpec = listSpec{
+	filters: map[string]listFilter{
+		"categoryId": intFilter("category_id"),
+		"kondisi": enumFilter("kondisi", models.KondisiBaik, models.KondisiRusakRingan, models.KondisiRusakBerat,
+			models.KondisiDalamPerbaikan, models.KondisiDihapuskan),
+		"year": intFilter("year"),
+	},
+	sorts: map[string]string{
+		"name":      "name",
+		"year":      "year",
+		"kondisi":   "kondisi",
+		"createdAt": "created_at",
+		"updatedAt": "updated_at",
+	},
+	defaultSort: "id",
+	dateColumn:  "created_at",
+}
+
+// GetAllItems returns a page of items
+func (s *ItemService) GetAllItems(q models.ListQuery) ([]models.Item, *models.PageMeta, error) {
 	var items []models.Item
-	result := s.db.Preload("Category").Find(&items)
-	return items, result.Error
+	meta, err := listPage(s.db.Model(&models.Item{}), itemListSpec, q, &items, "Category")
+	return items, meta, err
 }
 
 // GetItemByID returns an item by its ID
@@ -118,38 +141,49 @@ func (s *ItemService) GetItemByID(id int) (*models.Item, error) {
 	return &item, result.Error
 }
 
-// GetItemsByCategoryID returns all items for a specific category
-func (s *ItemService) GetItemsByCategoryID(categoryID int) ([]models.Item, error) {
+// GetItemsByCategoryID returns a page of items for a specific category
+func (s *ItemService) GetItemsByCategoryID(categoryID int, q models.ListQuery) ([]models.Item, *models.PageMeta, error) {
 	var items []models.Item
-	result := s.db.Preload("Category").Where("category_id = ?", categoryID).Find(&items)
-	return items, result.Error
+	meta, err := listPage(s.db.Model(&models.Item{}).Where("category_id = ?", categoryID), itemListSpec, q, &items, "Category")
+	return items, meta, err
 }
 
-// SearchItems searches items by name or note
+// SearchItems runs a full-text search over item names and notes and their
+// category name and specification, best match first
 func (s *ItemService) SearchItems(query string) ([]models.Item, error) {
 	var items []models.Item
-	searchTerm := "%" + query + "%"
 	result := s.db.Preload("Category").
-		Where("name ILIKE ? OR note ILIKE ?", searchTerm, searchTerm).
+		Joins("JOIN items_category c ON c.id = items.category_id").
+		Where("items.search_vector @@ "+tsQuery+" OR c.search_vector @@ "+tsQuery, query, query).
+		Order(clause.Expr{SQL: "ts_rank(items.search_vector || c.search_vector, " + tsQuery + ") DESC, items.id", Vars: []interface{}{query}}).
 		Find(&items)
 	return items, result.Error
 }
 
-// GetItemsByKondisi returns all items with a specific condition
-func (s *ItemService) GetItemsByKondisi(kondisi string) ([]models.Item, error) {
+// GetItemsByKondisi returns a page of items with a specific condition
+func (s *ItemService) GetItemsByKondisi(kondisi models.Kondisi, q models.ListQuery) ([]models.Item, *models.PageMeta, error) {
+	if !kondisi.IsValid() {
+		return nil, nil, fmt.Errorf("invalid kondisi %q", kondisi)
+	}
 	var items []models.Item
-	result := s.db.Preload("Category").Where("kondisi = ?", kondisi).Find(&items)
-	return items, result.Error
+	meta, err := listPage(s.db.Model(&models.Item{}).Where("kondisi = ?", kondisi), itemListSpec, q, &items, "Category")
+	return items, meta, err
 }
 
-// CreateItem creates a new item
-func (s *ItemService) CreateItem(item *models.Item) (*models.Item, error) {
+// CreateItem creates a new item and starts its kondisi history
+func (s *ItemService) CreateItem(item *models.Item, createdBy *uint) (*models.Item, error) {
 	if item.Name == "" {
 		return nil, errors.New("item name is required")
 	}
 	if item.CategoryID == 0 {
 		return nil, errors.New("category ID is required")
 	}
+	if item.Kondisi == "" {
+		item.Kondisi = models.KondisiBaik
+	}
+	if !item.Kondisi.IsValid() {
+		return nil, fmt.Errorf("invalid kondisi %q", item.Kondisi)
+	}
 
 	// Verify category exists
 	var category models.ItemCategory
@@ -160,9 +194,19 @@ func (s *ItemService) CreateItem(item *models.Item) (*models.Item, error) {
 		return nil, err
 	}
 
-	result := s.db.Create(item)
-	if result.Error != nil {
-		return nil, result.Error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.ItemKondisiHistory{
+			ItemID:    item.ID,
+			ToKondisi: item.Kondisi,
+			ChangedBy: createdBy,
+			Reason:    "Item registered",
+		}).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Reload with category data
@@ -170,20 +214,13 @@ func (s *ItemService) CreateItem(item *models.Item) (*models.Item, error) {
 	return item, nil
 }
 
-// UpdateItem updates an item
-func (s *ItemService) UpdateItem(id int, updates map[string]interface{}) (*models.Item, error) {
-	var item models.Item
-	if err := s.db.First(&item, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("item not found")
-		}
-		return nil, err
-	}
-
+// UpdateItem updates an item. A kondisi change goes through the lifecycle
+// rules and is recorded in the item history.
+func (s *ItemService) UpdateItem(id int, req models.UpdateItemRequest, changedBy *uint) (*models.Item, error) {
 	// If category_id is being updated, verify the new category exists
-	if newCategoryID, ok := updates["category_id"].(int); ok {
+	if req.CategoryID != nil {
 		var category models.ItemCategory
-		if err := s.db.First(&category, newCategoryID).Error; err != nil {
+		if err := s.db.First(&category, *req.CategoryID).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, errors.New("new category not found")
 			}
@@ -191,16 +228,87 @@ func (s *ItemService) UpdateItem(id int, updates map[string]interface{}) (*model
 		}
 	}
 
-	if len(updates) > 0 {
-		result := s.db.Model(&item).Updates(updates)
-		if result.Error != nil {
-			return nil, result.Error
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		if *req.Name == "" {
+			return nil, errors.New("item name is required")
 		}
+		updates["name"] = *req.Name
+	}
+	if req.Year != nil {
+		updates["year"] = *req.Year
+	}
+	if req.Note != nil {
+		updates["note"] = *req.Note
+	}
+	if req.CategoryID != nil {
+		updates["category_id"] = *req.CategoryID
 	}
 
-	// Reload with updated data
-	s.db.Preload("Category").First(&item, id)
-	return &item, nil
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		item, err := findItemForUpdate(tx, id)
+		if err != nil {
+			return err
+		}
+		if len(updates) > 0 {
+			if err := tx.Model(item).Updates(updates).Error; err != nil {
+				return err
+			}
+			if err := tx.First(item, id).Error; err != nil {
+				return err
+			}
+			changed := make([]string, 0, len(updates))
+			for column := range updates {
+				changed = append(changed, column)
+			}
+			sort.Strings(changed)
+			if err := enqueueEvent(tx, models.DomainItemUpdated, itemUpdatedEvent(item, changed)); err != nil {
+				return err
+			}
+		}
+		if req.Kondisi != nil && *req.Kondisi != item.Kondisi {
+			return changeItemKondisi(tx, item, *req.Kondisi, req.KondisiReason, changedBy, nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetItemByID(id)
+}
+
+// ChangeItemKondisi moves an item to another kondisi and records who did it and why
+func (s *ItemService) ChangeItemKondisi(id int, req models.ChangeKondisiRequest, changedBy *uint) (*models.Item, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		item, err := findItemForUpdate(tx, id)
+		if err != nil {
+			return err
+		}
+		if item.Kondisi == req.Kondisi {
+			return fmt.Errorf("item is already %s", item.Kondisi)
+		}
+		return changeItemKondisi(tx, item, req.Kondisi, req.Reason, changedBy, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetItemByID(id)
+}
+
+// GetItemKondisiHistory returns the kondisi timeline of an item, oldest first
+func (s *ItemService) GetItemKondisiHistory(id int) ([]models.ItemKondisiHistory, error) {
+	if _, err := s.GetItemByID(id); err != nil {
+		return nil, err
+	}
+
+	var history []models.ItemKondisiHistory
+	result := s.db.Preload("ChangedByUser").
+		Where("item_id = ?", id).
+		Order("created_at, id").
+		Find(&history)
+	return history, result.Error
 }
 
 // DeleteItem removes an item
@@ -244,5 +352,82 @@ func (s *ItemService) GetItemStatistics() (map[string]interface{}, error) {
 
 	stats["items_per_category"] = categoryStats
 
+	// Get items per kondisi
+	var kondisiStats []struct {
+		Kondisi   models.Kondisi `json:"kondisi"`
+		ItemCount int64          `json:"item_count"`
+	}
+
+	s.db.Model(&models.Item{}).
+		Select("kondisi, COUNT(id) as item_count").
+		Group("kondisi").
+		Scan(&kondisiStats)
+
+	stats["items_per_kondisi"] = kondisiStats
+
 	return stats, nil
 }
+
+func findItemForUpdate(tx *gorm.DB, id int) (*models.Item, error) {
+	var item models.Item
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("item not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// changeItemKondisi moves a locked item to a new kondisi and appends the
+// change to its history. Setting the current kondisi again is a no-op.
+func changeItemKondisi(tx *gorm.DB, item *models.Item, to models.Kondisi, reason string, changedBy *uint, loanID *int) error {
+	if !to.IsValid() {
+		return fmt.Errorf("invalid kondisi %q", to)
+	}
+	if item.Kondisi == to {
+		return nil
+	}
+	if !item.Kondisi.CanTransitionTo(to) {
+		return fmt.Errorf("kondisi cannot change from %s to %s", item.Kondisi, to)
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("a reason is required to change kondisi")
+	}
+
+	from := item.Kondisi
+	if err := tx.Model(item).Update("kondisi", to).Error; err != nil {
+		return err
+	}
+	item.Kondisi = to
+
+	err := tx.Create(&models.ItemKondisiHistory{
+		ItemID:      item.ID,
+		FromKondisi: &from,
+		ToKondisi:   to,
+		ChangedBy:   changedBy,
+		Reason:      reason,
+		LoanID:      loanID,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	data := itemUpdatedEvent(item, []string{"kondisi"})
+	data["fromKondisi"] = from
+	data["reason"] = reason
+	return enqueueEvent(tx, models.DomainItemUpdated, data)
+}
+
+// itemUpdatedEvent describes an item after an update; changed lists the updated columns
+func itemUpdatedEvent(item *models.Item, changed []string) map[string]interface{} {
+	return map[string]interface{}{
+		"itemId":     item.ID,
+		"name":       item.Name,
+		"categoryId": item.CategoryID,
+		"kondisi":    item.Kondisi,
+		"changed":    changed,
+	}
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"ketukApps/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Page sizes of list endpoints. A request without page, limit or cursor is
+// not paged, as list endpoints returned every row before paging existed.
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// ErrInvalidListQuery wraps every error caused by bad pagination, sort or filter parameters
+var ErrInvalidListQuery = errors.New("invalid list query")
+
+// listFilter maps a query parameter to a column. parse validates and converts
+// a single value; comma separated values match any of them.
+type listFilter struct {
+	column string
+	parse  func(string) (interface{}, error)
+}
+
+// listSpec describes the filters and sort keys a list endpoint accepts.
+// Sort keys use the JSON field names, prefixed with - for descending order.
+// idColumn and idField name the primary key column and struct field used for
+// the tie breaker and cursors; they default to id and ID.
+type listSpec struct {
+	filters     map[string]listFilter
+	sorts       map[string]string
+	defaultSort string
+	dateColumn  string
+	idColumn    string
+	idField     string
+}
+
+func (spec listSpec) primaryColumn() string {
+	if spec.idColumn == "" {
+		return "id"
+	}
+	return spec.idColumn
+}
+
+func (spec listSpec) primaryField() string {
+	if spec.idField == "" {
+		return "ID"
+	}
+	return spec.idField
+}
+
+func textFilter(column string) listFilter {
+	return listFilter{column: column, parse: func(value string) (interface{}, error) {
+		return value, nil
+	}}
+}
+
+func intFilter(column string) listFilter {
+	return listFilter{column: column, parse: func(value string) (interface{}, error) {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("must be an integer")
+		}
+		return n, nil
+	}}
+}
+
+func boolFilter(column string) listFilter {
+	return listFilter{column: column, parse: func(value string) (interface{}, error) {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return b, nil
+	}}
+}
+
+func enumFilter[T ~string](column string, allowed ...T) listFilter {
+	return listFilter{column: column, parse: func(value string) (interface{}, error) {
+		for _, a := range allowed {
+			if string(a) == value {
+				return value, nil
+			}
+		}
+		names := make([]string, len(allowed))
+		for i, a := range allowed {
+			names[i] = string(a)
+		}
+		return nil, fmt.Errorf("must be one of %s", strings.Join(names, ", "))
+	}}
+}
+
+// listPage applies the filters, sorting and pagination of q to base and loads
+// the page into dest, which must point to a slice of models with the
+// spec's ID field.
+// base must not have preloads yet so the total can be counted cheaply.
+func listPage(base *gorm.DB, spec listSpec, q models.ListQuery, dest interface{}, preloads ...string) (*models.PageMeta, error) {
+	query, err := spec.filter(base, q)
+	if err != nil {
+		return nil, err
+	}
+	order, byID, err := spec.order(q.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Page == 0 && q.Limit == 0 && q.Cursor == "" {
+		for _, preload := range preloads {
+			query = query.Preload(preload)
+		}
+		if err := query.Order(order).Find(dest).Error; err != nil {
+			return nil, err
+		}
+		count := reflect.ValueOf(dest).Elem().Len()
+		return &models.PageMeta{Limit: count, Total: int64(count)}, nil
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+	meta := &models.PageMeta{Limit: limit}
+
+	if err := query.Session(&gorm.Session{}).Count(&meta.Total).Error; err != nil {
+		return nil, err
+	}
+
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+	query = query.Order(order)
+
+	if q.Cursor != "" {
+		if byID == "" {
+			return nil, fmt.Errorf("%w: cursor pagination requires sort=id or sort=-id", ErrInvalidListQuery)
+		}
+		lastID, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		op := ">"
+		if byID == "DESC" {
+			op = "<"
+		}
+		query = query.Where(spec.primaryColumn()+" "+op+" ?", lastID)
+	} else {
+		page := q.Page
+		if page <= 0 {
+			page = 1
+		}
+		meta.Page = page
+		meta.TotalPages = int((meta.Total + int64(limit) - 1) / int64(limit))
+		query = query.Offset((page - 1) * limit)
+	}
+
+	// Fetch one extra row to know whether another page follows
+	if err := query.Limit(limit + 1).Find(dest).Error; err != nil {
+		return nil, err
+	}
+	rows := reflect.ValueOf(dest).Elem()
+	if rows.Len() > limit {
+		meta.HasMore = true
+		rows.Set(rows.Slice(0, limit))
+		if byID != "" {
+			meta.NextCursor = encodeCursor(rows.Index(limit - 1).FieldByName(spec.primaryField()).Interface())
+		}
+	}
+	return meta, nil
+}
+
+// filter applies the supported filters and the from/to date range. A filter
+// the endpoint does not support is rejected rather than ignored, so a typo
+// does not return the unfiltered list.
+func (spec listSpec) filter(query *gorm.DB, q models.ListQuery) (*gorm.DB, error) {
+	for name := range q.Filters {
+		if _, ok := spec.filters[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown filter %q", ErrInvalidListQuery, name)
+		}
+	}
+	for name, f := range spec.filters {
+		raw := strings.TrimSpace(q.Filters[name])
+		if raw == "" {
+			continue
+		}
+		var values []interface{}
+		for _, part := range strings.Split(raw, ",") {
+			value, err := f.parse(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s %s", ErrInvalidListQuery, name, err)
+			}
+			values = append(values, value)
+		}
+		if len(values) == 1 {
+			query = query.Where(f.column+" = ?", values[0])
+		} else {
+			query = query.Where(f.column+" IN ?", values)
+		}
+	}
+
+	if spec.dateColumn != "" {
+		if q.From != nil {
+			query = query.Where(spec.dateColumn+" >= ?", *q.From)
+		}
+		if q.To != nil {
+			query = query.Where(spec.dateColumn+" < ?", *q.To)
+		}
+	}
+	return query, nil
+}
+
+// order turns a sort parameter such as "-createdAt,title" into an ORDER BY
+// clause. The primary key is always appended as a tie breaker so pages are
+// stable. byID is the direction when the list is sorted by id alone, which
+// cursors require.
+func (spec listSpec) order(sort string) (string, string, error) {
+	if sort == "" {
+		sort = spec.defaultSort
+	}
+
+	var clauses []string
+	hasID := false
+	byID := ""
+	for i, key := range strings.Split(sort, ",") {
+		key = strings.TrimSpace(key)
+		direction := "ASC"
+		if strings.HasPrefix(key, "-") {
+			direction = "DESC"
+			key = key[1:]
+		}
+		column, ok := spec.sorts[key]
+		if key == "id" {
+			column, ok = spec.primaryColumn(), true
+			hasID = true
+			if i == 0 {
+				byID = direction
+			}
+		}
+		if !ok {
+			return "", "", fmt.Errorf("%w: cannot sort by %q", ErrInvalidListQuery, key)
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+	if len(clauses) > 1 {
+		byID = ""
+	}
+	if !hasID {
+		clauses = append(clauses, spec.primaryColumn()+" ASC")
+	}
+	return strings.Join(clauses, ", "), byID, nil
+}
+
+func encodeCursor(id interface{}) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprint(id)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid cursor", ErrInvalidListQuery)
+	}
+	id, err := strconv.Atoi(string(raw))
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid cursor", ErrInvalidListQuery)
+	}
+	return id, nil
+}
@@ -0,0 +1,456 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoanConflictError is returned when a requested item is already lent in an overlapping window
+type LoanConflictError struct {
+	Conflicts []models.LoanConflict
+}
+
+func (e *LoanConflictError) Error() string {
+	return fmt.Sprintf("items are not available: %d conflicting loan(s)", len(e.Conflicts))
+}
+
+type LoanService struct {
+	db            *gorm.DB
+	ticketService *TicketService
+}
+
+func NewLoanService(db *gorm.DB, ticketService *TicketService) *LoanService {
+	return &LoanService{
+		db:            db,
+		ticketService: ticketService,
+	}
+}
+
+// loanPreloads are the associations returned with every loan
+var loanPreloads = []string{"User", "Items.Item"}
+
+func (s *LoanService) preloaded() *gorm.DB {
+	query := s.db
+	for _, preload := range loanPreloads {
+		query = query.Preload(preload)
+	}
+	return query
+}
+
+// loanListSpec lists the filters and sort keys of loan lists
+var loanListSpec = listSpec{
+	filters: map[string]listFilter{
+		"status": enumFilter("status", models.LoanRequested, models.LoanApproved, models.LoanRejected,
+			models.LoanCheckedOut, models.LoanReturned),
+		"overdue": boolFilter("is_overdue"),
+		"userId":  intFilter("user_id"),
+	},
+	sorts: map[string]string{
+		"startDate": "start_date",
+		"endDate":   "end_date",
+		"createdAt": "created_at",
+		"status":    "status",
+	},
+	defaultSort: "-startDate",
+	dateColumn:  "start_date",
+}
+
+// GetAll returns a page of loans, latest start first by default
+func (s *LoanService) GetAll(q models.ListQuery) ([]models.ItemLoan, *models.PageMeta, error) {
+	var loans []models.ItemLoan
+	meta, err := listPage(s.db.Model(&models.ItemLoan{}), loanListSpec, q, &loans, loanPreloads...)
+	return loans, meta, err
+}
+
+// GetByID returns a loan by its ID
+func (s *LoanService) GetByID(id int) (*models.ItemLoan, error) {
+	var loan models.ItemLoan
+	result := s.preloaded().First(&loan, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, errors.New("loan not found")
+	}
+	return &loan, result.Error
+}
+
+// GetByUserID returns a page of loans requested by a user
+func (s *LoanService) GetByUserID(userID uint, q models.ListQuery) ([]models.ItemLoan, *models.PageMeta, error) {
+	var loans []models.ItemLoan
+	meta, err := listPage(s.db.Model(&models.ItemLoan{}).Where("user_id = ?", userID), loanListSpec, q, &loans, loanPreloads...)
+	return loans, meta, err
+}
+
+// CheckAvailability lists loans that would prevent lending the items in [startDate, endDate)
+func (s *LoanService) CheckAvailability(itemIDs []int, startDate, endDate time.Time) ([]models.LoanConflict, error) {
+	if err := validateScheduleRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	return findLoanConflicts(s.db, uniqueItemIDs(itemIDs), startDate, endDate, 0)
+}
+
+// Create files a loan request and the ticket used to approve it
+func (s *LoanService) Create(userID uint, req models.CreateLoanRequest) (*models.ItemLoan, error) {
+	if err := validateScheduleRange(req.StartDate, req.EndDate); err != nil {
+		return nil, err
+	}
+	purpose := strings.TrimSpace(req.Purpose)
+	if purpose == "" {
+		return nil, errors.New("purpose is required")
+	}
+	itemIDs := uniqueItemIDs(req.ItemIDs)
+
+	var ticket models.Ticket
+	var loan models.ItemLoan
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var items []models.Item
+		if err := tx.Where("id IN ?", itemIDs).Order("name").Find(&items).Error; err != nil {
+			return err
+		}
+		if len(items) != len(itemIDs) {
+			return errors.New("item not found")
+		}
+		for _, item := range items {
+			if !isLoanable(item.Kondisi) {
+				return fmt.Errorf("item %s cannot be lent while %s", item.Name, item.Kondisi)
+			}
+		}
+
+		// Pending requests may overlap; only approved or lent items are unavailable
+		conflicts, err := findLoanConflicts(tx, itemIDs, req.StartDate, req.EndDate, 0)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &LoanConflictError{Conflicts: conflicts}
+		}
+
+		ticket = models.Ticket{
+			UserID:      userID,
+			Title:       loanTicketTitle(items),
+			Description: loanTicketDescription(purpose, items, req.StartDate, req.EndDate),
+			Status:      models.StatusPending,
+			Kategori:    models.Lainnya,
+		}
+		if err := tx.Omit("User", "Room").Create(&ticket).Error; err != nil {
+			return err
+		}
+		if err := enqueueEvent(tx, models.DomainTicketCreated, ticketCreatedEvent(&ticket)); err != nil {
+			return err
+		}
+
+		loan = models.ItemLoan{
+			TicketID:  ticket.ID,
+			UserID:    userID,
+			StartDate: req.StartDate,
+			EndDate:   req.EndDate,
+			Purpose:   purpose,
+			Status:    models.LoanRequested,
+		}
+		for _, id := range itemIDs {
+			loan.Items = append(loan.Items, models.ItemLoanItem{ItemID: id})
+		}
+		return tx.Create(&loan).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Log audit trail for the loan ticket
+	userIDInt := int(userID)
+	s.ticketService.auditService.LogTicketEvent(
+		int(ticket.ID),
+		&userIDInt,
+		models.EventCreated,
+		nil,
+		ticket,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	return s.GetByID(loan.ID)
+}
+
+// Approve accepts the loan's ticket, which reserves the items and notifies the borrower
+func (s *LoanService) Approve(id int, reason string, adminUser *models.User) (*models.ItemLoan, error) {
+	return s.decide(id, string(models.StatusAccepted), reason, adminUser)
+}
+
+// Reject rejects the loan's ticket and notifies the borrower
+func (s *LoanService) Reject(id int, reason string, adminUser *models.User) (*models.ItemLoan, error) {
+	return s.decide(id, string(models.StatusRejected), reason, adminUser)
+}
+
+func (s *LoanService) decide(id int, status, reason string, adminUser *models.User) (*models.ItemLoan, error) {
+	loan, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.ticketService.UpdateStatusWithAdmin(loan.TicketID, status, reason, adminUser); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// CheckOut hands the items of an approved loan to the borrower
+func (s *LoanService) CheckOut(id int, adminUser *models.User) (*models.ItemLoan, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		loan, err := findLoanForUpdate(tx, id)
+		if err != nil {
+			return err
+		}
+		if loan.Status != models.LoanApproved {
+			return fmt.Errorf("only approved loans can be checked out, loan is %s", loan.Status)
+		}
+
+		// An earlier borrower may still hold an item past their end date
+		itemIDs := loanItemIDs(loan)
+		if err := lockLoanItems(tx, itemIDs); err != nil {
+			return err
+		}
+		conflicts, err := findLoanConflicts(tx, itemIDs, loan.StartDate, loan.EndDate, loan.ID)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &LoanConflictError{Conflicts: conflicts}
+		}
+
+		updates := map[string]interface{}{
+			"status":         models.LoanCheckedOut,
+			"checked_out_at": time.Now(),
+		}
+		if adminUser != nil {
+			updates["checked_out_by"] = adminUser.ID
+		}
+		return tx.Model(loan).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// CheckIn records the return of a checked out loan and the condition of its items
+func (s *LoanService) CheckIn(id int, req models.CheckInLoanRequest, adminUser *models.User) (*models.ItemLoan, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, errors.New("condition note is required")
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		loan, err := findLoanForUpdate(tx, id)
+		if err != nil {
+			return err
+		}
+		if loan.Status != models.LoanCheckedOut {
+			return fmt.Errorf("only checked out loans can be checked in, loan is %s", loan.Status)
+		}
+
+		lent := make(map[int]bool, len(loan.Items))
+		for _, item := range loan.Items {
+			lent[item.ItemID] = true
+		}
+		for _, ret := range req.Items {
+			if !lent[ret.ItemID] {
+				return fmt.Errorf("item %d is not part of this loan", ret.ItemID)
+			}
+			if err := tx.Model(&models.ItemLoanItem{}).
+				Where("loan_id = ? AND item_id = ?", loan.ID, ret.ItemID).
+				Updates(map[string]interface{}{
+					"return_kondisi": ret.Kondisi,
+					"return_note":    ret.Note,
+				}).Error; err != nil {
+				return err
+			}
+			item, err := findItemForUpdate(tx, ret.ItemID)
+			if err != nil {
+				return err
+			}
+			reason := ret.Note
+			if reason == "" {
+				reason = note
+			}
+			if err := changeItemKondisi(tx, item, ret.Kondisi, "Returned from loan: "+reason, userIDOf(adminUser), &loan.ID); err != nil {
+				return fmt.Errorf("item %s: %w", item.Name, err)
+			}
+		}
+
+		// is_overdue is kept so late returns stay visible
+		updates := map[string]interface{}{
+			"status":      models.LoanReturned,
+			"returned_at": time.Now(),
+			"return_note": note,
+		}
+		if adminUser != nil {
+			updates["returned_by"] = adminUser.ID
+		}
+		return tx.Model(loan).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+// syncLoanWithTicket mirrors a ticket status change onto the loan it belongs to.
+// Accepting the ticket approves the loan, so the items must still be free.
+func syncLoanWithTicket(tx *gorm.DB, ticketID uint, status string) error {
+	var loan models.ItemLoan
+	err := tx.Preload("Items").Where("ticket_id = ?", ticketID).First(&loan).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if loan.Status == models.LoanCheckedOut || loan.Status == models.LoanReturned {
+		return fmt.Errorf("loan is already %s", strings.ReplaceAll(string(loan.Status), "_", " "))
+	}
+
+	next := models.LoanRequested
+	switch models.TicketStatus(status) {
+	case models.StatusAccepted:
+		next = models.LoanApproved
+	case models.StatusRejected:
+		next = models.LoanRejected
+	}
+
+	if next == models.LoanApproved {
+		itemIDs := loanItemIDs(&loan)
+		if err := lockLoanItems(tx, itemIDs); err != nil {
+			return err
+		}
+		conflicts, err := findLoanConflicts(tx, itemIDs, loan.StartDate, loan.EndDate, loan.ID)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &LoanConflictError{Conflicts: conflicts}
+		}
+	}
+
+	return tx.Model(&loan).Update("status", next).Error
+}
+
+// findLoanConflicts returns approved or checked out loans holding any of the
+// items during [startDate, endDate). A checked out loan past its end date
+// keeps holding its items until they are checked in.
+func findLoanConflicts(db *gorm.DB, itemIDs []int, startDate, endDate time.Time, excludeLoanID int) ([]models.LoanConflict, error) {
+	conflicts := []models.LoanConflict{}
+	if len(itemIDs) == 0 {
+		return conflicts, nil
+	}
+
+	query := db.Table("item_loan_items AS li").
+		Select("l.id AS loan_id, li.item_id, i.name AS item_name, l.status, l.start_date, l.end_date").
+		Joins("JOIN item_loans l ON l.id = li.loan_id").
+		Joins("JOIN items i ON i.id = li.item_id").
+		Where("li.item_id IN ?", itemIDs).
+		Where("l.status IN ?", []models.LoanStatus{models.LoanApproved, models.LoanCheckedOut}).
+		Where("l.start_date < ? AND (l.end_date > ? OR (l.status = ? AND l.end_date <= ?))",
+			endDate, startDate, models.LoanCheckedOut, utils.LabWallClock(time.Now()))
+	if excludeLoanID != 0 {
+		query = query.Where("l.id <> ?", excludeLoanID)
+	}
+	if err := query.Order("l.start_date, li.item_id").Scan(&conflicts).Error; err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// lockLoanItems takes row locks on the items so concurrent approvals of the same item serialise
+func lockLoanItems(tx *gorm.DB, itemIDs []int) error {
+	var items []models.Item
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", itemIDs).Order("id").Find(&items).Error; err != nil {
+		return err
+	}
+	if len(items) != len(itemIDs) {
+		return errors.New("item not found")
+	}
+	return nil
+}
+
+func findLoanForUpdate(tx *gorm.DB, id int) (*models.ItemLoan, error) {
+	var loan models.ItemLoan
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&loan, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("loan not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Where("loan_id = ?", loan.ID).Find(&loan.Items).Error; err != nil {
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// isLoanable reports whether an item in this kondisi may be lent out
+func isLoanable(kondisi models.Kondisi) bool {
+	return kondisi == models.KondisiBaik || kondisi == models.KondisiRusakRingan
+}
+
+func userIDOf(user *models.User) *uint {
+	if user == nil {
+		return nil
+	}
+	return &user.ID
+}
+
+func loanItemIDs(loan *models.ItemLoan) []int {
+	ids := make([]int, 0, len(loan.Items))
+	for _, item := range loan.Items {
+		ids = append(ids, item.ItemID)
+	}
+	return uniqueItemIDs(ids)
+}
+
+func uniqueItemIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Ints(unique)
+	return unique
+}
+
+// loanTicketTitle fits the item names into the 100 character ticket title
+func loanTicketTitle(items []models.Item) string {
+	const maxTitle = 100
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	title := "Equipment loan: " + strings.Join(names, ", ")
+	if len([]rune(title)) > maxTitle {
+		title = string([]rune(title)[:maxTitle-3]) + "..."
+	}
+	return title
+}
+
+func loanTicketDescription(purpose string, items []models.Item, startDate, endDate time.Time) string {
+	lines := []string{
+		purpose,
+		"",
+		fmt.Sprintf("Loan period: %s - %s", startDate.Format("02 Jan 2006 15:04"), endDate.Format("02 Jan 2006 15:04")),
+		"Items:",
+	}
+	for _, item := range items {
+		lines = append(lines, fmt.Sprintf("- %s (ID %d)", item.Name, item.ID))
+	}
+	return strings.Join(lines, "\n")
+}
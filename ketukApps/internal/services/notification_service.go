@@ -0,0 +1,169 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/notify"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationService struct {
+	db *gorm.DB
+}
+
+func NewNotificationService(db *gorm.DB) *NotificationService {
+	return &NotificationService{
+		db: db,
+	}
+}
+
+// notificationListSpec lists the filters and sort keys of in-app notification lists
+var notificationListSpec = listSpec{
+	filters: map[string]listFilter{
+		"event":  textFilter("event"),
+		"unread": boolFilter("(read_at IS NULL)"),
+	},
+	sorts: map[string]string{
+		"createdAt": "created_at",
+	},
+	defaultSort: "-id",
+	dateColumn:  "created_at",
+}
+
+// GetByUserID returns a page of a user's in-app notifications, newest first by default
+func (s *NotificationService) GetByUserID(userID uint, q models.ListQuery) ([]models.Notification, *models.PageMeta, error) {
+	var notifications []models.Notification
+	meta, err := listPage(s.db.Model(&models.Notification{}).Where("user_id = ?", userID), notificationListSpec, q, &notifications)
+	return notifications, meta, err
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationService) MarkRead(id int, userID uint) (*models.Notification, error) {
+	var notification models.Notification
+	if err := s.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("notification not found")
+		}
+		return nil, err
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		if err := s.db.Model(&notification).Update("read_at", now).Error; err != nil {
+			return nil, err
+		}
+		notification.ReadAt = &now
+	}
+	return &notification, nil
+}
+
+// MarkAllRead marks every unread notification of the user as read and returns how many changed
+func (s *NotificationService) MarkAllRead(userID uint) (int64, error) {
+	result := s.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+// GetPreferences returns whether the user receives each routed event on each
+// of its channels, filling in the enabled default for missing rows
+func (s *NotificationService) GetPreferences(userID uint) ([]models.NotificationPreference, error) {
+	var stored []models.NotificationPreference
+	if err := s.db.Where("user_id = ?", userID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]models.NotificationPreference, len(stored))
+	for _, pref := range stored {
+		byKey[string(pref.Event)+"/"+string(pref.Channel)] = pref
+	}
+
+	events := []models.NotificationEvent{models.NotifyTicketCreated, models.NotifyTicketStatusChanged}
+	var prefs []models.NotificationPreference
+	for _, event := range events {
+		for _, channel := range notify.DefaultRoutes[event] {
+			pref, ok := byKey[string(event)+"/"+string(channel)]
+			if !ok {
+				pref = models.NotificationPreference{UserID: userID, Event: event, Channel: channel, Enabled: true}
+			}
+			prefs = append(prefs, pref)
+		}
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences stores the given preferences and returns the full set
+func (s *NotificationService) UpdatePreferences(userID uint, prefs []models.NotificationPreference) ([]models.NotificationPreference, error) {
+	for i := range prefs {
+		if !isRoutedChannel(prefs[i].Event, prefs[i].Channel) {
+			return nil, fmt.Errorf("invalid preference: %s is not sent on %s", prefs[i].Event, prefs[i].Channel)
+		}
+		prefs[i].UserID = userID
+	}
+
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&prefs).Error
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(userID)
+}
+
+// NotifyBookingClosed tells a user that their booking request was turned down
+// because booking is closed. nextOpen is when the next window opens, if any.
+func (s *NotificationService) NotifyBookingClosed(userID uint, title string, startDate, endDate time.Time, nextOpen *time.Time) error {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	data := map[string]interface{}{
+		"title":     title,
+		"startDate": startDate,
+		"endDate":   endDate,
+	}
+	if nextOpen != nil {
+		data["nextOpen"] = *nextOpen
+	}
+	return enqueueNotification(s.db, notify.Notification{
+		Event:  models.NotifyBookingClosed,
+		UserID: user.ID,
+		Email:  user.Email,
+		Name:   user.Name,
+		Locale: user.Locale,
+		Data:   data,
+	})
+}
+
+// PreviewTemplate renders the email of an event with sample data
+func (s *NotificationService) PreviewTemplate(event models.NotificationEvent, locale string) (*notify.Email, error) {
+	data, ok := notify.SampleData(event)
+	if !ok {
+		return nil, errors.New("notification event not found")
+	}
+	return notify.Render(notify.Notification{
+		Event:  event,
+		Name:   "Budi Santoso",
+		Email:  "budi@example.com",
+		Locale: locale,
+		Data:   data,
+	})
+}
+
+func isRoutedChannel(event models.NotificationEvent, channel models.NotificationChannel) bool {
+	for _, routed := range notify.DefaultRoutes[event] {
+		if routed == channel {
+			return true
+		}
+	}
+	return false
+}
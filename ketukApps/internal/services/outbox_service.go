@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/notify"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Outbox relay settings. A message is retried with exponential backoff and
+// given up on after outboxMaxAttempts. A claimed message is not due again for
+// outboxClaimTimeout, so a relay that dies while delivering it only delays it.
+const (
+	outboxBatchSize    = 50
+	outboxMaxAttempts  = 8
+	outboxBaseBackoff  = 30 * time.Second
+	outboxMaxBackoff   = time.Hour
+	outboxClaimTimeout = 10 * time.Minute
+)
+
+// enqueueNotification writes one outbox message per channel the event is
+// routed to, so a channel that fails is retried without resending the others
+func enqueueNotification(tx *gorm.DB, n notify.Notification) error {
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	for _, channel := range notify.DefaultRoutes[n.Event] {
+		message := models.OutboxMessage{
+			Kind:          models.OutboxNotification,
+			Destination:   string(channel),
+			Payload:       string(payload),
+			Status:        models.OutboxPending,
+			NextAttemptAt: n.CreatedAt,
+		}
+		if err := tx.Create(&message).Error; err != nil {
+			return fmt.Errorf("failed to write outbox message: %w", err)
+		}
+	}
+	return nil
+}
+
+type OutboxService struct {
+	db       *gorm.DB
+	notifier *notify.Dispatcher
+	events   EventPublisher
+	bookings BookingPublisher
+}
+
+func NewOutboxService(db *gorm.DB, notifier *notify.Dispatcher, events EventPublisher, bookings BookingPublisher) *OutboxService {
+	return &OutboxService{
+		db:       db,
+		notifier: notifier,
+		events:   events,
+		bookings: bookings,
+	}
+}
+
+// RelayPending delivers the outbox messages that are due and returns how many
+// were delivered. Messages are claimed first, so several relays can run side
+// by side without sending a message twice, and no transaction stays open
+// while they are delivered. Each result is recorded on its own.
+func (s *OutboxService) RelayPending(ctx context.Context) (int, error) {
+	messages, err := s.claimPending(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, message := range messages {
+		updates := map[string]interface{}{}
+		if err := s.deliver(ctx, message); err != nil {
+			errMsg := err.Error()
+			updates["last_error"] = errMsg
+			if message.Attempts >= outboxMaxAttempts {
+				updates["status"] = models.OutboxFailed
+				log.Printf("Giving up on outbox message #%d (%s to %s) after %d attempts: %s",
+					message.ID, message.Kind, message.Destination, message.Attempts, errMsg)
+			} else {
+				updates["next_attempt_at"] = time.Now().Add(outboxBackoff(message.Attempts))
+				log.Printf("Failed to deliver outbox message #%d (%s to %s): %s",
+					message.ID, message.Kind, message.Destination, errMsg)
+			}
+		} else {
+			updates["status"] = models.OutboxDelivered
+			updates["delivered_at"] = time.Now()
+			delivered++
+		}
+
+		if err := s.db.WithContext(ctx).Model(&message).Updates(updates).Error; err != nil {
+			log.Printf("Failed to record the delivery of outbox message #%d: %s", message.ID, err)
+		}
+	}
+	return delivered, nil
+}
+
+// claimPending counts an attempt for the messages that are due and moves
+// their next attempt past outboxClaimTimeout in a single statement. Rows other
+// relays are claiming are skipped. The messages are returned as updated.
+func (s *OutboxService) claimPending(ctx context.Context) ([]models.OutboxMessage, error) {
+	due := s.db.Model(&models.OutboxMessage{}).
+		Select("id").
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", models.OutboxPending, time.Now()).
+		Order("id").
+		Limit(outboxBatchSize)
+
+	var messages []models.OutboxMessage
+	err := s.db.WithContext(ctx).Model(&messages).
+		Clauses(clause.Returning{}).
+		Where("id IN (?)", due).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": time.Now().Add(outboxClaimTimeout),
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, nil
+}
+
+// deliver hands a message to its destination
+func (s *OutboxService) deliver(ctx context.Context, message models.OutboxMessage) error {
+	switch message.Kind {
+	case models.OutboxNotification:
+		if s.notifier == nil {
+			return fmt.Errorf("no notifier configured")
+		}
+		var n notify.Notification
+		if err := json.Unmarshal([]byte(message.Payload), &n); err != nil {
+			return fmt.Errorf("invalid notification payload: %w", err)
+		}
+		return s.notifier.Deliver(ctx, models.NotificationChannel(message.Destination), n)
+	case models.OutboxEvent:
+		if s.events == nil {
+			return fmt.Errorf("no event publisher configured")
+		}
+		var event struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+			return fmt.Errorf("invalid event payload: %w", err)
+		}
+		return s.events.PublishEvent(ctx, message.Destination, event.ID, []byte(message.Payload))
+	case models.OutboxBooking:
+		if s.bookings == nil {
+			return fmt.Errorf("no booking publisher configured")
+		}
+		return s.publishBooking(ctx, message.Destination)
+	}
+	return fmt.Errorf("unknown outbox message kind %q", message.Kind)
+}
+
+// publishBooking publishes a booking again and puts it back in the queued
+// state. Bookings the worker has reported on meanwhile are left alone.
+func (s *OutboxService) publishBooking(ctx context.Context, id string) error {
+	var booking models.BookingRequest
+	if err := s.db.WithContext(ctx).First(&booking, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if booking.Status != models.BookingUnpublished {
+		return nil
+	}
+
+	if err := s.bookings.Publish(ctx, &booking); err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Model(&models.BookingRequest{}).
+		Where("id = ? AND status = ?", id, models.BookingUnpublished).
+		Update("status", models.BookingQueued).Error
+}
+
+// outboxBackoff doubles the delay after every failed attempt, up to outboxMaxBackoff
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxBaseBackoff
+	for i := 1; i < attempts && delay < outboxMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > outboxMaxBackoff {
+		delay = outboxMaxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ketukApps/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// pgForeignKeyViolation is the SQLSTATE raised when a referenced row is deleted
+const pgForeignKeyViolation = "23503"
+
+type RoomService struct {
+	db *gorm.DB
+}
+
+func NewRoomService(db *gorm.DB) *RoomService {
+	return &RoomService{
+		db: db,
+	}
+}
+
+// GetAll returns all rooms, optionally only the active ones
+func (s *RoomService) GetAll(activeOnly bool) ([]models.Room, error) {
+	var rooms []models.Room
+	query := s.db.Order("name")
+	if activeOnly {
+		query = query.Where("is_active")
+	}
+	result := query.Find(&rooms)
+	return rooms, result.Error
+}
+
+// GetByID returns a room by its ID
+func (s *RoomService) GetByID(id int) (*models.Room, error) {
+	var room models.Room
+	result := s.db.First(&room, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, errors.New("room not found")
+	}
+	return &room, result.Error
+}
+
+// Create creates a new room
+func (s *RoomService) Create(req models.CreateRoomRequest) (*models.Room, error) {
+	room := models.Room{
+		Name:     strings.TrimSpace(req.Name),
+		Location: req.Location,
+		Capacity: req.Capacity,
+		OpensAt:  req.OpensAt,
+		ClosesAt: req.ClosesAt,
+		IsActive: true,
+	}
+	if req.IsActive != nil {
+		room.IsActive = *req.IsActive
+	}
+	if err := validateRoom(&room); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&room).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// Update updates a room
+func (s *RoomService) Update(id int, req models.UpdateRoomRequest) (*models.Room, error) {
+	room, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		room.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Location != nil {
+		room.Location = *req.Location
+	}
+	if req.Capacity != nil {
+		room.Capacity = *req.Capacity
+	}
+	// An empty string clears the opening hours
+	if req.OpensAt != nil {
+		room.OpensAt = emptyToNil(*req.OpensAt)
+	}
+	if req.ClosesAt != nil {
+		room.ClosesAt = emptyToNil(*req.ClosesAt)
+	}
+	if req.IsActive != nil {
+		room.IsActive = *req.IsActive
+	}
+	if err := validateRoom(room); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(room).Error; err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// Delete removes a room that was never used. Rooms with history should be deactivated instead.
+func (s *RoomService) Delete(id int) error {
+	result := s.db.Delete(&models.Room{}, id)
+	if result.Error != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(result.Error, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return errors.New("room is still referenced by schedules or tickets, deactivate it instead")
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("room not found")
+	}
+	return nil
+}
+
+func emptyToNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// validateRoom checks required fields and normalises opening hours to HH:MM
+func validateRoom(room *models.Room) error {
+	if room.Name == "" {
+		return errors.New("room name is required")
+	}
+	if room.Capacity < 0 {
+		return errors.New("capacity cannot be negative")
+	}
+	if (room.OpensAt == nil) != (room.ClosesAt == nil) {
+		return errors.New("opensAt and closesAt must be provided together")
+	}
+	if room.OpensAt == nil {
+		return nil
+	}
+
+	opens, err := parseClock(*room.OpensAt)
+	if err != nil {
+		return fmt.Errorf("invalid opensAt: %w", err)
+	}
+	closes, err := parseClock(*room.ClosesAt)
+	if err != nil {
+		return fmt.Errorf("invalid closesAt: %w", err)
+	}
+	if closes <= opens {
+		return errors.New("closesAt must be after opensAt")
+	}
+
+	opensAt, closesAt := formatClock(opens), formatClock(closes)
+	room.OpensAt, room.ClosesAt = &opensAt, &closesAt
+	return nil
+}
+
+// parseClock parses "HH:MM" or "HH:MM:SS" into the offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a HH:MM time", value)
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// resolveRoomID returns the room a schedule or ticket is booked in. When no
+// room is given and only one active room exists, that room is used so
+// single-lab deployments keep working without sending a roomId.
+func resolveRoomID(db *gorm.DB, roomID int) (int, error) {
+	if roomID == 0 {
+		var rooms []models.Room
+		if err := db.Where("is_active").Limit(2).Find(&rooms).Error; err != nil {
+			return 0, err
+		}
+		if len(rooms) != 1 {
+			return 0, errors.New("room ID is required")
+		}
+		return rooms[0].ID, nil
+	}
+
+	var room models.Room
+	if err := db.First(&room, roomID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errors.New("room not found")
+		}
+		return 0, err
+	}
+	if !room.IsActive {
+		return 0, fmt.Errorf("room %s is not active", room.Name)
+	}
+	return room.ID, nil
+}
+
+// checkRoomOpen verifies that [startDate, endDate) lies within the room's opening hours
+func checkRoomOpen(db *gorm.DB, roomID int, startDate, endDate time.Time) error {
+	var room models.Room
+	if err := db.First(&room, roomID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("room not found")
+		}
+		return err
+	}
+	if room.OpensAt == nil || room.ClosesAt == nil {
+		return nil
+	}
+
+	opens, err := parseClock(*room.OpensAt)
+	if err != nil {
+		return err
+	}
+	closes, err := parseClock(*room.ClosesAt)
+	if err != nil {
+		return err
+	}
+
+	dayStart := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	if startDate.Before(dayStart.Add(opens)) || endDate.After(dayStart.Add(closes)) {
+		return fmt.Errorf("room %s is only open from %s to %s", room.Name, formatClock(opens), formatClock(closes))
+	}
+	return nil
+}
+
+// scopeRoom limits a schedule or ticket query to one room; roomID 0 leaves it unfiltered
+func scopeRoom(query *gorm.DB, roomID int) *gorm.DB {
+	if roomID == 0 {
+		return query
+	}
+	return query.Where("room_id = ?", roomID)
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ketukApps/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// pgExclusionViolation is the SQLSTATE raised when an EXCLUDE constraint fails
+const pgExclusionViolation = "23P01"
+
+// ScheduleConflictError is returned when a requested slot overlaps existing schedules
+type ScheduleConflictError struct {
+	Conflicts []models.ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("schedule conflicts with %d existing schedule(s)", len(e.Conflicts))
+}
+
+// validateScheduleRange checks that a schedule ends after it starts
+func validateScheduleRange(startDate, endDate time.Time) error {
+	if startDate.IsZero() || endDate.IsZero() {
+		return errors.New("start date and end date are required")
+	}
+	if !endDate.After(startDate) {
+		return errors.New("end date must be after start date")
+	}
+	return nil
+}
+
+// findScheduleConflicts returns booked schedule tickets and regular schedule
+// occurrences in a room overlapping [startDate, endDate). excludeID skips the schedule ticket being edited.
+func findScheduleConflicts(db *gorm.DB, roomID int, startDate, endDate time.Time, excludeID int) ([]models.ScheduleConflict, error) {
+	conflicts := []models.ScheduleConflict{}
+
+	var tickets []models.ScheduleTicket
+	query := db.Where("room_id = ? AND is_booked AND start_date < ? AND end_date > ?", roomID, endDate, startDate)
+	if excludeID != 0 {
+		query = query.Where("id_schedule <> ?", excludeID)
+	}
+	if err := query.Order("start_date").Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+	for _, t := range tickets {
+		conflicts = append(conflicts, models.ScheduleConflict{
+			Source:     models.ScheduleSourceTicket,
+			IDSchedule: t.IDSchedule,
+			Title:      t.Title,
+			StartDate:  t.StartDate,
+			EndDate:    t.EndDate,
+			UserID:     t.UserID,
+			RoomID:     t.RoomID,
+		})
+	}
+
+	// Recurring regular schedules are expanded so each clashing occurrence is reported
+	occurrences, err := scheduleRegulerOccurrences(db, db.Where("room_id = ?", roomID), startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range occurrences {
+		conflicts = append(conflicts, models.ScheduleConflict{
+			Source:     models.ScheduleSourceReguler,
+			IDSchedule: o.IDSchedule,
+			Title:      o.Title,
+			StartDate:  o.StartDate,
+			EndDate:    o.EndDate,
+			UserID:     o.UserID,
+			RoomID:     o.RoomID,
+		})
+	}
+
+	return conflicts, nil
+}
+
+// checkScheduleConflicts returns a *ScheduleConflictError if the slot is taken in the room
+func checkScheduleConflicts(db *gorm.DB, roomID int, startDate, endDate time.Time, excludeID int) error {
+	conflicts, err := findScheduleConflicts(db, roomID, startDate, endDate, excludeID)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// isExclusionViolation reports whether err comes from an EXCLUDE constraint
+func isExclusionViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation
+}
+
+// conflictFromViolation converts an exclusion constraint failure into a
+// ScheduleConflictError listing whatever now occupies the slot
+func conflictFromViolation(db *gorm.DB, roomID int, startDate, endDate time.Time, excludeID int) error {
+	conflicts, err := findScheduleConflicts(db, roomID, startDate, endDate, excludeID)
+	if err != nil {
+		return err
+	}
+	return &ScheduleConflictError{Conflicts: conflicts}
+}
@@ -0,0 +1,480 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// errImportRejected rolls back an import that is a dry run or has invalid rows
+var errImportRejected = errors.New("schedule import rejected")
+
+// scheduleImportColumns are the CSV header names; the first four are required
+var scheduleImportColumns = []string{"title", "email", "start_date", "end_date", "rrule", "exdates", "tahun", "semester", "room"}
+
+// ScheduleImportDefaults apply to rows that don't set tahun/semester or a room themselves
+type ScheduleImportDefaults struct {
+	Tahun    *int
+	Semester *models.SemesterCategory
+	RoomID   int
+}
+
+// scheduleImportCandidate is a parsed row waiting for validation
+type scheduleImportCandidate struct {
+	report      *models.ScheduleImportRow
+	schedule    models.ScheduleReguler
+	occurrences []models.ScheduleRegulerOccurrence
+}
+
+func (c *scheduleImportCandidate) fail(format string, args ...interface{}) {
+	c.report.Errors = append(c.report.Errors, fmt.Sprintf(format, args...))
+}
+
+func (c *scheduleImportCandidate) valid() bool {
+	return len(c.report.Errors) == 0
+}
+
+// ImportScheduleReguler validates every row of a CSV or ICS timetable and, unless
+// dryRun is set, creates all of them in a single transaction. Nothing is written
+// when any row is invalid. The returned error is only set for unreadable files.
+func (s *ScheduleService) ImportScheduleReguler(r io.Reader, format models.ScheduleImportFormat, defaults ScheduleImportDefaults, dryRun bool) (*models.ScheduleImportReport, error) {
+	var candidates []*scheduleImportCandidate
+	var err error
+	switch format {
+	case models.ScheduleImportCSV:
+		candidates, err = parseScheduleImportCSV(r)
+	case models.ScheduleImportICS:
+		candidates, err = parseScheduleImportICS(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("import file contains no schedules")
+	}
+
+	for _, c := range candidates {
+		if c.schedule.Tahun == nil && c.schedule.Semester == nil {
+			c.schedule.Tahun = defaults.Tahun
+			c.schedule.Semester = defaults.Semester
+		}
+		if c.report.Room == "" {
+			c.schedule.RoomID = defaults.RoomID
+		}
+	}
+
+	report := &models.ScheduleImportReport{
+		Format:    format,
+		DryRun:    dryRun,
+		TotalRows: len(candidates),
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := validateScheduleImport(tx, candidates); err != nil {
+			return err
+		}
+		for _, c := range candidates {
+			if c.valid() {
+				report.ValidRows++
+			}
+		}
+		if dryRun || report.ValidRows != report.TotalRows {
+			return errImportRejected
+		}
+
+		for _, c := range candidates {
+			if err := tx.Omit("User", "Room").Create(&c.schedule).Error; err != nil {
+				return fmt.Errorf("row %d: %w", c.report.Row, err)
+			}
+			c.report.IDSchedule = c.schedule.IDSchedule
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRejected) {
+		return nil, err
+	}
+	report.Committed = err == nil
+
+	report.Rows = make([]models.ScheduleImportRow, 0, len(candidates))
+	for _, c := range candidates {
+		report.Rows = append(report.Rows, *c.report)
+	}
+	return report, nil
+}
+
+// validateScheduleImport checks each row on its own, then against existing
+// schedules and finally against the other rows in the file
+func validateScheduleImport(tx *gorm.DB, candidates []*scheduleImportCandidate) error {
+	users := make(map[string]*models.User)
+	semesters := newSemesterEnds(tx)
+	for _, c := range candidates {
+		if c.report.Room != "" {
+			var room models.Room
+			err := tx.Where("LOWER(name) = LOWER(?)", c.report.Room).First(&room).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err != nil {
+				c.fail("no room named %s", c.report.Room)
+			} else {
+				c.schedule.RoomID = room.ID
+			}
+		}
+		if c.report.Room == "" || c.schedule.RoomID != 0 {
+			roomID, err := resolveRoomID(tx, c.schedule.RoomID)
+			if err != nil {
+				c.fail("%s", err.Error())
+			}
+			c.schedule.RoomID = roomID
+		}
+
+		if c.report.Title == "" {
+			c.fail("title is required")
+		}
+
+		email := strings.ToLower(c.report.Email)
+		if email == "" {
+			c.fail("email is required")
+		} else if _, ok := users[email]; !ok {
+			var user models.User
+			err := tx.Where("LOWER(email) = ?", email).First(&user).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err == nil {
+				users[email] = &user
+			} else {
+				users[email] = nil
+			}
+		}
+		if user := users[email]; user != nil {
+			c.schedule.UserID = int(user.ID)
+		} else if email != "" {
+			c.fail("no user with email %s", c.report.Email)
+		}
+
+		if c.report.StartDate == nil || c.report.EndDate == nil {
+			continue
+		}
+		if err := validateScheduleRange(c.schedule.StartDate, c.schedule.EndDate); err != nil {
+			c.fail("%s", err.Error())
+			continue
+		}
+		if err := validateRecurrence(semesters, &c.schedule); err != nil {
+			c.fail("%s", err.Error())
+			continue
+		}
+		c.report.RRule = c.schedule.RRule
+		if c.schedule.RoomID == 0 {
+			continue
+		}
+		if err := checkRoomOpen(tx, c.schedule.RoomID, c.schedule.StartDate, c.schedule.EndDate); err != nil {
+			c.fail("%s", err.Error())
+			continue
+		}
+
+		occurrences, err := expandScheduleReguler(semesters, c.schedule, c.schedule.StartDate, c.schedule.StartDate.Add(maxOccurrenceWindow))
+		if err != nil {
+			c.fail("%s", err.Error())
+			continue
+		}
+		if len(occurrences) == 0 {
+			c.fail("recurrence produces no occurrences")
+			continue
+		}
+		c.occurrences = occurrences
+		c.report.Occurrences = len(occurrences)
+	}
+
+	existing := make(map[int][]models.ScheduleConflict)
+	for _, c := range candidates {
+		if _, ok := existing[c.schedule.RoomID]; ok || len(c.occurrences) == 0 {
+			continue
+		}
+		conflicts, err := importWindowConflicts(tx, c.schedule.RoomID, candidates)
+		if err != nil {
+			return err
+		}
+		existing[c.schedule.RoomID] = conflicts
+	}
+
+	for i, c := range candidates {
+		if len(c.occurrences) == 0 {
+			continue
+		}
+		for _, conflict := range existing[c.schedule.RoomID] {
+			if start, ok := firstOverlap(c.occurrences, conflict.StartDate, conflict.EndDate); ok {
+				c.fail("overlaps %s schedule #%d %q on %s", conflict.Source, conflict.IDSchedule, conflict.Title, start.Format("2006-01-02 15:04"))
+			}
+		}
+		for j, other := range candidates[:i] {
+			if other.schedule.RoomID != c.schedule.RoomID {
+				continue
+			}
+			for _, o := range other.occurrences {
+				if start, ok := firstOverlap(c.occurrences, o.StartDate, o.EndDate); ok {
+					c.fail("overlaps row %d %q on %s", candidates[j].report.Row, other.report.Title, start.Format("2006-01-02 15:04"))
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// importWindowConflicts loads every existing schedule in a room overlapping
+// the span of the occurrences imported into it, one year at a time
+func importWindowConflicts(tx *gorm.DB, roomID int, candidates []*scheduleImportCandidate) ([]models.ScheduleConflict, error) {
+	var from, to time.Time
+	for _, c := range candidates {
+		if c.schedule.RoomID != roomID {
+			continue
+		}
+		for _, o := range c.occurrences {
+			if from.IsZero() || o.StartDate.Before(from) {
+				from = o.StartDate
+			}
+			if o.EndDate.After(to) {
+				to = o.EndDate
+			}
+		}
+	}
+	if from.IsZero() {
+		return nil, nil
+	}
+
+	var conflicts []models.ScheduleConflict
+	seen := make(map[string]bool)
+	for start := from; start.Before(to); start = start.Add(maxOccurrenceWindow) {
+		end := start.Add(maxOccurrenceWindow)
+		if end.After(to) {
+			end = to
+		}
+		found, err := findScheduleConflicts(tx, roomID, start, end, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, conflict := range found {
+			key := fmt.Sprintf("%s-%d-%d", conflict.Source, conflict.IDSchedule, conflict.StartDate.Unix())
+			if !seen[key] {
+				seen[key] = true
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// firstOverlap returns the start of the first occurrence overlapping [start, end)
+func firstOverlap(occurrences []models.ScheduleRegulerOccurrence, start, end time.Time) (time.Time, bool) {
+	for _, o := range occurrences {
+		if o.StartDate.Before(end) && o.EndDate.After(start) {
+			return o.StartDate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseScheduleImportCSV reads a CSV with a header row. Column names are
+// case-insensitive; see scheduleImportColumns.
+func parseScheduleImportCSV(r io.Reader) ([]*scheduleImportCandidate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV file is empty")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range scheduleImportColumns[:4] {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing column %q", required)
+		}
+	}
+
+	var candidates []*scheduleImportCandidate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV at line %d: %w", line, err)
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		c := &scheduleImportCandidate{report: &models.ScheduleImportRow{
+			Row:   line,
+			Title: field("title"),
+			Email: field("email"),
+			Room:  field("room"),
+		}}
+		c.schedule.Title = c.report.Title
+		c.schedule.RRule = field("rrule")
+
+		if start, err := parseImportTime(field("start_date")); err != nil {
+			c.fail("start_date: %s", err.Error())
+		} else {
+			c.schedule.StartDate = start
+			c.report.StartDate = &start
+		}
+		if end, err := parseImportTime(field("end_date")); err != nil {
+			c.fail("end_date: %s", err.Error())
+		} else {
+			c.schedule.EndDate = end
+			c.report.EndDate = &end
+		}
+
+		if exDates := field("exdates"); exDates != "" {
+			for _, value := range strings.FieldsFunc(exDates, func(r rune) bool { return r == ';' || r == '|' || r == ' ' }) {
+				exDate, err := parseImportTime(value)
+				if err != nil {
+					c.fail("exdates: %s", err.Error())
+					continue
+				}
+				c.schedule.ExDates = append(c.schedule.ExDates, exDate)
+			}
+		}
+
+		if value := field("tahun"); value != "" {
+			tahun, err := strconv.Atoi(value)
+			if err != nil {
+				c.fail("tahun must be a number")
+			} else {
+				c.schedule.Tahun = &tahun
+			}
+		}
+		if value := field("semester"); value != "" {
+			semester, err := parseSemester(value)
+			if err != nil {
+				c.fail("%s", err.Error())
+			} else {
+				c.schedule.Semester = &semester
+			}
+		}
+
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// parseScheduleImportICS reads VEVENTs. The owner is taken from ORGANIZER and the room from LOCATION.
+func parseScheduleImportICS(r io.Reader) ([]*scheduleImportCandidate, error) {
+	events, err := utils.ParseICalEvents(r)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*scheduleImportCandidate, 0, len(events))
+	for _, event := range events {
+		c := &scheduleImportCandidate{report: &models.ScheduleImportRow{Row: event.Line}}
+		if summary, ok := event.Get("SUMMARY"); ok {
+			c.report.Title = strings.TrimSpace(utils.UnescapeICalText(summary.Value))
+		}
+		if organizer, ok := event.Get("ORGANIZER"); ok {
+			value := organizer.Value
+			if len(value) > len("mailto:") && strings.EqualFold(value[:len("mailto:")], "mailto:") {
+				value = value[len("mailto:"):]
+			}
+			c.report.Email = strings.TrimSpace(value)
+		}
+		if location, ok := event.Get("LOCATION"); ok {
+			c.report.Room = strings.TrimSpace(utils.UnescapeICalText(location.Value))
+		}
+		c.schedule.Title = c.report.Title
+
+		start, hasStart := event.Get("DTSTART")
+		if !hasStart {
+			c.fail("DTSTART is required")
+		} else if t, err := start.Time(); err != nil {
+			c.fail("%s", err.Error())
+		} else {
+			t = utils.LabWallClock(t)
+			c.schedule.StartDate = t
+			c.report.StartDate = &t
+		}
+
+		if end, ok := event.Get("DTEND"); ok {
+			if t, err := end.Time(); err != nil {
+				c.fail("%s", err.Error())
+			} else {
+				t = utils.LabWallClock(t)
+				c.schedule.EndDate = t
+				c.report.EndDate = &t
+			}
+		} else {
+			c.fail("DTEND is required")
+		}
+
+		if rules := event.All("RRULE"); len(rules) > 1 {
+			c.fail("only one RRULE per event is supported")
+		} else if len(rules) == 1 {
+			c.schedule.RRule = rules[0].Value
+		}
+		for _, prop := range event.All("EXDATE") {
+			times, err := prop.Times()
+			if err != nil {
+				c.fail("%s", err.Error())
+				continue
+			}
+			for _, t := range times {
+				c.schedule.ExDates = append(c.schedule.ExDates, utils.LabWallClock(t))
+			}
+		}
+
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// parseImportTime accepts RFC3339 or a local "YYYY-MM-DD HH:MM" time in the lab time zone
+func parseImportTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("value is required")
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return utils.LabWallClock(t), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD HH:MM or RFC3339", value)
+}
+
+func parseSemester(value string) (models.SemesterCategory, error) {
+	switch strings.ToLower(value) {
+	case "ganjil":
+		return models.SemesterGanjil, nil
+	case "genap":
+		return models.SemesterGenap, nil
+	}
+	return "", fmt.Errorf("semester must be Ganjil or Genap, got %q", value)
+}
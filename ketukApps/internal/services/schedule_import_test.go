@@ -0,0 +1,172 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseScheduleImportCSV(t *testing.T) {
+	csv := strings.Join([]string{
+		"\ufeffTitle,Email,Start_Date,End_Date,RRule,ExDates,Tahun,Semester,Room",
+		"Praktikum Basis Data,dosen@example.com,2025-03-03 08:00,2025-03-03 10:00,FREQ=WEEKLY;BYDAY=MO;COUNT=14,2025-03-31|2025-04-07,2024,genap,Lab 1",
+		"Praktikum Jaringan,dosen@example.com,03/03/2025,,,,dua,Pendek,",
+		",,,,,,,,",
+		"Praktikum Web,dosen@example.com,2025-03-04T08:00:00+07:00,2025-03-04 10:00,FREQ=WEEKLY,2025-13-01 2025-03-11,,,",
+	}, "\n")
+
+	candidates, err := parseScheduleImportCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candidates) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(candidates))
+	}
+
+	valid := candidates[0]
+	if valid.report.Row != 2 || len(valid.report.Errors) != 0 {
+		t.Fatalf("expected row 2 without errors, got %+v", valid.report)
+	}
+	if valid.schedule.Title != "Praktikum Basis Data" || valid.report.Email != "dosen@example.com" || valid.report.Room != "Lab 1" {
+		t.Errorf("unexpected fields %+v", valid.report)
+	}
+	if want := time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC); !valid.schedule.StartDate.Equal(want) {
+		t.Errorf("expected start %s, got %s", want, valid.schedule.StartDate)
+	}
+	if len(valid.schedule.ExDates) != 2 || valid.schedule.Tahun == nil || *valid.schedule.Tahun != 2024 ||
+		valid.schedule.Semester == nil || *valid.schedule.Semester != "Genap" {
+		t.Errorf("unexpected recurrence fields %+v", valid.schedule)
+	}
+
+	tests := []struct {
+		candidate *scheduleImportCandidate
+		row       int
+		errors    []string
+	}{
+		{candidates[1], 3, []string{
+			`start_date: invalid date "03/03/2025", expected YYYY-MM-DD HH:MM or RFC3339`,
+			"end_date: value is required",
+			"tahun must be a number",
+			`semester must be Ganjil or Genap, got "Pendek"`,
+		}},
+		{candidates[2], 5, []string{
+			`exdates: invalid date "2025-13-01", expected YYYY-MM-DD HH:MM or RFC3339`,
+		}},
+	}
+	for _, tt := range tests {
+		if tt.candidate.report.Row != tt.row {
+			t.Errorf("expected row %d, got %d", tt.row, tt.candidate.report.Row)
+		}
+		if !reflect.DeepEqual(tt.candidate.report.Errors, tt.errors) {
+			t.Errorf("row %d: expected errors %q, got %q", tt.row, tt.errors, tt.candidate.report.Errors)
+		}
+	}
+
+	// RFC3339 times are converted to the lab's wall clock
+	if want := time.Date(2025, time.March, 4, 8, 0, 0, 0, time.UTC); !candidates[2].schedule.StartDate.Equal(want) {
+		t.Errorf("expected start %s, got %s", want, candidates[2].schedule.StartDate)
+	}
+}
+
+func TestParseScheduleImportCSVRejectsBadFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		wantErr string
+	}{
+		{"empty", "", "CSV file is empty"},
+		{"missing column", "title,email,start_date\n", `CSV header is missing column "end_date"`},
+		{"unterminated quote", "title,email,start_date,end_date\n\"Praktikum,a@b.c,2025-03-03 08:00,2025-03-03 10:00\n", "invalid CSV at line 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseScheduleImportCSV(strings.NewReader(tt.csv))
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseScheduleImportICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"SUMMARY:Praktikum Basis Data\\, Kelas A",
+		"ORGANIZER:MAILTO:dosen@example.com",
+		"LOCATION:Lab 1",
+		"DTSTART;TZID=Asia/Jakarta:20250303T080000",
+		"DTEND:20250303T030000Z",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=14",
+		"EXDATE;TZID=Asia/Jakarta:20250331T080000,20250407T080000",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Praktikum Jaringan",
+		"DTSTART:2025-03-03",
+		"RRULE:FREQ=WEEKLY",
+		"RRULE:FREQ=WEEKLY;BYDAY=TU",
+		"EXDATE:yesterday",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	candidates, err := parseScheduleImportICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(candidates))
+	}
+
+	valid := candidates[0]
+	if valid.report.Row != 3 || len(valid.report.Errors) != 0 {
+		t.Fatalf("expected row 3 without errors, got %+v", valid.report)
+	}
+	if valid.report.Title != "Praktikum Basis Data, Kelas A" || valid.report.Email != "dosen@example.com" || valid.report.Room != "Lab 1" {
+		t.Errorf("unexpected fields %+v", valid.report)
+	}
+	wantStart := time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2025, time.March, 3, 10, 0, 0, 0, time.UTC)
+	if !valid.schedule.StartDate.Equal(wantStart) || !valid.schedule.EndDate.Equal(wantEnd) {
+		t.Errorf("expected %s to %s, got %s to %s", wantStart, wantEnd, valid.schedule.StartDate, valid.schedule.EndDate)
+	}
+	if valid.schedule.RRule != "FREQ=WEEKLY;BYDAY=MO;COUNT=14" || len(valid.schedule.ExDates) != 2 {
+		t.Errorf("unexpected recurrence %q %v", valid.schedule.RRule, valid.schedule.ExDates)
+	}
+
+	invalid := candidates[1]
+	want := []string{
+		`invalid DTSTART value "2025-03-03"`,
+		"DTEND is required",
+		"only one RRULE per event is supported",
+		`invalid EXDATE value "yesterday"`,
+	}
+	if invalid.report.Row != 15 || !reflect.DeepEqual(invalid.report.Errors, want) {
+		t.Errorf("expected row 15 with errors %q, got row %d with %q", want, invalid.report.Row, invalid.report.Errors)
+	}
+}
+
+func TestParseScheduleImportICSRejectsBadFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		ics     string
+		wantErr string
+	}{
+		{"not ical", "title,email\n", "not an iCalendar file: missing BEGIN:VCALENDAR"},
+		{"unclosed event", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Praktikum\nEND:VCALENDAR\n", "line 2: VEVENT is not closed"},
+		{"bad content line", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY Praktikum\n", `line 3: invalid content line "SUMMARY Praktikum"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseScheduleImportICS(strings.NewReader(tt.ics))
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
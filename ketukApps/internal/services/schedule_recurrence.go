@@ -0,0 +1,196 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// maxOccurrenceWindow limits how far a single request may expand recurring schedules
+const maxOccurrenceWindow = 366 * 24 * time.Hour
+
+type semesterKey struct {
+	tahun    int
+	semester models.SemesterCategory
+}
+
+// semesterEnds looks up where the unblocking windows of each semester end.
+// Every semester is loaded by the first lookup, so expanding many schedules
+// costs a single query.
+type semesterEnds struct {
+	db   *gorm.DB
+	ends map[semesterKey]time.Time
+}
+
+func newSemesterEnds(db *gorm.DB) *semesterEnds {
+	return &semesterEnds{db: db}
+}
+
+// end returns the end of the unblocking window defined for a semester, or nil
+// when the schedule has no semester or none is defined yet
+func (s *semesterEnds) end(tahun *int, semester *models.SemesterCategory) (*time.Time, error) {
+	if tahun == nil || semester == nil {
+		return nil, nil
+	}
+	if s.ends == nil {
+		var rows []struct {
+			Tahun    int
+			Semester models.SemesterCategory
+			EndDate  time.Time
+		}
+		err := s.db.Model(&models.Unblocking{}).
+			Select("tahun, semester, MAX(end_date) AS end_date").
+			Group("tahun, semester").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		s.ends = make(map[semesterKey]time.Time, len(rows))
+		for _, row := range rows {
+			s.ends[semesterKey{row.Tahun, row.Semester}] = row.EndDate
+		}
+	}
+
+	end, ok := s.ends[semesterKey{*tahun, *semester}]
+	if !ok {
+		return nil, nil
+	}
+	return &end, nil
+}
+
+// validateRecurrence checks the recurrence fields of a regular schedule
+func validateRecurrence(semesters *semesterEnds, schedule *models.ScheduleReguler) error {
+	if (schedule.Tahun == nil) != (schedule.Semester == nil) {
+		return errors.New("tahun and semester must be provided together")
+	}
+	if schedule.RRule == "" {
+		if len(schedule.ExDates) > 0 {
+			return errors.New("exDates require an rrule")
+		}
+		return nil
+	}
+
+	rule, err := utils.ParseRRule(schedule.RRule)
+	if err != nil {
+		return err
+	}
+	// Store the normalised form
+	schedule.RRule = rule.String()
+
+	if rule.Bounded() {
+		return nil
+	}
+	if schedule.Tahun == nil {
+		return errors.New("rrule without UNTIL or COUNT requires tahun and semester")
+	}
+	end, err := semesters.end(schedule.Tahun, schedule.Semester)
+	if err != nil {
+		return err
+	}
+	if end == nil {
+		return fmt.Errorf("no unblocking window defined for %s %d", *schedule.Semester, *schedule.Tahun)
+	}
+	return nil
+}
+
+// expandScheduleReguler returns the occurrences of a regular schedule that
+// overlap [from, to). One-off schedules yield at most one occurrence.
+func expandScheduleReguler(semesters *semesterEnds, schedule models.ScheduleReguler, from, to time.Time) ([]models.ScheduleRegulerOccurrence, error) {
+	duration := schedule.EndDate.Sub(schedule.StartDate)
+	occurrence := func(start time.Time) models.ScheduleRegulerOccurrence {
+		return models.ScheduleRegulerOccurrence{
+			IDSchedule: schedule.IDSchedule,
+			Title:      schedule.Title,
+			StartDate:  start,
+			EndDate:    start.Add(duration),
+			UserID:     schedule.UserID,
+			Recurring:  schedule.RRule != "",
+			RoomID:     schedule.RoomID,
+			User:       schedule.User,
+			Room:       schedule.Room,
+		}
+	}
+
+	if schedule.RRule == "" {
+		if schedule.StartDate.Before(to) && schedule.EndDate.After(from) {
+			return []models.ScheduleRegulerOccurrence{occurrence(schedule.StartDate)}, nil
+		}
+		return nil, nil
+	}
+
+	rule, err := utils.ParseRRule(schedule.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("schedule reguler %d has an invalid rrule: %w", schedule.IDSchedule, err)
+	}
+
+	// Open-ended series stop at the end of their semester
+	limit := to
+	if !rule.Bounded() {
+		end, err := semesters.end(schedule.Tahun, schedule.Semester)
+		if err != nil {
+			return nil, err
+		}
+		if end != nil && end.Before(limit) {
+			limit = *end
+		}
+	}
+
+	var occurrences []models.ScheduleRegulerOccurrence
+	for _, start := range rule.Occurrences(schedule.StartDate, from.Add(-duration), limit, schedule.ExDates) {
+		if start.Add(duration).After(from) {
+			occurrences = append(occurrences, occurrence(start))
+		}
+	}
+	return occurrences, nil
+}
+
+// scheduleRegulerOccurrences expands every schedule matched by query into occurrences within [from, to)
+func scheduleRegulerOccurrences(db *gorm.DB, query *gorm.DB, from, to time.Time) ([]models.ScheduleRegulerOccurrence, error) {
+	if !to.After(from) {
+		return nil, errors.New("to must be after from")
+	}
+	if to.Sub(from) > maxOccurrenceWindow {
+		return nil, errors.New("date window cannot be longer than one year")
+	}
+
+	var schedules []models.ScheduleReguler
+	err := query.
+		Where("start_date < ?", to).
+		Where("(rrule IS NOT NULL AND rrule <> '') OR end_date > ?", from).
+		Find(&schedules).Error
+	if err != nil {
+		return nil, err
+	}
+
+	semesters := newSemesterEnds(db)
+	occurrences := []models.ScheduleRegulerOccurrence{}
+	for _, schedule := range schedules {
+		expanded, err := expandScheduleReguler(semesters, schedule, from, to)
+		if err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, expanded...)
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].StartDate.Before(occurrences[j].StartDate)
+	})
+	return occurrences, nil
+}
+
+// GetScheduleRegulerOccurrences returns all regular schedule occurrences within [from, to).
+// roomID 0 means every room.
+func (s *ScheduleService) GetScheduleRegulerOccurrences(from, to time.Time, roomID int) ([]models.ScheduleRegulerOccurrence, error) {
+	return scheduleRegulerOccurrences(s.db, scopeRoom(s.db.Preload("User").Preload("Room"), roomID), from, to)
+}
+
+// GetScheduleRegulerOccurrencesByUserID returns a user's regular schedule occurrences within [from, to)
+func (s *ScheduleService) GetScheduleRegulerOccurrencesByUserID(userID int, from, to time.Time, roomID int) ([]models.ScheduleRegulerOccurrence, error) {
+	return scheduleRegulerOccurrences(s.db, scopeRoom(s.db.Preload("User").Preload("Room").Where("user_id = ?", userID), roomID), from, to)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
 
 	"gorm.io/gorm"
 )
@@ -21,35 +22,55 @@ func NewScheduleService(db *gorm.DB) *ScheduleService {
 
 // ScheduleTicket methods
 
-// GetAllScheduleTickets returns all schedule tickets
-func (s *ScheduleService) GetAllScheduleTickets() ([]models.ScheduleTicket, error) {
+// scheduleTicketListSpec lists the filters and sort keys of schedule ticket lists
+var scheduleTicketListSpec = listSpec{
+	filters: map[string]listFilter{
+		"roomId":   intFilter("room_id"),
+		"userId":   intFilter("user_id"),
+		"category": enumFilter("kategori", models.Kelas, models.Lainnya, models.Praktikum, models.Skripsi),
+		"isBooked": boolFilter("is_booked"),
+	},
+	sorts: map[string]string{
+		"startDate": "start_date",
+		"endDate":   "end_date",
+		"createdAt": "created_at",
+		"title":     "title",
+	},
+	defaultSort: "-startDate",
+	dateColumn:  "start_date",
+	idColumn:    "id_schedule",
+	idField:     "IDSchedule",
+}
+
+// GetAllScheduleTickets returns a page of schedule tickets
+func (s *ScheduleService) GetAllScheduleTickets(q models.ListQuery) ([]models.ScheduleTicket, *models.PageMeta, error) {
 	var schedules []models.ScheduleTicket
-	result := s.db.Preload("User").Preload("Tickets").Find(&schedules)
-	return schedules, result.Error
+	meta, err := listPage(s.db.Model(&models.ScheduleTicket{}), scheduleTicketListSpec, q, &schedules, "User", "Room", "Tickets")
+	return schedules, meta, err
 }
 
 // GetScheduleTicketByID returns a schedule ticket by its ID
 func (s *ScheduleService) GetScheduleTicketByID(id int) (*models.ScheduleTicket, error) {
 	var schedule models.ScheduleTicket
-	result := s.db.Preload("User").Preload("Tickets").First(&schedule, id)
+	result := s.db.Preload("User").Preload("Room").Preload("Tickets").First(&schedule, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, errors.New("schedule ticket not found")
 	}
 	return &schedule, result.Error
 }
 
-// GetScheduleTicketsByUserID returns all schedule tickets for a specific user
-func (s *ScheduleService) GetScheduleTicketsByUserID(userID int) ([]models.ScheduleTicket, error) {
+// GetScheduleTicketsByUserID returns a page of schedule tickets for a specific user
+func (s *ScheduleService) GetScheduleTicketsByUserID(userID int, q models.ListQuery) ([]models.ScheduleTicket, *models.PageMeta, error) {
 	var schedules []models.ScheduleTicket
-	result := s.db.Preload("User").Preload("Tickets").Where("user_id = ?", userID).Find(&schedules)
-	return schedules, result.Error
+	meta, err := listPage(s.db.Model(&models.ScheduleTicket{}).Where("user_id = ?", userID), scheduleTicketListSpec, q, &schedules, "User", "Room", "Tickets")
+	return schedules, meta, err
 }
 
-// GetScheduleTicketsByCategory returns all schedule tickets with a specific category
-func (s *ScheduleService) GetScheduleTicketsByCategory(category models.Category) ([]models.ScheduleTicket, error) {
+// GetScheduleTicketsByCategory returns a page of schedule tickets with a specific category
+func (s *ScheduleService) GetScheduleTicketsByCategory(category models.Category, q models.ListQuery) ([]models.ScheduleTicket, *models.PageMeta, error) {
 	var schedules []models.ScheduleTicket
-	result := s.db.Preload("User").Preload("Tickets").Where("kategori = ?", string(category)).Find(&schedules)
-	return schedules, result.Error
+	meta, err := listPage(s.db.Model(&models.ScheduleTicket{}).Where("kategori = ?", string(category)), scheduleTicketListSpec, q, &schedules, "User", "Room", "Tickets")
+	return schedules, meta, err
 }
 
 // GetScheduleTicketsByDateRange returns schedule tickets within a date range
@@ -63,25 +84,66 @@ func (s *ScheduleService) GetScheduleTicketsByDateRange(startDate, endDate time.
 
 // CreateScheduleTicket creates a new schedule ticket
 func (s *ScheduleService) CreateScheduleTicket(schedule *models.ScheduleTicket) (*models.ScheduleTicket, error) {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return createScheduleTicket(tx, schedule)
+	})
+	if err != nil {
+		if isExclusionViolation(err) {
+			return nil, conflictFromViolation(s.db, schedule.RoomID, schedule.StartDate, schedule.EndDate, 0)
+		}
+		return nil, err
+	}
+
+	// Reload with user data
+	s.db.Preload("User").Preload("Room").Preload("Tickets").First(schedule, schedule.IDSchedule)
+	return schedule, nil
+}
+
+// createScheduleTicket validates and inserts a schedule ticket and queues its
+// schedule.created event using db, which should be a transaction. An exclusion violation is returned as is, since the
+// conflicting rows can only be looked up outside an aborted transaction.
+func createScheduleTicket(db *gorm.DB, schedule *models.ScheduleTicket) error {
 	if schedule.Title == "" {
-		return nil, errors.New("title is required")
+		return errors.New("title is required")
 	}
 	if schedule.UserID == 0 {
-		return nil, errors.New("user ID is required")
+		return errors.New("user ID is required")
+	}
+	if err := validateScheduleRange(schedule.StartDate, schedule.EndDate); err != nil {
+		return err
+	}
+	roomID, err := resolveRoomID(db, schedule.RoomID)
+	if err != nil {
+		return err
+	}
+	schedule.RoomID = roomID
+	if err := checkRoomOpen(db, schedule.RoomID, schedule.StartDate, schedule.EndDate); err != nil {
+		return err
 	}
 
-	result := s.db.Create(schedule)
-	if result.Error != nil {
-		return nil, result.Error
+	// Reject slots already taken by a booking or a regular class
+	if err := checkScheduleConflicts(db, schedule.RoomID, schedule.StartDate, schedule.EndDate, 0); err != nil {
+		return err
 	}
 
-	// Reload with user data
-	s.db.Preload("User").Preload("Tickets").First(schedule, schedule.IDSchedule)
-	return schedule, nil
+	if err := db.Omit("User", "Room", "Tickets").Create(schedule).Error; err != nil {
+		return err
+	}
+	return enqueueEvent(db, models.DomainScheduleCreated, map[string]interface{}{
+		"kind":        "ticket",
+		"scheduleId":  schedule.IDSchedule,
+		"userId":      schedule.UserID,
+		"roomId":      schedule.RoomID,
+		"title":       schedule.Title,
+		"description": schedule.Description,
+		"category":    schedule.Kategori,
+		"startDate":   utils.InLabTime(schedule.StartDate),
+		"endDate":     utils.InLabTime(schedule.EndDate),
+	})
 }
 
 // UpdateScheduleTicket updates a schedule ticket
-func (s *ScheduleService) UpdateScheduleTicket(id int, updates map[string]interface{}) (*models.ScheduleTicket, error) {
+func (s *ScheduleService) UpdateScheduleTicket(id int, req models.UpdateScheduleTicketRequest) (*models.ScheduleTicket, error) {
 	var schedule models.ScheduleTicket
 	if err := s.db.First(&schedule, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -90,15 +152,55 @@ func (s *ScheduleService) UpdateScheduleTicket(id int, updates map[string]interf
 		return nil, err
 	}
 
-	if len(updates) > 0 {
-		result := s.db.Model(&schedule).Updates(updates)
-		if result.Error != nil {
-			return nil, result.Error
+	if req.Title != nil {
+		schedule.Title = *req.Title
+	}
+	if req.StartDate != nil {
+		schedule.StartDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		schedule.EndDate = *req.EndDate
+	}
+	if req.Kategori != nil {
+		schedule.Kategori = *req.Kategori
+	}
+	if req.Description != nil {
+		schedule.Description = *req.Description
+	}
+	if req.RoomID != nil && *req.RoomID != schedule.RoomID {
+		roomID, err := resolveRoomID(s.db, *req.RoomID)
+		if err != nil {
+			return nil, err
 		}
+		schedule.RoomID = roomID
+	}
+
+	if schedule.Title == "" {
+		return nil, errors.New("title is required")
+	}
+	if err := validateScheduleRange(schedule.StartDate, schedule.EndDate); err != nil {
+		return nil, err
+	}
+	if err := checkRoomOpen(s.db, schedule.RoomID, schedule.StartDate, schedule.EndDate); err != nil {
+		return nil, err
+	}
+
+	// Validate the new slot and save in one transaction so a conflicting change is rolled back
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkScheduleConflicts(tx, schedule.RoomID, schedule.StartDate, schedule.EndDate, schedule.IDSchedule); err != nil {
+			return err
+		}
+		return tx.Omit("User", "Room", "Tickets").Save(&schedule).Error
+	})
+	if err != nil {
+		if isExclusionViolation(err) {
+			return nil, conflictFromViolation(s.db, schedule.RoomID, schedule.StartDate, schedule.EndDate, schedule.IDSchedule)
+		}
+		return nil, err
 	}
 
 	// Reload with updated data
-	s.db.Preload("User").Preload("Tickets").First(&schedule, id)
+	s.db.Preload("User").Preload("Room").Preload("Tickets").First(&schedule, id)
 	return &schedule, nil
 }
 
@@ -116,28 +218,47 @@ func (s *ScheduleService) DeleteScheduleTicket(id int) error {
 
 // ScheduleReguler methods
 
-// GetAllScheduleReguler returns all regular schedules
-func (s *ScheduleService) GetAllScheduleReguler() ([]models.ScheduleReguler, error) {
+// scheduleRegulerListSpec lists the filters and sort keys of regular schedule lists
+var scheduleRegulerListSpec = listSpec{
+	filters: map[string]listFilter{
+		"roomId":   intFilter("room_id"),
+		"userId":   intFilter("user_id"),
+		"tahun":    intFilter("tahun"),
+		"semester": enumFilter("semester", models.SemesterGanjil, models.SemesterGenap),
+	},
+	sorts: map[string]string{
+		"startDate": "start_date",
+		"endDate":   "end_date",
+		"createdAt": "created_at",
+		"title":     "title",
+	},
+	defaultSort: "startDate",
+	idColumn:    "id_schedule",
+	idField:     "IDSchedule",
+}
+
+// GetAllScheduleReguler returns a page of regular schedules
+func (s *ScheduleService) GetAllScheduleReguler(q models.ListQuery) ([]models.ScheduleReguler, *models.PageMeta, error) {
 	var schedules []models.ScheduleReguler
-	result := s.db.Preload("User").Find(&schedules)
-	return schedules, result.Error
+	meta, err := listPage(s.db.Model(&models.ScheduleReguler{}), scheduleRegulerListSpec, q, &schedules, "User", "Room")
+	return schedules, meta, err
 }
 
 // GetScheduleRegulerByID returns a regular schedule by its ID
 func (s *ScheduleService) GetScheduleRegulerByID(id int) (*models.ScheduleReguler, error) {
 	var schedule models.ScheduleReguler
-	result := s.db.Preload("User").First(&schedule, id)
+	result := s.db.Preload("User").Preload("Room").First(&schedule, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, errors.New("schedule reguler not found")
 	}
 	return &schedule, result.Error
 }
 
-// GetScheduleRegulerByUserID returns all regular schedules for a specific user
-func (s *ScheduleService) GetScheduleRegulerByUserID(userID int) ([]models.ScheduleReguler, error) {
+// GetScheduleRegulerByUserID returns a page of regular schedules for a specific user
+func (s *ScheduleService) GetScheduleRegulerByUserID(userID int, q models.ListQuery) ([]models.ScheduleReguler, *models.PageMeta, error) {
 	var schedules []models.ScheduleReguler
-	result := s.db.Preload("User").Where("user_id = ?", userID).Find(&schedules)
-	return schedules, result.Error
+	meta, err := listPage(s.db.Model(&models.ScheduleReguler{}).Where("user_id = ?", userID), scheduleRegulerListSpec, q, &schedules, "User", "Room")
+	return schedules, meta, err
 }
 
 // GetScheduleRegulerByDateRange returns regular schedules within a date range
@@ -157,19 +278,50 @@ func (s *ScheduleService) CreateScheduleReguler(schedule *models.ScheduleReguler
 	if schedule.UserID == 0 {
 		return nil, errors.New("user ID is required")
 	}
+	if err := validateScheduleRange(schedule.StartDate, schedule.EndDate); err != nil {
+		return nil, err
+	}
+	if err := validateRecurrence(newSemesterEnds(s.db), schedule); err != nil {
+		return nil, err
+	}
+	roomID, err := resolveRoomID(s.db, schedule.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	schedule.RoomID = roomID
+	// Every occurrence shares the first one's time of day
+	if err := checkRoomOpen(s.db, schedule.RoomID, schedule.StartDate, schedule.EndDate); err != nil {
+		return nil, err
+	}
 
-	result := s.db.Create(schedule)
-	if result.Error != nil {
-		return nil, result.Error
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Room").Create(schedule).Error; err != nil {
+			return err
+		}
+		return enqueueEvent(tx, models.DomainScheduleCreated, map[string]interface{}{
+			"kind":       "reguler",
+			"scheduleId": schedule.IDSchedule,
+			"userId":     schedule.UserID,
+			"roomId":     schedule.RoomID,
+			"title":      schedule.Title,
+			"startDate":  utils.InLabTime(schedule.StartDate),
+			"endDate":    utils.InLabTime(schedule.EndDate),
+			"rrule":      schedule.RRule,
+			"tahun":      schedule.Tahun,
+			"semester":   schedule.Semester,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Reload with user data
-	s.db.Preload("User").First(schedule, schedule.IDSchedule)
+	s.db.Preload("User").Preload("Room").First(schedule, schedule.IDSchedule)
 	return schedule, nil
 }
 
 // UpdateScheduleReguler updates a regular schedule
-func (s *ScheduleService) UpdateScheduleReguler(id int, updates map[string]interface{}) (*models.ScheduleReguler, error) {
+func (s *ScheduleService) UpdateScheduleReguler(id int, req models.UpdateScheduleRegulerRequest) (*models.ScheduleReguler, error) {
 	var schedule models.ScheduleReguler
 	if err := s.db.First(&schedule, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -178,15 +330,54 @@ func (s *ScheduleService) UpdateScheduleReguler(id int, updates map[string]inter
 		return nil, err
 	}
 
-	if len(updates) > 0 {
-		result := s.db.Model(&schedule).Updates(updates)
-		if result.Error != nil {
-			return nil, result.Error
+	if req.Title != nil {
+		schedule.Title = *req.Title
+	}
+	if req.StartDate != nil {
+		schedule.StartDate = *req.StartDate
+	}
+	if req.EndDate != nil {
+		schedule.EndDate = *req.EndDate
+	}
+	if req.RRule != nil {
+		schedule.RRule = *req.RRule
+	}
+	if req.ExDates != nil {
+		schedule.ExDates = *req.ExDates
+	}
+	if req.Tahun != nil {
+		schedule.Tahun = req.Tahun
+	}
+	if req.Semester != nil {
+		schedule.Semester = req.Semester
+	}
+	if req.RoomID != nil && *req.RoomID != schedule.RoomID {
+		roomID, err := resolveRoomID(s.db, *req.RoomID)
+		if err != nil {
+			return nil, err
 		}
+		schedule.RoomID = roomID
+	}
+
+	if schedule.Title == "" {
+		return nil, errors.New("title is required")
+	}
+	if err := validateScheduleRange(schedule.StartDate, schedule.EndDate); err != nil {
+		return nil, err
+	}
+	if err := validateRecurrence(newSemesterEnds(s.db), &schedule); err != nil {
+		return nil, err
+	}
+	if err := checkRoomOpen(s.db, schedule.RoomID, schedule.StartDate, schedule.EndDate); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Omit("User", "Room").Save(&schedule).Error; err != nil {
+		return nil, err
 	}
 
 	// Reload with updated data
-	s.db.Preload("User").First(&schedule, id)
+	s.db.Preload("User").Preload("Room").First(&schedule, id)
 	return &schedule, nil
 }
 
@@ -246,9 +437,8 @@ func (s *ScheduleService) CreateUnblocking(unblocking *models.Unblocking) (*mode
 		return nil, errors.New("user ID is required")
 	}
 
-	result := s.db.Create(unblocking)
-	if result.Error != nil {
-		return nil, result.Error
+	if err := createUnblocking(s.db, unblocking); err != nil {
+		return nil, err
 	}
 
 	// Reload with user data
@@ -256,21 +446,37 @@ func (s *ScheduleService) CreateUnblocking(unblocking *models.Unblocking) (*mode
 	return unblocking, nil
 }
 
-// UpdateUnblocking updates an unblocking record
+// UpdateUnblocking updates an unblocking record. The updated window may not
+// overlap another one.
 func (s *ScheduleService) UpdateUnblocking(id int, updates map[string]interface{}) (*models.Unblocking, error) {
 	var unblocking models.Unblocking
-	if err := s.db.First(&unblocking, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("unblocking not found")
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockUnblockings(tx); err != nil {
+			return err
+		}
+		if err := tx.First(&unblocking, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("unblocking not found")
+			}
+			return err
+		}
+		if len(updates) == 0 {
+			return nil
 		}
-		return nil, err
-	}
 
-	if len(updates) > 0 {
-		result := s.db.Model(&unblocking).Updates(updates)
-		if result.Error != nil {
-			return nil, result.Error
+		if err := tx.Model(&unblocking).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&unblocking, id).Error; err != nil {
+			return err
 		}
+		if err := validateUnblocking(&unblocking); err != nil {
+			return err
+		}
+		return checkUnblockingOverlap(tx, &unblocking)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Reload with updated data
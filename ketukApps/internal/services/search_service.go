@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"ketukApps/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Search result limits per type
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 50
+)
+
+// searchConfig is the text search configuration created by the full-text search migration
+const searchConfig = "ketuk"
+
+// tsQuery parses user input with web search syntax: quoted phrases, OR and -word
+const tsQuery = "websearch_to_tsquery('" + searchConfig + "', ?)"
+
+// headline options for titles (whole text) and snippets (best fragments)
+const (
+	headlineTitle   = "'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'"
+	headlineSnippet = "'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5'"
+)
+
+// SearchScope limits a search to some types and, for regular users, to their
+// own tickets and bookings
+type SearchScope struct {
+	Types   []models.SearchType
+	Limit   int
+	UserID  uint
+	IsAdmin bool
+}
+
+type SearchService struct {
+	db *gorm.DB
+}
+
+func NewSearchService(db *gorm.DB) *SearchService {
+	return &SearchService{
+		db: db,
+	}
+}
+
+// Search runs a ranked full-text search over tickets, items and schedules.
+// Admins search every ticket and booking; other users only their own.
+func (s *SearchService) Search(query string, scope SearchScope) (*models.SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query is required")
+	}
+
+	limit := scope.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	types := scope.Types
+	if len(types) == 0 {
+		types = []models.SearchType{models.SearchTicket, models.SearchItem, models.SearchScheduleTicket, models.SearchScheduleReguler}
+	}
+
+	results := &models.SearchResults{
+		Query:  query,
+		Counts: make(map[models.SearchType]int),
+		Hits:   []models.SearchHit{},
+	}
+	for _, searchType := range types {
+		var hits []models.SearchHit
+		var err error
+		switch searchType {
+		case models.SearchTicket:
+			hits, err = s.searchTickets(query, scope, limit)
+		case models.SearchItem:
+			hits, err = s.searchItems(query, limit)
+		case models.SearchScheduleTicket:
+			hits, err = s.searchScheduleTickets(query, scope, limit)
+		case models.SearchScheduleReguler:
+			hits, err = s.searchScheduleReguler(query, limit)
+		default:
+			return nil, errors.New("unknown search type " + string(searchType))
+		}
+		if err != nil {
+			return nil, err
+		}
+		results.Counts[searchType] = len(hits)
+		results.Hits = append(results.Hits, hits...)
+	}
+
+	sort.SliceStable(results.Hits, func(i, j int) bool {
+		return results.Hits[i].Rank > results.Hits[j].Rank
+	})
+	results.Total = len(results.Hits)
+	return results, nil
+}
+
+// scopeToOwner limits a query over user-owned rows to the caller's rows,
+// unless the caller is an admin
+func scopeToOwner(sql string, args []interface{}, column string, scope SearchScope) (string, []interface{}) {
+	if scope.IsAdmin {
+		return sql, args
+	}
+	return sql + ` AND ` + column + ` = ?`, append(args, scope.UserID)
+}
+
+func (s *SearchService) searchTickets(query string, scope SearchScope, limit int) ([]models.SearchHit, error) {
+	sql, args := ticketSearchSQL(query, scope, limit)
+	var hits []models.SearchHit
+	err := s.db.Raw(sql, args...).Scan(&hits).Error
+	return hits, err
+}
+
+func ticketSearchSQL(query string, scope SearchScope, limit int) (string, []interface{}) {
+	sql := `SELECT 'ticket' AS type, t.id, t.title, t.status AS subtitle, t.created_at,
+			ts_headline('` + searchConfig + `', t.title, q, ` + headlineTitle + `) AS headline,
+			ts_headline('` + searchConfig + `', COALESCE(t.description, ''), q, ` + headlineSnippet + `) AS snippet,
+			ts_rank(t.search_vector, q) AS rank
+		FROM tickets t, ` + tsQuery + ` q
+		WHERE t.search_vector @@ q`
+	sql, args := scopeToOwner(sql, []interface{}{query}, "t.user_id", scope)
+	return sql + ` ORDER BY rank DESC, t.id DESC LIMIT ?`, append(args, limit)
+}
+
+func (s *SearchService) searchItems(query string, limit int) ([]models.SearchHit, error) {
+	var hits []models.SearchHit
+	err := s.db.Raw(`SELECT 'item' AS type, i.id, i.name AS title, c.category_name AS subtitle, i.created_at,
+			ts_headline('`+searchConfig+`', i.name, q, `+headlineTitle+`) AS headline,
+			ts_headline('`+searchConfig+`', CONCAT_WS(' - ', NULLIF(i.note, ''), NULLIF(c.specification, '')), q, `+headlineSnippet+`) AS snippet,
+			ts_rank(i.search_vector || c.search_vector, q) AS rank
+		FROM items i
+		JOIN items_category c ON c.id = i.category_id,
+		`+tsQuery+` q
+		WHERE i.search_vector @@ q OR c.search_vector @@ q
+		ORDER BY rank DESC, i.id DESC LIMIT ?`, query, limit).Scan(&hits).Error
+	return hits, err
+}
+
+func (s *SearchService) searchScheduleTickets(query string, scope SearchScope, limit int) ([]models.SearchHit, error) {
+	sql, args := scheduleTicketSearchSQL(query, scope, limit)
+	var hits []models.SearchHit
+	err := s.db.Raw(sql, args...).Scan(&hits).Error
+	return hits, err
+}
+
+// scheduleTicketSearchSQL searches bookings, whose descriptions are as private
+// as those of the tickets they came from
+func scheduleTicketSearchSQL(query string, scope SearchScope, limit int) (string, []interface{}) {
+	sql := `SELECT 'schedule_ticket' AS type, st.id_schedule AS id, st.title, st.kategori AS subtitle, COALESCE(st.created_at, st.start_date) AS created_at,
+			ts_headline('` + searchConfig + `', st.title, q, ` + headlineTitle + `) AS headline,
+			ts_headline('` + searchConfig + `', COALESCE(st.description, ''), q, ` + headlineSnippet + `) AS snippet,
+			ts_rank(st.search_vector, q) AS rank
+		FROM schedule_ticket st, ` + tsQuery + ` q
+		WHERE st.search_vector @@ q`
+	sql, args := scopeToOwner(sql, []interface{}{query}, "st.user_id", scope)
+	return sql + ` ORDER BY rank DESC, st.id_schedule DESC LIMIT ?`, append(args, limit)
+}
+
+func (s *SearchService) searchScheduleReguler(query string, limit int) ([]models.SearchHit, error) {
+	var hits []models.SearchHit
+	err := s.db.Raw(`SELECT 'schedule_reguler' AS type, sr.id_schedule AS id, sr.title, CONCAT_WS(' ', sr.semester, sr.tahun) AS subtitle, COALESCE(sr.created_at, sr.start_date) AS created_at,
+			ts_headline('`+searchConfig+`', sr.title, q, `+headlineTitle+`) AS headline,
+			ts_rank(sr.search_vector, q) AS rank
+		FROM schedule_reguler sr, `+tsQuery+` q
+		WHERE sr.search_vector @@ q
+		ORDER BY rank DESC, sr.id_schedule DESC LIMIT ?`, query, limit).Scan(&hits).Error
+	return hits, err
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ketukApps/internal/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB connects to the migrated database in TEST_DATABASE_URL, e.g.
+// "host=localhost user=user password=password dbname=mydb sslmode=disable"
+// against the docker compose stack. Tests needing it are skipped without one.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+	return db
+}
+
+// TestSearchQueriesMatchSchema runs the query of every search type against
+// the migrated schema, so a column that does not exist fails here rather than
+// on every default search.
+func TestSearchQueriesMatchSchema(t *testing.T) {
+	service := NewSearchService(testDB(t))
+
+	types := []models.SearchType{models.SearchTicket, models.SearchItem, models.SearchScheduleTicket, models.SearchScheduleReguler}
+	scopes := map[string]SearchScope{
+		"admin": {IsAdmin: true},
+		"user":  {UserID: 1},
+	}
+	for _, searchType := range types {
+		for name, scope := range scopes {
+			scope.Types = []models.SearchType{searchType}
+			t.Run(string(searchType)+"/"+name, func(t *testing.T) {
+				results, err := service.Search("praktikum -jaringan", scope)
+				if err != nil {
+					t.Fatalf("search failed: %v", err)
+				}
+				for _, hit := range results.Hits {
+					if hit.Type != searchType || hit.ID == 0 {
+						t.Errorf("unexpected hit %+v", hit)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestSearchScopesOwnedRowsToTheCaller checks without a database that regular
+// users only search their own tickets and bookings while admins search all.
+func TestSearchScopesOwnedRowsToTheCaller(t *testing.T) {
+	builders := map[string]struct {
+		build  func(string, SearchScope, int) (string, []interface{})
+		column string
+	}{
+		"ticket":          {ticketSearchSQL, "t.user_id = ?"},
+		"schedule_ticket": {scheduleTicketSearchSQL, "st.user_id = ?"},
+	}
+
+	for name, b := range builders {
+		t.Run(name+"/user", func(t *testing.T) {
+			sql, args := b.build("praktikum", SearchScope{UserID: 7}, 10)
+			if !strings.Contains(sql, b.column) {
+				t.Errorf("expected the query to filter on %q:\n%s", b.column, sql)
+			}
+			want := []interface{}{"praktikum", uint(7), 10}
+			if !reflect.DeepEqual(args, want) {
+				t.Errorf("expected args %v, got %v", want, args)
+			}
+			if placeholders := strings.Count(sql, "?"); placeholders != len(args) {
+				t.Errorf("query has %d placeholders for %d args", placeholders, len(args))
+			}
+		})
+		t.Run(name+"/admin", func(t *testing.T) {
+			sql, args := b.build("praktikum", SearchScope{UserID: 1, IsAdmin: true}, 10)
+			if strings.Contains(sql, "user_id") {
+				t.Errorf("expected admins to search every row:\n%s", sql)
+			}
+			want := []interface{}{"praktikum", 10}
+			if !reflect.DeepEqual(args, want) {
+				t.Errorf("expected args %v, got %v", want, args)
+			}
+		})
+	}
+}
@@ -4,21 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"ketukApps/internal/utils"
-	"log"
-	"net/smtp"
 	"time"
 
 	"ketukApps/internal/models"
+	"ketukApps/internal/notify"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TicketService struct {
 	db           *gorm.DB
 	auditService *AuditService
-	smtpAuth     *smtp.Auth
-	smtpHost     string
-	smtpEmail    string
 }
 
 func NewTicketService(db *gorm.DB) *TicketService {
@@ -28,44 +25,52 @@ func NewTicketService(db *gorm.DB) *TicketService {
 	}
 }
 
-func NewTicketServiceWithEmail(db *gorm.DB, smtpAuth *smtp.Auth, smtpHost, smtpEmail string) *TicketService {
-	return &TicketService{
-		db:           db,
-		auditService: NewAuditService(db),
-		smtpAuth:     smtpAuth,
-		smtpHost:     smtpHost,
-		smtpEmail:    smtpEmail,
-	}
+// ticketListSpec lists the filters and sort keys of ticket lists
+var ticketListSpec = listSpec{
+	filters: map[string]listFilter{
+		"status": enumFilter("status", models.StatusPending, models.StatusAccepted, models.StatusRejected),
+		"userId": intFilter("user_id"),
+		"roomId": intFilter("room_id"),
+	},
+	sorts: map[string]string{
+		"createdAt":  "created_at",
+		"updatedAt":  "updated_at",
+		"approvedAt": "approved_at",
+		"title":      "title",
+		"status":     "status",
+	},
+	defaultSort: "-id",
+	dateColumn:  "created_at",
 }
 
-// GetAll returns all tickets
-func (s *TicketService) GetAll() ([]models.Ticket, error) {
+// GetAll returns a page of tickets, newest first by default
+func (s *TicketService) GetAll(q models.ListQuery) ([]models.Ticket, *models.PageMeta, error) {
 	var tickets []models.Ticket
-	result := s.db.Preload("User").Find(&tickets)
-	return tickets, result.Error
+	meta, err := listPage(s.db.Model(&models.Ticket{}), ticketListSpec, q, &tickets, "User", "Room")
+	return tickets, meta, err
 }
 
 // GetByID returns a ticket by its ID
 func (s *TicketService) GetByID(id uint) (*models.Ticket, error) {
 	var ticket models.Ticket
-	result := s.db.Preload("User").First(&ticket, id)
+	result := s.db.Preload("User").Preload("Room").First(&ticket, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, errors.New("ticket not found")
 	}
 	return &ticket, result.Error
 }
 
-// GetByUserID returns all tickets for a specific user
-func (s *TicketService) GetByUserID(userID uint) ([]models.Ticket, error) {
+// GetByUserID returns a page of tickets for a specific user
+func (s *TicketService) GetByUserID(userID uint, q models.ListQuery) ([]models.Ticket, *models.PageMeta, error) {
 	var tickets []models.Ticket
-	result := s.db.Preload("User").Where("user_id = ?", userID).Find(&tickets)
-	if result.Error != nil {
-		return nil, result.Error
+	meta, err := listPage(s.db.Model(&models.Ticket{}).Where("user_id = ?", userID), ticketListSpec, q, &tickets, "User", "Room")
+	if err != nil {
+		return nil, nil, err
 	}
-	if len(tickets) == 0 {
-		return nil, errors.New("no tickets found for user")
+	if meta.Total == 0 {
+		return nil, nil, errors.New("no tickets found for user")
 	}
-	return tickets, nil
+	return tickets, meta, nil
 }
 
 // GetByStatus returns all tickets with a specific status
@@ -91,9 +96,15 @@ func (s *TicketService) Create(userID uint, title, description string) (*models.
 		Status:      "pending",
 	}
 
-	result := s.db.Create(&ticket)
-	if result.Error != nil {
-		return nil, result.Error
+	// The ticket.created event is published only if the ticket is committed
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&ticket).Error; err != nil {
+			return err
+		}
+		return enqueueEvent(tx, models.DomainTicketCreated, ticketCreatedEvent(&ticket))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Reload with user data
@@ -118,7 +129,99 @@ func (s *TicketService) Create(userID uint, title, description string) (*models.
 
 // CreateFromRequest creates a new ticket from CreateTicketRequest
 func (s *TicketService) CreateFromRequest(req models.CreateTicketRequest) (*models.Ticket, error) {
-	return s.Create(req.UserID, req.Title, req.Description)
+	roomID, err := resolveRoomID(s.db, req.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateFromModel(&models.Ticket{
+		UserID:      req.UserID,
+		Title:       req.Title,
+		Description: req.Description,
+		Kategori:    req.Kategori,
+		RoomID:      &roomID,
+	})
+}
+
+// CreateFromRequestOnce creates a ticket like CreateFromRequest, at most once
+// per idempotency key of the calling user. A retried request gets the ticket
+// of the first one and created is false.
+func (s *TicketService) CreateFromRequestOnce(callerID uint, key string, req models.CreateTicketRequest) (ticket *models.Ticket, created bool, err error) {
+	if key == "" {
+		ticket, err = s.CreateFromRequest(req)
+		return ticket, err == nil, err
+	}
+
+	if req.Title == "" {
+		return nil, false, errors.New("title is required")
+	}
+	if req.Description == "" {
+		return nil, false, errors.New("description is required")
+	}
+	roomID, err := resolveRoomID(s.db, req.RoomID)
+	if err != nil {
+		return nil, false, err
+	}
+	hash, err := requestHash(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	scope := apiTicketsScope(callerID)
+	ticket = &models.Ticket{
+		UserID:      req.UserID,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      models.StatusPending,
+		Kategori:    req.Kategori,
+		RoomID:      &roomID,
+	}
+	var processed *models.ProcessedMessage
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var claimErr error
+		processed, claimErr = claimIdempotencyKey(tx, scope, key, hash, idempotentTicket)
+		if claimErr != nil || processed != nil {
+			return claimErr
+		}
+		if err := tx.Omit("User", "Room").Create(ticket).Error; err != nil {
+			return err
+		}
+		if err := enqueueEvent(tx, models.DomainTicketCreated, ticketCreatedEvent(ticket)); err != nil {
+			return err
+		}
+		return completeIdempotencyKey(tx, scope, key, int64(ticket.ID))
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if processed != nil {
+		ticket, err = s.processedTicket(processed)
+		return ticket, false, err
+	}
+
+	// Reload with user data
+	s.db.Preload("User").First(ticket, ticket.ID)
+
+	// Log audit trail
+	userIDInt := int(ticket.UserID)
+	s.auditService.LogTicketEvent(int(ticket.ID), &userIDInt, models.EventCreated, nil, ticket, nil, nil, nil, nil)
+
+	return ticket, true, nil
+}
+
+// processedTicket loads the ticket created by an earlier request with the same idempotency key
+func (s *TicketService) processedTicket(processed *models.ProcessedMessage) (*models.Ticket, error) {
+	if processed.ResourceID == nil {
+		return nil, errors.New("ticket not found")
+	}
+	var ticket models.Ticket
+	if err := s.db.Preload("User").First(&ticket, *processed.ResourceID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("ticket not found")
+		}
+		return nil, err
+	}
+	return &ticket, nil
 }
 
 // CreateFromModel creates a new ticket from a models.Ticket (used for queue processing)
@@ -138,9 +241,14 @@ func (s *TicketService) CreateFromModel(ticket *models.Ticket) (*models.Ticket,
 		ticket.Status = "pending"
 	}
 
-	result := s.db.Create(ticket)
-	if result.Error != nil {
-		return nil, result.Error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(ticket).Error; err != nil {
+			return err
+		}
+		return enqueueEvent(tx, models.DomainTicketCreated, ticketCreatedEvent(ticket))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Reload with user data
@@ -163,12 +271,120 @@ func (s *TicketService) CreateFromModel(ticket *models.Ticket) (*models.Ticket,
 	return ticket, nil
 }
 
+// CreateWithSchedule books a schedule and creates its ticket, the audit entry
+// and the requester's notification in one transaction, so a failure leaves
+// neither an orphaned schedule nor a lost notification behind
+func (s *TicketService) CreateWithSchedule(schedule *models.ScheduleTicket, ticket *models.Ticket) (*models.Ticket, error) {
+	ticket, _, err := s.createWithSchedule(schedule, ticket, "", "", "")
+	return ticket, err
+}
+
+// CreateWithScheduleOnce is CreateWithSchedule for queue messages. The
+// message key is recorded in the same transaction, so a redelivered message
+// returns the ticket booked the first time with created false.
+func (s *TicketService) CreateWithScheduleOnce(queue, key string, body []byte, schedule *models.ScheduleTicket, ticket *models.Ticket) (*models.Ticket, bool, error) {
+	hash, err := requestHash(body)
+	if err != nil {
+		return nil, false, err
+	}
+	return s.createWithSchedule(schedule, ticket, scopeQueuePrefix+queue, key, hash)
+}
+
+func (s *TicketService) createWithSchedule(schedule *models.ScheduleTicket, ticket *models.Ticket, scope, key, hash string) (*models.Ticket, bool, error) {
+	if ticket.Title == "" {
+		return nil, false, errors.New("title is required")
+	}
+	if ticket.Description == "" {
+		return nil, false, errors.New("description is required")
+	}
+	ticket.ID = 0
+	if ticket.Status == "" {
+		ticket.Status = models.StatusPending
+	}
+
+	var processed *models.ProcessedMessage
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if key != "" {
+			var err error
+			processed, err = claimIdempotencyKey(tx, scope, key, hash, idempotentTicket)
+			if err != nil || processed != nil {
+				return err
+			}
+		}
+
+		var user models.User
+		if err := tx.First(&user, ticket.UserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("user not found")
+			}
+			return err
+		}
+
+		if err := createScheduleTicket(tx, schedule); err != nil {
+			return err
+		}
+
+		ticket.IDSchedule = &schedule.IDSchedule
+		ticket.RoomID = &schedule.RoomID
+		if err := tx.Omit("User", "Room").Create(ticket).Error; err != nil {
+			return err
+		}
+
+		userIDInt := int(ticket.UserID)
+		if err := NewAuditService(tx).LogTicketEvent(int(ticket.ID), &userIDInt, models.EventCreated, nil, ticket, nil, nil, nil, nil); err != nil {
+			return err
+		}
+		if err := enqueueEvent(tx, models.DomainTicketCreated, ticketCreatedEvent(ticket)); err != nil {
+			return err
+		}
+		if key != "" {
+			if err := completeIdempotencyKey(tx, scope, key, int64(ticket.ID)); err != nil {
+				return err
+			}
+			// Messages published by the bookings endpoint are tracked by their key
+			if err := markBookingProcessed(tx, key, ticket.ID); err != nil {
+				return err
+			}
+		}
+
+		return enqueueNotification(tx, notify.Notification{
+			Event:  models.NotifyTicketCreated,
+			UserID: user.ID,
+			Email:  user.Email,
+			Name:   user.Name,
+			Locale: user.Locale,
+			Data: map[string]interface{}{
+				"ticketId":    ticket.ID,
+				"title":       ticket.Title,
+				"description": ticket.Description,
+				"status":      string(ticket.Status),
+				"startDate":   utils.InLabTime(schedule.StartDate),
+				"endDate":     utils.InLabTime(schedule.EndDate),
+			},
+		})
+	})
+	if err != nil {
+		if isExclusionViolation(err) {
+			return nil, false, conflictFromViolation(s.db, schedule.RoomID, schedule.StartDate, schedule.EndDate, 0)
+		}
+		return nil, false, err
+	}
+	if processed != nil {
+		ticket, err := s.processedTicket(processed)
+		return ticket, false, err
+	}
+
+	// Reload with user data
+	s.db.Preload("User").First(ticket, ticket.ID)
+	return ticket, true, nil
+}
+
 // UpdateStatus updates the status of a ticket
 func (s *TicketService) UpdateStatus(id uint, status, reason string) (*models.Ticket, error) {
 	return s.UpdateStatusWithAdmin(id, status, reason, nil)
 }
 
-// UpdateStatusWithAdmin updates the status of a ticket and sends email notification
+// UpdateStatusWithAdmin updates the status of a ticket and notifies its owner
 func (s *TicketService) UpdateStatusWithAdmin(id uint, status, reason string, adminUser *models.User) (*models.Ticket, error) {
 	validStatuses := []string{"pending", "accepted", "rejected"}
 
@@ -193,6 +409,18 @@ func (s *TicketService) UpdateStatusWithAdmin(id uint, status, reason string, ad
 		return nil, err
 	}
 
+	// Accepting a ticket books its schedule, so make sure the slot is still free
+	var schedule *models.ScheduleTicket
+	if status == "accepted" && ticket.IDSchedule != nil {
+		schedule = &models.ScheduleTicket{}
+		if err := s.db.First(schedule, *ticket.IDSchedule).Error; err != nil {
+			return nil, err
+		}
+		if err := checkScheduleConflicts(s.db, schedule.RoomID, schedule.StartDate, schedule.EndDate, schedule.IDSchedule); err != nil {
+			return nil, err
+		}
+	}
+
 	// Store old ticket state for audit and comparison
 	oldTicket := ticket
 	oldStatus := string(ticket.Status)
@@ -208,9 +436,31 @@ func (s *TicketService) UpdateStatusWithAdmin(id uint, status, reason string, ad
 		updates["approved_at"] = now
 	}
 
-	result := s.db.Model(&ticket).Updates(updates)
-	if result.Error != nil {
-		return nil, result.Error
+	// Equipment loan tickets carry their loan along in the same transaction,
+	// and the owner's notification is queued in the outbox with it
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&ticket).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := syncLoanWithTicket(tx, ticket.ID, status); err != nil {
+			return err
+		}
+		if oldStatus == status {
+			return nil
+		}
+		if err := enqueueEvent(tx, models.DomainTicketStatusChanged, statusChangedEvent(&ticket, oldStatus, status, reason, adminUser)); err != nil {
+			return err
+		}
+		if ticket.User.ID != 0 {
+			return enqueueNotification(tx, statusChangeNotification(&ticket, oldStatus, status, reason, adminUser))
+		}
+		return nil
+	})
+	if err != nil {
+		if schedule != nil && isExclusionViolation(err) {
+			return nil, conflictFromViolation(s.db, schedule.RoomID, schedule.StartDate, schedule.EndDate, schedule.IDSchedule)
+		}
+		return nil, err
 	}
 
 	// Reload with updated data
@@ -248,77 +498,47 @@ func (s *TicketService) UpdateStatusWithAdmin(id uint, status, reason string, ad
 		nil,
 	)
 
-	// Send email notification only if status actually changed
-	if oldStatus != string(ticket.Status) {
-		s.sendStatusChangeEmail(&ticket, oldStatus, adminUser)
-	}
-
 	return &ticket, nil
 }
 
-// sendStatusChangeEmail sends an email notification when ticket status changes
-func (s *TicketService) sendStatusChangeEmail(ticket *models.Ticket, oldStatus string, adminUser *models.User) {
-	if s.smtpAuth == nil || ticket.User.Email == "" {
-		return
+// statusChangeNotification tells the ticket owner that the status of their ticket changed
+func statusChangeNotification(ticket *models.Ticket, oldStatus, newStatus, reason string, adminUser *models.User) notify.Notification {
+	data := map[string]interface{}{
+		"ticketId":    ticket.ID,
+		"title":       ticket.Title,
+		"description": ticket.Description,
+		"oldStatus":   oldStatus,
+		"newStatus":   newStatus,
+		"reason":      reason,
 	}
-
-	to := []string{ticket.User.Email}
-	var subject string
-	var body string
-
-	statusAction := "updated"
-	if ticket.Status == "accepted" {
-		statusAction = "approved"
-	} else if ticket.Status == "rejected" {
-		statusAction = "rejected"
+	if adminUser != nil {
+		data["processedBy"] = fmt.Sprintf("%s (%s)", adminUser.Name, adminUser.Email)
 	}
 
-	subject = fmt.Sprintf("Ticket #%d Status %s", ticket.ID, statusAction)
+	return notify.Notification{
+		Event:  models.NotifyTicketStatusChanged,
+		UserID: ticket.User.ID,
+		Email:  ticket.User.Email,
+		Name:   ticket.User.Name,
+		Locale: ticket.User.Locale,
+		Data:   data,
+	}
+}
 
-	adminInfo := "the admin team"
+// statusChangedEvent describes a ticket status change for subscribers
+func statusChangedEvent(ticket *models.Ticket, oldStatus, newStatus, reason string, adminUser *models.User) map[string]interface{} {
+	data := map[string]interface{}{
+		"ticketId":   ticket.ID,
+		"userId":     ticket.UserID,
+		"scheduleId": ticket.IDSchedule,
+		"oldStatus":  oldStatus,
+		"newStatus":  newStatus,
+		"reason":     reason,
+	}
 	if adminUser != nil {
-		adminInfo = fmt.Sprintf("%s (%s)", adminUser.Name, adminUser.Email)
-	}
-
-	body = fmt.Sprintf(`Hello %s,
-
-Your ticket status has been %s.
-
-Ticket Details:
-- Ticket ID: #%d
-- Title: %s
-- Description: %s
-- Previous Status: %s
-- New Status: %s
-- Reason: %s
-- Processed by: %s
-
-Thank you for using our service.
-
-Best regards,
-The Support Team`,
-		ticket.User.Name,
-		statusAction,
-		ticket.ID,
-		ticket.Title,
-		ticket.Description,
-		oldStatus,
-		ticket.Status,
-		func() string {
-			if ticket.Reason != "" {
-				return ticket.Reason
-			}
-			return "N/A"
-		}(),
-		adminInfo,
-	)
-
-	err := utils.SendEmail(to, subject, body, *s.smtpAuth, s.smtpHost, s.smtpEmail)
-	if err != nil {
-		log.Printf("Failed to send status change notification email to %s: %s", ticket.User.Email, err)
-	} else {
-		log.Printf("Successfully sent status change notification email to %s for ticket #%d", ticket.User.Email, ticket.ID)
+		data["processedBy"] = adminUser.ID
 	}
+	return data
 }
 
 // Update updates ticket details
@@ -434,11 +654,13 @@ func (s *TicketService) GetStatistics() (map[string]interface{}, error) {
 	return stats, nil
 }
 
-// SearchTickets searches tickets by title or description
+// SearchTickets runs a full-text search over ticket titles and descriptions, best match first
 func (s *TicketService) SearchTickets(query string) ([]models.Ticket, error) {
 	var tickets []models.Ticket
-	searchTerm := fmt.Sprintf("%%%s%%", query)
-	result := s.db.Preload("User").Where("title ILIKE ? OR description ILIKE ?", searchTerm, searchTerm).Find(&tickets)
+	result := s.db.Preload("User").
+		Where("search_vector @@ "+tsQuery, query).
+		Order(clause.Expr{SQL: "ts_rank(search_vector, " + tsQuery + ") DESC, id", Vars: []interface{}{query}}).
+		Find(&tickets)
 	return tickets, result.Error
 }
 
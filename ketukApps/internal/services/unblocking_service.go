@@ -1,8 +1,14 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"ketukApps/internal/models"
+	"ketukApps/internal/utils"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -16,10 +22,78 @@ func NewUnblockingService(db *gorm.DB) *UnblockingService {
 	}
 }
 
-// Create unblocking request
+// ErrUnblockingOverlap is returned when a window would overlap another one of the same scope
+var ErrUnblockingOverlap = errors.New("unblocking window overlaps an existing window")
+
+// Create unblocking request. Windows of the same category and role may not overlap.
 func (s *UnblockingService) Create(unblocking *models.Unblocking) (*models.Unblocking, error) {
-	result := s.db.Create(unblocking)
-	return unblocking, result.Error
+	if err := createUnblocking(s.db, unblocking); err != nil {
+		return nil, err
+	}
+	return unblocking, nil
+}
+
+// createUnblocking inserts a window unless it overlaps another one. The table
+// is locked against concurrent writers so two overlapping windows cannot both
+// pass the check.
+func createUnblocking(db *gorm.DB, unblocking *models.Unblocking) error {
+	if err := validateUnblocking(unblocking); err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := lockUnblockings(tx); err != nil {
+			return err
+		}
+		if err := checkUnblockingOverlap(tx, unblocking); err != nil {
+			return err
+		}
+		return tx.Create(unblocking).Error
+	})
+}
+
+func lockUnblockings(tx *gorm.DB) error {
+	return tx.Exec("LOCK TABLE unblocking IN SHARE ROW EXCLUSIVE MODE").Error
+}
+
+// validateUnblocking checks the date range and scope of a window
+func validateUnblocking(unblocking *models.Unblocking) error {
+	if unblocking.StartDate.IsZero() || unblocking.EndDate.IsZero() {
+		return errors.New("start and end date are required")
+	}
+	if !unblocking.EndDate.After(unblocking.StartDate) {
+		return errors.New("end date must be after start date")
+	}
+	if unblocking.Kategori != nil {
+		switch *unblocking.Kategori {
+		case models.Kelas, models.Lainnya, models.Praktikum, models.Skripsi:
+		default:
+			return errors.New("kategori must be one of Kelas, Lainnya, Praktikum, Skripsi")
+		}
+	}
+	if unblocking.Role != nil && *unblocking.Role != "admin" && *unblocking.Role != "user" {
+		return errors.New("role must be one of admin, user")
+	}
+	return nil
+}
+
+// checkUnblockingOverlap reports the first other window of the same category
+// and role overlapping unblocking. Windows may touch: one can end at the
+// moment the next starts. Windows of different scopes may overlap freely.
+func checkUnblockingOverlap(tx *gorm.DB, unblocking *models.Unblocking) error {
+	var existing models.Unblocking
+	err := tx.Where("id <> ? AND start_date < ? AND end_date > ?", unblocking.ID, unblocking.EndDate, unblocking.StartDate).
+		Where("kategori IS NOT DISTINCT FROM ? AND role IS NOT DISTINCT FROM ?", unblocking.Kategori, unblocking.Role).
+		Order("start_date ASC, id ASC").
+		First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: window %d (%d %s, %s) runs from %s to %s", ErrUnblockingOverlap,
+		existing.ID, existing.Tahun, existing.Semester, existing.Scope(),
+		existing.StartDate.Format("2006-01-02 15:04"), existing.EndDate.Format("2006-01-02 15:04"))
 }
 
 // GetByID returns an unblocking request by its ID
@@ -29,18 +103,37 @@ func (s *UnblockingService) GetByID(id int) (*models.Unblocking, error) {
 	return &unblocking, result.Error
 }
 
-// GetByUserID returns all unblocking requests for a specific user
-func (s *UnblockingService) GetByUserID(userID int) ([]models.Unblocking, error) {
+// unblockingListSpec lists the filters and sort keys of unblocking lists
+var unblockingListSpec = listSpec{
+	filters: map[string]listFilter{
+		"userId":   intFilter("user_id"),
+		"tahun":    intFilter("tahun"),
+		"semester": enumFilter("semester", models.SemesterGanjil, models.SemesterGenap),
+		"kategori": enumFilter("kategori", models.Kelas, models.Lainnya, models.Praktikum, models.Skripsi),
+		"role":     enumFilter("role", "admin", "user"),
+	},
+	sorts: map[string]string{
+		"startDate": "start_date",
+		"endDate":   "end_date",
+		"createdAt": "created_at",
+		"tahun":     "tahun",
+	},
+	defaultSort: "-startDate",
+	dateColumn:  "start_date",
+}
+
+// GetByUserID returns a page of unblocking requests for a specific user
+func (s *UnblockingService) GetByUserID(userID int, q models.ListQuery) ([]models.Unblocking, *models.PageMeta, error) {
 	var unblockings []models.Unblocking
-	result := s.db.Preload("User").Where("user_id = ?", userID).Find(&unblockings)
-	return unblockings, result.Error
+	meta, err := listPage(s.db.Model(&models.Unblocking{}).Where("user_id = ?", userID), unblockingListSpec, q, &unblockings, "User")
+	return unblockings, meta, err
 }
 
-// GetAll returns all unblocking requests
-func (s *UnblockingService) GetAll() ([]models.Unblocking, error) {
+// GetAll returns a page of unblocking requests
+func (s *UnblockingService) GetAll(q models.ListQuery) ([]models.Unblocking, *models.PageMeta, error) {
 	var unblockings []models.Unblocking
-	result := s.db.Preload("User").Find(&unblockings)
-	return unblockings, result.Error
+	meta, err := listPage(s.db.Model(&models.Unblocking{}), unblockingListSpec, q, &unblockings, "User")
+	return unblockings, meta, err
 }
 
 // Delete an unblocking request by its ID
@@ -55,3 +148,116 @@ func (s *UnblockingService) GetDueUnblockings() ([]models.Unblocking, error) {
 	result := s.db.Where("scheduled_at <= NOW()").Find(&unblockings)
 	return unblockings, result.Error
 }
+
+// OpenWindows returns every window covering t, most specific first: windows
+// for a category and role, then for a category, then for a role, then for
+// everyone. Windows of the same scope are ordered latest created first.
+func (s *UnblockingService) OpenWindows(t time.Time) ([]models.Unblocking, error) {
+	now := utils.LabWallClock(t)
+	var windows []models.Unblocking
+	err := s.db.Where("start_date <= ? AND end_date >= ?", now, now).
+		Order("(kategori IS NOT NULL) DESC, (role IS NOT NULL) DESC, created_at DESC, id DESC").
+		Find(&windows).Error
+	return windows, err
+}
+
+// Effective resolves the window in effect at t for a booking of category by
+// role; empty values stand for any category or role. Booking is open while
+// any window that applies covers t. The most specific of them is reported,
+// and among windows of the same scope, which only overlap when created
+// before overlaps were rejected, the one created last.
+func (s *UnblockingService) Effective(t time.Time, category models.Category, role string) (*models.EffectiveUnblocking, error) {
+	windows, err := s.OpenWindows(t)
+	if err != nil {
+		return nil, err
+	}
+	var covering []models.Unblocking
+	for _, window := range windows {
+		if window.AppliesTo(category, role) {
+			covering = append(covering, window)
+		}
+	}
+
+	effective := &models.EffectiveUnblocking{
+		At:       t.In(utils.LabLocation),
+		Kategori: category,
+		Role:     role,
+		Covering: covering,
+	}
+	switch len(covering) {
+	case 0:
+		next, err := s.NextWindow(t, category, role)
+		if err != nil {
+			return nil, err
+		}
+		effective.Next = next
+		if next == nil {
+			effective.Reason = "no unblocking window that applies is scheduled"
+		} else {
+			effective.Reason = fmt.Sprintf("no window that applies covers this moment; window %d (%s) opens at %s",
+				next.ID, next.Scope(), utils.InLabTime(next.StartDate).Format(time.RFC3339))
+		}
+	case 1:
+		effective.Open = true
+		effective.Window = &covering[0]
+		effective.Reason = fmt.Sprintf("window %d (%s) covers this moment", covering[0].ID, covering[0].Scope())
+	default:
+		effective.Open = true
+		effective.Window = &covering[0]
+		effective.Reason = fmt.Sprintf("%d windows cover this moment; window %d (%s) is the most specific and was created last",
+			len(covering), covering[0].ID, covering[0].Scope())
+	}
+	return effective, nil
+}
+
+// NextWindow returns the unblocking window for category and role that is
+// open at t or, when none is, the next one to open. Empty values stand for
+// any category or role. It returns nil when no window is scheduled.
+func (s *UnblockingService) NextWindow(t time.Time, category models.Category, role string) (*models.Unblocking, error) {
+	query := s.db.Where("end_date >= ?", utils.LabWallClock(t))
+	if category != "" {
+		query = query.Where("kategori IS NULL OR kategori = ?", category)
+	}
+	if role != "" {
+		query = query.Where("role IS NULL OR role = ?", role)
+	}
+
+	var unblocking models.Unblocking
+	err := query.Order("start_date ASC, id ASC").First(&unblocking).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &unblocking, nil
+}
+
+// RecordTransition publishes that window opened or closed. Every instance's
+// scheduler notices the same transition, so the event ID is derived from the
+// window and subscribers drop the copies.
+func (s *UnblockingService) RecordTransition(opened bool, window *models.Unblocking) error {
+	eventType := models.DomainUnblockClosed
+	if opened {
+		eventType = models.DomainUnblockOpened
+	}
+	start := utils.InLabTime(window.StartDate)
+	end := utils.InLabTime(window.EndDate)
+	name := fmt.Sprintf("%s:%d:%s:%s", eventType, window.ID, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	id := uuid.NewSHA1(unblockEventNamespace, []byte(name)).String()
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return enqueueEventWithID(tx, id, eventType, map[string]interface{}{
+			"windowId":  window.ID,
+			"tahun":     window.Tahun,
+			"semester":  window.Semester,
+			"kategori":  window.Kategori,
+			"role":      window.Role,
+			"startDate": start,
+			"endDate":   end,
+		})
+	})
+}
+
+// unblockEventNamespace scopes the derived IDs of unblock events
+var unblockEventNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://ketuk/events/unblock"))
@@ -18,10 +18,25 @@ func NewUserService(db *gorm.DB) *UserService {
 	}
 }
 
-func (s *UserService) GetAll() ([]models.User, error) {
+// userListSpec lists the filters and sort keys of the user list
+var userListSpec = listSpec{
+	filters: map[string]listFilter{
+		"role": enumFilter("role", "admin", "user"),
+	},
+	sorts: map[string]string{
+		"name":       "full_name",
+		"email":      "email",
+		"role":       "role",
+		"created_at": "created_at",
+	},
+	defaultSort: "id",
+	dateColumn:  "created_at",
+}
+
+func (s *UserService) GetAll(q models.ListQuery) ([]models.User, *models.PageMeta, error) {
 	var users []models.User
-	result := s.db.Find(&users)
-	return users, result.Error
+	meta, err := listPage(s.db.Model(&models.User{}), userListSpec, q, &users)
+	return users, meta, err
 }
 
 func (s *UserService) GetByID(id uint) (*models.User, error) {
@@ -45,9 +60,14 @@ func (s *UserService) Create(user *models.User) (*models.User, error) {
 		user.Role = "user"
 	}
 
-	result := s.db.Create(user)
-	if result.Error != nil {
-		return nil, result.Error
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return enqueueEvent(tx, models.DomainUserCreated, userCreatedEvent(user))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return user, nil
@@ -83,6 +103,9 @@ func (s *UserService) Update(id uint, req models.UpdateUserRequest) (*models.Use
 	if req.Role != "" {
 		updates["role"] = req.Role
 	}
+	if req.Locale != "" {
+		updates["locale"] = req.Locale
+	}
 
 	if len(updates) > 0 {
 		result := s.db.Model(&user).Updates(updates)
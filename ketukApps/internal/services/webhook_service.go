@@ -0,0 +1,426 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ketukApps/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Webhook relay settings. Failed deliveries are retried with the outbox
+// backoff and given up on after webhookMaxAttempts. A claimed delivery is not
+// due again for webhookClaimTimeout, which outlasts a batch of timeouts.
+const (
+	webhookBatchSize       = 20
+	webhookMaxAttempts     = 8
+	webhookTimeout         = 10 * time.Second
+	webhookClaimTimeout    = 10 * time.Minute
+	webhookMaxResponseBody = 2048
+)
+
+// Headers sent with every webhook delivery. The signature is the hex
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription secret.
+const (
+	WebhookEventHeader     = "X-Ketuk-Event"
+	WebhookDeliveryHeader  = "X-Ketuk-Delivery"
+	WebhookTimestampHeader = "X-Ketuk-Timestamp"
+	WebhookSignatureHeader = "X-Ketuk-Signature"
+)
+
+// SignWebhook returns the signature header value of body sent at timestamp
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// enqueueWebhooks writes a delivery of event for every active subscription
+// to its type, using tx so nothing is sent for a rolled back change
+func enqueueWebhooks(tx *gorm.DB, event models.DomainEvent, payload string) error {
+	if !isWebhookEventType(event.Type) {
+		return nil
+	}
+
+	match, err := json.Marshal([]models.DomainEventType{event.Type})
+	if err != nil {
+		return err
+	}
+	var subscriptionIDs []int
+	err = tx.Model(&models.WebhookSubscription{}).
+		Where("is_active AND event_types @> ?::jsonb", string(match)).
+		Pluck("id", &subscriptionIDs).Error
+	if err != nil {
+		return fmt.Errorf("failed to look up webhook subscriptions: %w", err)
+	}
+
+	for _, id := range subscriptionIDs {
+		delivery := models.WebhookDelivery{
+			SubscriptionID: id,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         models.WebhookPending,
+			NextAttemptAt:  event.OccurredAt,
+		}
+		if err := tx.Create(&delivery).Error; err != nil {
+			return fmt.Errorf("failed to write webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+func isWebhookEventType(eventType models.DomainEventType) bool {
+	for _, t := range models.WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookService struct {
+	db     *gorm.DB
+	client *http.Client
+}
+
+func NewWebhookService(db *gorm.DB) *WebhookService {
+	return &WebhookService{
+		db:     db,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// GetAll returns every webhook subscription
+func (s *WebhookService) GetAll() ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	result := s.db.Order("id").Find(&subscriptions)
+	return subscriptions, result.Error
+}
+
+// GetByID returns a webhook subscription by its ID
+func (s *WebhookService) GetByID(id int) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	result := s.db.First(&subscription, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, errors.New("webhook subscription not found")
+	}
+	return &subscription, result.Error
+}
+
+// Create subscribes a URL to events. The secret is generated when the request
+// has none and is only returned here.
+func (s *WebhookService) Create(createdBy uint, req models.CreateWebhookSubscriptionRequest) (*models.WebhookSubscriptionWithSecret, error) {
+	subscription := models.WebhookSubscription{
+		URL:         strings.TrimSpace(req.URL),
+		Description: strings.TrimSpace(req.Description),
+		EventTypes:  req.EventTypes,
+		Secret:      req.Secret,
+		IsActive:    true,
+		CreatedBy:   &createdBy,
+	}
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+	if subscription.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+	if err := validateWebhookSubscription(&subscription); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(&subscription).Error; err != nil {
+		return nil, err
+	}
+	return &models.WebhookSubscriptionWithSecret{WebhookSubscription: subscription, Secret: subscription.Secret}, nil
+}
+
+// Update updates a webhook subscription. The new secret is returned only when
+// it was rotated.
+func (s *WebhookService) Update(id int, req models.UpdateWebhookSubscriptionRequest) (*models.WebhookSubscriptionWithSecret, error) {
+	subscription, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Description != nil {
+		subscription.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = req.EventTypes
+	}
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+	if req.RotateSecret {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+	if err := validateWebhookSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Save(subscription).Error; err != nil {
+		return nil, err
+	}
+	result := &models.WebhookSubscriptionWithSecret{WebhookSubscription: *subscription}
+	if req.RotateSecret {
+		result.Secret = subscription.Secret
+	}
+	return result, nil
+}
+
+// Delete removes a webhook subscription and its delivery log
+func (s *WebhookService) Delete(id int) error {
+	result := s.db.Delete(&models.WebhookSubscription{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("webhook subscription not found")
+	}
+	return nil
+}
+
+// webhookDeliveryListSpec lists the filters and sort keys of delivery lists
+var webhookDeliveryListSpec = listSpec{
+	filters: map[string]listFilter{
+		"status":    enumFilter("status", models.WebhookPending, models.WebhookSucceeded, models.WebhookFailed),
+		"eventType": enumFilter("event_type", models.WebhookEventTypes...),
+		"eventId":   textFilter("event_id"),
+	},
+	sorts: map[string]string{
+		"createdAt": "created_at",
+	},
+	defaultSort: "-id",
+	dateColumn:  "created_at",
+}
+
+// GetDeliveries returns a page of the delivery log of a subscription, newest first
+func (s *WebhookService) GetDeliveries(subscriptionID int, q models.ListQuery) ([]models.WebhookDelivery, *models.PageMeta, error) {
+	if _, err := s.GetByID(subscriptionID); err != nil {
+		return nil, nil, err
+	}
+	var deliveries []models.WebhookDelivery
+	meta, err := listPage(s.db.Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID), webhookDeliveryListSpec, q, &deliveries)
+	return deliveries, meta, err
+}
+
+// Redeliver queues the event of a delivery to be sent again. The original
+// delivery is kept in the log; the new one points back to it.
+func (s *WebhookService) Redeliver(subscriptionID int, deliveryID int64) (*models.WebhookDelivery, error) {
+	var original models.WebhookDelivery
+	err := s.db.Where("id = ? AND subscription_id = ?", deliveryID, subscriptionID).First(&original).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook delivery not found")
+		}
+		return nil, err
+	}
+
+	delivery := models.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         models.WebhookPending,
+		RedeliveryOf:   &original.ID,
+		NextAttemptAt:  time.Now(),
+	}
+	if err := s.db.Create(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// DeliverPending posts the webhook deliveries that are due and returns how
+// many succeeded. Deliveries of inactive subscriptions wait until the
+// subscription is activated again. Deliveries are claimed first, so no
+// transaction stays open while they are posted, and each result is recorded
+// on its own.
+func (s *WebhookService) DeliverPending(ctx context.Context) (int, error) {
+	deliveries, err := s.claimPending(ctx)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	subscriptionIDs := make([]int, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		subscriptionIDs = append(subscriptionIDs, delivery.SubscriptionID)
+	}
+	var subscriptions []models.WebhookSubscription
+	if err := s.db.WithContext(ctx).Where("id IN ?", subscriptionIDs).Find(&subscriptions).Error; err != nil {
+		return 0, err
+	}
+	byID := make(map[int]*models.WebhookSubscription, len(subscriptions))
+	for i := range subscriptions {
+		byID[subscriptions[i].ID] = &subscriptions[i]
+	}
+
+	succeeded := 0
+	for _, delivery := range deliveries {
+		subscription, ok := byID[delivery.SubscriptionID]
+		if !ok {
+			// Deleted since it was claimed, together with its deliveries
+			continue
+		}
+
+		started := time.Now()
+		code, body, err := s.post(ctx, subscription, delivery)
+		duration := int(time.Since(started).Milliseconds())
+
+		updates := map[string]interface{}{
+			"duration_ms":   duration,
+			"response_code": code,
+			"response_body": body,
+		}
+		if err != nil {
+			errMsg := err.Error()
+			updates["last_error"] = errMsg
+			if delivery.Attempts >= webhookMaxAttempts {
+				updates["status"] = models.WebhookFailed
+				log.Printf("Giving up on webhook delivery #%d (%s to %s) after %d attempts: %s",
+					delivery.ID, delivery.EventType, subscription.URL, delivery.Attempts, errMsg)
+			} else {
+				updates["next_attempt_at"] = time.Now().Add(outboxBackoff(delivery.Attempts))
+				log.Printf("Failed to deliver webhook #%d (%s to %s): %s",
+					delivery.ID, delivery.EventType, subscription.URL, errMsg)
+			}
+		} else {
+			updates["status"] = models.WebhookSucceeded
+			updates["last_error"] = nil
+			updates["delivered_at"] = time.Now()
+			succeeded++
+		}
+
+		if err := s.db.WithContext(ctx).Model(&delivery).Updates(updates).Error; err != nil {
+			log.Printf("Failed to record webhook delivery #%d: %s", delivery.ID, err)
+		}
+	}
+	return succeeded, nil
+}
+
+// claimPending counts an attempt for the deliveries of active subscriptions
+// that are due and moves their next attempt past webhookClaimTimeout in a
+// single statement. Rows other relays are claiming are skipped. The
+// deliveries are returned as updated.
+func (s *WebhookService) claimPending(ctx context.Context) ([]models.WebhookDelivery, error) {
+	due := s.db.Model(&models.WebhookDelivery{}).
+		Select("id").
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookPending, time.Now()).
+		Where("subscription_id IN (SELECT id FROM webhook_subscriptions WHERE is_active)").
+		Order("id").
+		Limit(webhookBatchSize)
+
+	var deliveries []models.WebhookDelivery
+	err := s.db.WithContext(ctx).Model(&deliveries).
+		Clauses(clause.Returning{}).
+		Where("id IN (?)", due).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": time.Now().Add(webhookClaimTimeout),
+		}).Error
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+	return deliveries, nil
+}
+
+// post sends a delivery to its subscription. It returns the response code and
+// the start of the response body, which are nil when no response came back.
+func (s *WebhookService) post(ctx context.Context, subscription *models.WebhookSubscription, delivery models.WebhookDelivery) (*int, *string, error) {
+	payload := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Ketuk-Webhook/1")
+	req.Header.Set(WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(subscription.Secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	code := resp.StatusCode
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseBody))
+	body := strings.ToValidUTF8(string(raw), "")
+	if code < 200 || code >= 300 {
+		return &code, &body, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return &code, &body, nil
+}
+
+// validateWebhookSubscription checks the URL and event types
+func validateWebhookSubscription(subscription *models.WebhookSubscription) error {
+	target, err := url.Parse(subscription.URL)
+	if err != nil || target.Host == "" || (target.Scheme != "http" && target.Scheme != "https") {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if len(subscription.EventTypes) == 0 {
+		return errors.New("at least one event type is required")
+	}
+	seen := make(map[models.DomainEventType]bool, len(subscription.EventTypes))
+	eventTypes := subscription.EventTypes[:0]
+	for _, eventType := range subscription.EventTypes {
+		if !isWebhookEventType(eventType) {
+			names := make([]string, len(models.WebhookEventTypes))
+			for i, t := range models.WebhookEventTypes {
+				names[i] = string(t)
+			}
+			return fmt.Errorf("event type %q is not one of %s", eventType, strings.Join(names, ", "))
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	subscription.EventTypes = eventTypes
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,137 @@
+package stream
+
+import (
+	"sync"
+
+	"ketukApps/internal/models"
+)
+
+// RoutingKeys are the topic exchange bindings the stream needs
+var RoutingKeys = []string{
+	string(models.DomainTicketCreated),
+	string(models.DomainTicketStatusChanged),
+	"unblock.*",
+}
+
+// subscriberBuffer is how many events a client may lag behind before it is
+// disconnected; the client reconnects and refetches what it missed
+const subscriberBuffer = 32
+
+// seenEvents is how many event IDs are remembered to drop duplicates
+const seenEvents = 1024
+
+// Subscriber is one connected client
+type Subscriber struct {
+	UserID uint
+	Role   string
+	events chan models.DomainEvent
+}
+
+// Events delivers the events the subscriber may see. It is closed when the
+// subscriber is removed from the hub.
+func (s *Subscriber) Events() <-chan models.DomainEvent {
+	return s.events
+}
+
+// Hub fans the events consumed by this instance out to its connected clients
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[*Subscriber]struct{}
+	seen        map[string]struct{}
+	seenOrder   []string
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[*Subscriber]struct{}),
+		seen:        make(map[string]struct{}),
+	}
+}
+
+// Subscribe connects a client
+func (h *Hub) Subscribe(userID uint, role string) *Subscriber {
+	s := &Subscriber{
+		UserID: userID,
+		Role:   role,
+		events: make(chan models.DomainEvent, subscriberBuffer),
+	}
+	h.mu.Lock()
+	h.subscribers[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+// Unsubscribe disconnects a client and closes its events channel
+func (h *Hub) Unsubscribe(s *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(s)
+}
+
+func (h *Hub) remove(s *Subscriber) {
+	if _, ok := h.subscribers[s]; ok {
+		delete(h.subscribers, s)
+		close(s.events)
+	}
+}
+
+// Clients returns how many clients are connected
+func (h *Hub) Clients() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+// Publish sends event to every subscriber allowed to see it. Events seen
+// before are dropped, and subscribers that cannot keep up are disconnected.
+func (h *Hub) Publish(event models.DomainEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if event.ID != "" {
+		if _, ok := h.seen[event.ID]; ok {
+			return
+		}
+		h.seen[event.ID] = struct{}{}
+		h.seenOrder = append(h.seenOrder, event.ID)
+		if len(h.seenOrder) > seenEvents {
+			delete(h.seen, h.seenOrder[0])
+			h.seenOrder = h.seenOrder[1:]
+		}
+	}
+
+	for s := range h.subscribers {
+		if !visibleTo(event, s) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			h.remove(s)
+		}
+	}
+}
+
+// visibleTo decides who sees an event: ticket owners see their tickets
+// change status, admins see new pending tickets and everyone sees the
+// unblocking windows for their role open and close
+func visibleTo(event models.DomainEvent, s *Subscriber) bool {
+	switch event.Type {
+	case models.DomainTicketStatusChanged:
+		return dataUint(event.Data, "userId") == s.UserID
+	case models.DomainTicketCreated:
+		return s.Role == "admin" && event.Data["status"] == string(models.StatusPending)
+	case models.DomainUnblockOpened, models.DomainUnblockClosed:
+		role, _ := event.Data["role"].(string)
+		return role == "" || role == s.Role
+	}
+	return false
+}
+
+// dataUint reads a numeric field of decoded event data
+func dataUint(data map[string]interface{}, key string) uint {
+	if n, ok := data[key].(float64); ok && n > 0 {
+		return uint(n)
+	}
+	return 0
+}
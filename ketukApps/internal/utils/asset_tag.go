@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+// AssetTagPrefix starts every printed asset tag
+const AssetTagPrefix = "KTK-"
+
+// AssetTag returns the printed tag of an item, e.g. KTK-000012
+func AssetTag(itemID int) string {
+	return fmt.Sprintf("%s%06d", AssetTagPrefix, itemID)
+}
+
+// ParseAssetTag returns the item ID of a scanned label. It accepts the tag
+// itself, a bare item ID, or the lookup URL encoded in the QR code.
+func ParseAssetTag(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if i := strings.LastIndex(value, "/"); i >= 0 {
+		value = value[i+1:]
+	}
+	if len(value) >= len(AssetTagPrefix) && strings.EqualFold(value[:len(AssetTagPrefix)], AssetTagPrefix) {
+		value = value[len(AssetTagPrefix):]
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return 0, errors.New("invalid asset tag")
+	}
+	return id, nil
+}
+
+// QRCodePNG renders content as a square PNG QR code of size pixels
+func QRCodePNG(content string, size int) ([]byte, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	return code.PNG(size)
+}
+
+// QRCodeSVG renders content as a square SVG QR code of size pixels. Each row
+// of dark modules is drawn as horizontal runs so the output stays small.
+func QRCodeSVG(content string, size int) ([]byte, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	bitmap := code.Bitmap()
+	modules := len(bitmap)
+
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, modules, modules)
+	fmt.Fprintf(&buf, `<path fill="#000" d="%s"/>`, path.String())
+	buf.WriteString("</svg>\n")
+	return buf.Bytes(), nil
+}
+
+// AssetLabel is one label on a printable sheet
+type AssetLabel struct {
+	Tag      string
+	Title    string
+	Subtitle string
+	Content  string
+}
+
+// Label sheet geometry in millimetres: 3 x 8 labels of 70 x 37 on A4
+const (
+	labelColumns = 3
+	labelRows    = 8
+	labelWidth   = 70.0
+	labelHeight  = 37.0
+	labelPadding = 3.0
+	labelMarginY = (297.0 - labelRows*labelHeight) / 2
+)
+
+// AssetLabelSheet renders labels as an A4 PDF with 24 labels per page. Every
+// label shows its QR code next to the tag, title and subtitle.
+func AssetLabelSheet(title string, labels []AssetLabel) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("Ketuk", true)
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	qrSize := labelHeight - 2*labelPadding
+	textX := labelPadding + qrSize + labelPadding
+	textWidth := labelWidth - textX - labelPadding
+
+	for i, label := range labels {
+		slot := i % (labelColumns * labelRows)
+		if slot == 0 {
+			pdf.AddPage()
+		}
+		x := float64(slot%labelColumns) * labelWidth
+		y := labelMarginY + float64(slot/labelColumns)*labelHeight
+
+		png, err := QRCodePNG(label.Content, 256)
+		if err != nil {
+			return nil, fmt.Errorf("label %s: %w", label.Tag, err)
+		}
+		name := "qr-" + label.Tag
+		pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+		pdf.ImageOptions(name, x+labelPadding, y+labelPadding, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+		pdf.SetXY(x+textX, y+labelPadding+2)
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(textWidth, 6, label.Tag, "", 2, "L", false, 0, "")
+		pdf.SetX(x + textX)
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.MultiCell(textWidth, 4.5, tr(label.Title), "", "L", false)
+		pdf.SetX(x + textX)
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.MultiCell(textWidth, 3.5, tr(label.Subtitle), "", "L", false)
+		pdf.SetTextColor(0, 0, 0)
+	}
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
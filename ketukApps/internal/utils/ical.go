@@ -0,0 +1,326 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// LabTimeZone is the IANA name of the time zone schedules are entered in
+const LabTimeZone = "Asia/Jakarta"
+
+// LabLocation is the lab's local time zone. Schedule timestamps are stored
+// without a zone and hold wall-clock times in this location.
+var LabLocation = loadLabLocation()
+
+func loadLabLocation() *time.Location {
+	loc, err := time.LoadLocation(LabTimeZone)
+	if err != nil {
+		// tzdata is missing in minimal images; Indonesia has no DST
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
+// LabWallClock converts t to the lab time zone and returns that wall-clock
+// time in the form schedules are stored in
+func LabWallClock(t time.Time) time.Time {
+	t = t.In(LabLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// InLabTime reinterprets the wall clock of a stored schedule timestamp in the lab time zone
+func InLabTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, LabLocation)
+}
+
+// ICalEvent is a single VEVENT. Start, End and ExDates are wall-clock times
+// in the lab time zone.
+type ICalEvent struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	RRule        string
+	ExDates      []time.Time
+	LastModified time.Time
+}
+
+// ICalendar renders a VCALENDAR with its events
+type ICalendar struct {
+	Name   string
+	Events []ICalEvent
+}
+
+// String renders the calendar as RFC 5545 text with CRLF line endings
+func (c *ICalendar) String() string {
+	var b strings.Builder
+	w := func(line string) {
+		b.WriteString(foldICalLine(line))
+		b.WriteString("\r\n")
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	w("BEGIN:VCALENDAR")
+	w("VERSION:2.0")
+	w("PRODID:-//Ketuk//Lab Schedule//ID")
+	w("CALSCALE:GREGORIAN")
+	w("METHOD:PUBLISH")
+	w("X-WR-CALNAME:" + escapeICalText(c.Name))
+	w("X-WR-TIMEZONE:" + LabTimeZone)
+
+	w("BEGIN:VTIMEZONE")
+	w("TZID:" + LabTimeZone)
+	w("BEGIN:STANDARD")
+	w("DTSTART:19700101T000000")
+	w("TZOFFSETFROM:+0700")
+	w("TZOFFSETTO:+0700")
+	w("TZNAME:WIB")
+	w("END:STANDARD")
+	w("END:VTIMEZONE")
+
+	for _, e := range c.Events {
+		w("BEGIN:VEVENT")
+		w("UID:" + e.UID)
+		w("DTSTAMP:" + stamp)
+		w("DTSTART;TZID=" + LabTimeZone + ":" + formatICalLocal(e.Start))
+		w("DTEND;TZID=" + LabTimeZone + ":" + formatICalLocal(e.End))
+		w("SUMMARY:" + escapeICalText(e.Summary))
+		if e.Description != "" {
+			w("DESCRIPTION:" + escapeICalText(e.Description))
+		}
+		if e.Location != "" {
+			w("LOCATION:" + escapeICalText(e.Location))
+		}
+		if e.RRule != "" {
+			w("RRULE:" + e.RRule)
+		}
+		if len(e.ExDates) > 0 {
+			exDates := make([]string, 0, len(e.ExDates))
+			for _, exDate := range e.ExDates {
+				exDates = append(exDates, formatICalLocal(exDate))
+			}
+			w("EXDATE;TZID=" + LabTimeZone + ":" + strings.Join(exDates, ","))
+		}
+		if !e.LastModified.IsZero() {
+			w("LAST-MODIFIED:" + e.LastModified.UTC().Format("20060102T150405Z"))
+		}
+		w("END:VEVENT")
+	}
+
+	w("END:VCALENDAR")
+	return b.String()
+}
+
+func formatICalLocal(t time.Time) string {
+	return t.Format("20060102T150405")
+}
+
+var icalTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeICalText(value string) string {
+	return icalTextEscaper.Replace(value)
+}
+
+// foldICalLine splits lines longer than 75 octets without breaking UTF-8 sequences
+func foldICalLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			// the leading space counts towards the next line
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+var icalTextUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, ";",
+	`\,`, ",",
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+// UnescapeICalText reverses the TEXT escaping of RFC 5545
+func UnescapeICalText(value string) string {
+	return icalTextUnescaper.Replace(value)
+}
+
+// ICalProperty is a single content line of a parsed component
+type ICalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Time parses the property as DATE or DATE-TIME, honouring its TZID.
+// Floating times and unknown zones are read in the lab time zone.
+func (p ICalProperty) Time() (time.Time, error) {
+	return p.parseTime(p.Value)
+}
+
+// Times parses a comma-separated list of DATE or DATE-TIME values such as EXDATE
+func (p ICalProperty) Times() ([]time.Time, error) {
+	var times []time.Time
+	for _, value := range strings.Split(p.Value, ",") {
+		t, err := p.parseTime(value)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+func (p ICalProperty) parseTime(value string) (time.Time, error) {
+	loc := LabLocation
+	if tzid := p.Params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+	t, err := ParseICalTime(value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s value %q", p.Name, value)
+	}
+	return t, nil
+}
+
+// ICalComponent is a parsed VEVENT. Line is where BEGIN:VEVENT appears.
+type ICalComponent struct {
+	Line       int
+	Properties []ICalProperty
+}
+
+// Get returns the first property with the given name
+func (c ICalComponent) Get(name string) (ICalProperty, bool) {
+	for _, prop := range c.Properties {
+		if prop.Name == name {
+			return prop, true
+		}
+	}
+	return ICalProperty{}, false
+}
+
+// All returns every property with the given name
+func (c ICalComponent) All(name string) []ICalProperty {
+	var props []ICalProperty
+	for _, prop := range c.Properties {
+		if prop.Name == name {
+			props = append(props, prop)
+		}
+	}
+	return props
+}
+
+// ParseICalEvents reads the VEVENT components of an iCalendar stream.
+// Components nested in an event (such as VALARM) are skipped.
+func ParseICalEvents(r io.Reader) ([]ICalComponent, error) {
+	type contentLine struct {
+		number int
+		text   string
+	}
+
+	// Unfold continuation lines first
+	var lines []contentLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text != "" {
+			lines = append(lines, contentLine{number: number, text: text})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0].text, "BEGIN:VCALENDAR") {
+		return nil, errors.New("not an iCalendar file: missing BEGIN:VCALENDAR")
+	}
+
+	var events []ICalComponent
+	var current *ICalComponent
+	depth := 0
+	for _, line := range lines {
+		prop, err := parseICalLine(line.text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		switch {
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VEVENT") && current == nil:
+			current = &ICalComponent{Line: line.number}
+		case current != nil && prop.Name == "BEGIN":
+			depth++
+		case current != nil && prop.Name == "END" && depth > 0:
+			depth--
+		case current != nil && prop.Name == "END" && strings.EqualFold(prop.Value, "VEVENT"):
+			events = append(events, *current)
+			current = nil
+		case current != nil && depth == 0:
+			current.Properties = append(current.Properties, prop)
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("line %d: VEVENT is not closed", current.Line)
+	}
+	return events, nil
+}
+
+// parseICalLine splits "NAME;PARAM=value:VALUE" into its parts
+func parseICalLine(line string) (ICalProperty, error) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return ICalProperty{}, fmt.Errorf("invalid content line %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	prop := ICalProperty{
+		Name:   strings.ToUpper(parts[0]),
+		Params: make(map[string]string),
+		Value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		prop.Params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return prop, nil
+}
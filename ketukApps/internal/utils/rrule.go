@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRRuleWeeks caps expansion of a series so a bad rule can't loop forever
+const maxRRuleWeeks = 52 * 10
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RRule is the subset of an RFC 5545 recurrence rule supported for regular
+// schedules: FREQ=WEEKLY with optional INTERVAL, BYDAY, UNTIL or COUNT.
+type RRule struct {
+	Interval int
+	ByDay    []time.Weekday
+	Until    *time.Time
+	Count    int
+}
+
+// ParseRRule parses a rule such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=16".
+// A leading "RRULE:" is accepted.
+func ParseRRule(value string) (*RRule, error) {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "RRULE:"))
+	if value == "" {
+		return nil, errors.New("rrule is empty")
+	}
+
+	rule := &RRule{Interval: 1}
+	hasFreq := false
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			if strings.ToUpper(val) != "WEEKLY" {
+				return nil, fmt.Errorf("unsupported rrule frequency %q, only WEEKLY is supported", val)
+			}
+			hasFreq = true
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid rrule interval %q", val)
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			seen := make(map[time.Weekday]bool)
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := rruleWeekdays[strings.ToUpper(strings.TrimSpace(day))]
+				if !ok {
+					return nil, fmt.Errorf("invalid rrule weekday %q", day)
+				}
+				if !seen[weekday] {
+					seen[weekday] = true
+					rule.ByDay = append(rule.ByDay, weekday)
+				}
+			}
+		case "UNTIL":
+			until, err := ParseICalTime(val, time.UTC)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rrule until %q", val)
+			}
+			rule.Until = &until
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid rrule count %q", val)
+			}
+			rule.Count = count
+		case "WKST":
+			// Weeks always start on Monday here, which is the RFC default
+		default:
+			return nil, fmt.Errorf("unsupported rrule part %q", key)
+		}
+	}
+
+	if !hasFreq {
+		return nil, errors.New("rrule FREQ is required")
+	}
+	if rule.Until != nil && rule.Count > 0 {
+		return nil, errors.New("rrule cannot contain both UNTIL and COUNT")
+	}
+
+	sort.Slice(rule.ByDay, func(i, j int) bool {
+		return weekdayOffset(rule.ByDay[i]) < weekdayOffset(rule.ByDay[j])
+	})
+	return rule, nil
+}
+
+// Bounded reports whether the rule ends on its own through UNTIL or COUNT
+func (r *RRule) Bounded() bool {
+	return r.Until != nil || r.Count > 0
+}
+
+// String formats the rule back into RFC 5545 form
+func (r *RRule) String() string {
+	parts := []string{"FREQ=WEEKLY"}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			for code, wd := range rruleWeekdays {
+				if wd == weekday {
+					days = append(days, code)
+					break
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns the start times of the series beginning at dtstart
+// that fall within [from, to). COUNT is applied before exception dates are
+// removed, as RFC 5545 requires. An exception date removes the occurrence on
+// the same calendar day.
+func (r *RRule) Occurrences(dtstart, from, to time.Time, exDates []time.Time) []time.Time {
+	days := r.ByDay
+	if len(days) == 0 {
+		days = []time.Weekday{dtstart.Weekday()}
+	}
+
+	excluded := make(map[string]bool, len(exDates))
+	for _, exDate := range exDates {
+		excluded[exDate.In(dtstart.Location()).Format("2006-01-02")] = true
+	}
+
+	weekStart := dtstart.AddDate(0, 0, -weekdayOffset(dtstart.Weekday()))
+	var occurrences []time.Time
+	generated := 0
+	for week := 0; week <= maxRRuleWeeks; week += r.Interval {
+		base := weekStart.AddDate(0, 0, 7*week)
+		for _, weekday := range days {
+			occurrence := base.AddDate(0, 0, weekdayOffset(weekday))
+			if occurrence.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && occurrence.After(*r.Until) {
+				return occurrences
+			}
+			if r.Count > 0 && generated >= r.Count {
+				return occurrences
+			}
+			generated++
+			if !occurrence.Before(to) {
+				return occurrences
+			}
+			if occurrence.Before(from) || excluded[occurrence.Format("2006-01-02")] {
+				continue
+			}
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
+
+// weekdayOffset returns the number of days since Monday
+func weekdayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// ParseICalTime parses an iCalendar DATE or DATE-TIME value. Values without
+// a trailing Z are interpreted in loc.
+func ParseICalTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse("20060102T150405Z", value)
+	case strings.Contains(value, "T"):
+		return time.ParseInLocation("20060102T150405", value, loc)
+	default:
+		return time.ParseInLocation("20060102", value, loc)
+	}
+}
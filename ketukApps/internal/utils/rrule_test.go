@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{name: "normalised", value: "RRULE:FREQ=weekly;BYDAY=we,MO,mo;COUNT=3", want: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3"},
+		{name: "interval and until", value: "FREQ=WEEKLY;INTERVAL=2;UNTIL=20250630T170000Z;WKST=MO", want: "FREQ=WEEKLY;INTERVAL=2;UNTIL=20250630T170000Z"},
+		{name: "date until", value: "FREQ=WEEKLY;UNTIL=20250630", want: "FREQ=WEEKLY;UNTIL=20250630T000000Z"},
+		{name: "empty", value: " ", wantErr: "rrule is empty"},
+		{name: "missing freq", value: "BYDAY=MO;COUNT=3", wantErr: "rrule FREQ is required"},
+		{name: "daily", value: "FREQ=DAILY", wantErr: `unsupported rrule frequency "DAILY", only WEEKLY is supported`},
+		{name: "zero interval", value: "FREQ=WEEKLY;INTERVAL=0", wantErr: `invalid rrule interval "0"`},
+		{name: "bad weekday", value: "FREQ=WEEKLY;BYDAY=MO,XX", wantErr: `invalid rrule weekday "XX"`},
+		{name: "bad until", value: "FREQ=WEEKLY;UNTIL=2025-06-30", wantErr: `invalid rrule until "2025-06-30"`},
+		{name: "negative count", value: "FREQ=WEEKLY;COUNT=-1", wantErr: `invalid rrule count "-1"`},
+		{name: "until and count", value: "FREQ=WEEKLY;UNTIL=20250630T170000Z;COUNT=3", wantErr: "rrule cannot contain both UNTIL and COUNT"},
+		{name: "part without value", value: "FREQ=WEEKLY;COUNT", wantErr: `invalid rrule part "COUNT"`},
+		{name: "unsupported part", value: "FREQ=WEEKLY;BYMONTH=3", wantErr: `unsupported rrule part "BYMONTH"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRRuleOccurrences(t *testing.T) {
+	jakarta := LabLocation
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, time.March, day, hour, min, 0, 0, jakarta)
+	}
+	// Monday 3 March 2025, 08:00 WIB
+	dtstart := at(3, 8, 0)
+	from := at(1, 0, 0)
+	to := time.Date(2025, time.June, 1, 0, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		from    time.Time
+		to      time.Time
+		exDates []time.Time
+		want    []time.Time
+	}{
+		{
+			name: "count",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3",
+			want: []time.Time{at(3, 8, 0), at(5, 8, 0), at(10, 8, 0)},
+		},
+		{
+			name:    "count is applied before exdates",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3",
+			exDates: []time.Time{at(5, 0, 0)},
+			want:    []time.Time{at(3, 8, 0), at(10, 8, 0)},
+		},
+		{
+			name: "until includes an occurrence at that instant",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20250312T010000Z",
+			want: []time.Time{at(3, 8, 0), at(5, 8, 0), at(10, 8, 0), at(12, 8, 0)},
+		},
+		{
+			name: "until stops before a later occurrence",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20250312T005959Z",
+			want: []time.Time{at(3, 8, 0), at(5, 8, 0), at(10, 8, 0)},
+		},
+		{
+			name: "interval",
+			rule: "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			want: []time.Time{at(3, 8, 0), at(17, 8, 0), at(31, 8, 0)},
+		},
+		{
+			name: "window of an open-ended rule",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE",
+			from: at(10, 0, 0),
+			to:   at(17, 0, 0),
+			want: []time.Time{at(10, 8, 0), at(12, 8, 0)},
+		},
+		{
+			name:    "days before dtstart are skipped",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=2",
+			dtstart: at(5, 8, 0),
+			want:    []time.Time{at(5, 8, 0), at(10, 8, 0)},
+		},
+		{
+			// 23:30 WIB is still the previous day in UTC, so weekdays and
+			// exception dates must be taken in the series' own zone
+			name:    "byday near midnight in Asia/Jakarta",
+			rule:    "FREQ=WEEKLY;BYDAY=SU,MO;COUNT=4",
+			dtstart: at(9, 23, 30),
+			exDates: []time.Time{time.Date(2025, time.March, 10, 16, 30, 0, 0, time.UTC)},
+			want:    []time.Time{at(9, 23, 30), at(16, 23, 30), at(17, 23, 30)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			start, windowFrom, windowTo := dtstart, from, to
+			if !tt.dtstart.IsZero() {
+				start = tt.dtstart
+			}
+			if !tt.from.IsZero() {
+				windowFrom, windowTo = tt.from, tt.to
+			}
+
+			got := rule.Occurrences(start, windowFrom, windowTo, tt.exDates)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) || got[i].Weekday() != tt.want[i].Weekday() {
+					t.Errorf("occurrence %d: expected %s, got %s", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -3,18 +3,23 @@ package main
 import (
 	"fmt"
 	"log"
+	"net/smtp"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"gorm.io/gorm"
 
 	"ketukApps/config"
 	_ "ketukApps/docs" // docs is generated by Swag CLI
 	"ketukApps/internal/database"
 	"ketukApps/internal/handlers"
 	"ketukApps/internal/middleware"
+	"ketukApps/internal/models"
+	"ketukApps/internal/notify"
 	"ketukApps/internal/queue"
 	"ketukApps/internal/scheduler"
+	"ketukApps/internal/stream"
 	"ketukApps/internal/services"
 	"ketukApps/internal/utils"
 )
@@ -46,9 +51,6 @@ func main() {
 	// Set JWT secret
 	utils.SetJWTSecret(cfg.JWTSecret)
 
-	//set smpt gmail auth
-	GmailSmtpAuth := utils.SetSMTPAuth(cfg.SMTPGmail.Email, cfg.SMTPGmail.Password, cfg.SMTPGmail.Host)
-
 	// Initialize database
 	if err := database.Initialize(cfg); err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
@@ -63,22 +65,45 @@ func main() {
 	}
 	defer queue.CloseRabbitMQ()
 
+	// Initialize notification channels
+	notifier := setupNotifier(cfg, db)
+
 	// Initialize services
 	userService := services.NewUserService(db)
-	ticketService := services.NewTicketServiceWithEmail(db, &GmailSmtpAuth, cfg.SMTPGmail.Host, cfg.SMTPGmail.Email)
+	ticketService := services.NewTicketService(db)
 	scheduleService := services.NewScheduleService(db)
 	itemsService := services.NewItemService(db)
 	unblockingService := services.NewUnblockingService(db)
 	googleOAuthService := services.NewGoogleOAuthService(cfg)
 	auditService := services.NewAuditService(db)
-
-	// Start the worker with ticket service and schedule service
+	calendarService := services.NewCalendarService(db)
+	roomService := services.NewRoomService(db)
+	loanService := services.NewLoanService(db, ticketService)
+	searchService := services.NewSearchService(db)
+	notificationService := services.NewNotificationService(db)
+	bookingService := services.NewBookingService(db)
+	webhookService := services.NewWebhookService(db)
+
+	retryPolicy := queue.NewRetryPolicy(cfg)
+	bookingPublisher := queue.NewBookingPublisher(cfg.Queue.Name, retryPolicy)
+	outboxService := services.NewOutboxService(db, notifier, queue.NewEventPublisher(cfg.Queue.Exchanges.Topic), bookingPublisher)
+	closedWindow := queue.NewClosedWindow(cfg, unblockingService, notificationService, userService)
+
+	// Start the worker that books schedules requested through the queue
 	go func() {
-		if err := queue.SchduleWorker(cfg.Queue.Name, ticketService, scheduleService, &GmailSmtpAuth, cfg.SMTPGmail.Email); err != nil {
+		if err := queue.SchduleWorker(cfg.Queue.Name, ticketService, bookingService, retryPolicy, closedWindow); err != nil {
 			log.Fatalf("Failed to start schedule worker: %v", err)
 		}
 	}()
 
+	// Forward the events of every instance to the clients of the live stream
+	hub := stream.NewHub()
+	go func() {
+		if err := queue.StreamConsumer(cfg.Queue.Exchanges.Topic, hub); err != nil {
+			log.Printf("Stream consumer stopped: %v", err)
+		}
+	}()
+
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(db, googleOAuthService)
 	userHandler := handlers.NewUserHandler(userService)
@@ -87,9 +112,18 @@ func main() {
 	unblockingHandler := handlers.NewUnblockingHandler(unblockingService)
 	scheduleHandler := handlers.NewScheduleHandler(scheduleService)
 	auditHandler := handlers.NewAuditHandler(auditService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	roomHandler := handlers.NewRoomHandler(roomService)
+	loanHandler := handlers.NewLoanHandler(loanService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	queueHandler := handlers.NewQueueHandler(queue.NewDeadLetters(retryPolicy))
+	bookingHandler := handlers.NewBookingHandler(bookingService, bookingPublisher)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	streamHandler := handlers.NewStreamHandler(hub)
 
 	// Setup Gin router
-	router := setupRouter(authHandler, userHandler, tickets, items, unblockingHandler, scheduleHandler, auditHandler)
+	router := setupRouter(cfg, authHandler, userHandler, tickets, items, unblockingHandler, scheduleHandler, auditHandler, calendarHandler, roomHandler, loanHandler, searchHandler, notificationHandler, queueHandler, bookingHandler, webhookHandler, streamHandler)
 
 	// Setup Scheduler
 
@@ -102,6 +136,22 @@ func main() {
 	if err := scheduler.RegisterUnblockJob(); err != nil {
 		log.Fatalf("Failed to register unblock job: %v", err)
 	}
+	// Register overdue loan job
+	if err := scheduler.RegisterOverdueLoanJob(); err != nil {
+		log.Fatalf("Failed to register overdue loan job: %v", err)
+	}
+	// Register outbox relay job
+	if err := scheduler.RegisterOutboxRelayJob(outboxService); err != nil {
+		log.Fatalf("Failed to register outbox relay job: %v", err)
+	}
+	// Register webhook relay job
+	if err := scheduler.RegisterWebhookRelayJob(webhookService); err != nil {
+		log.Fatalf("Failed to register webhook relay job: %v", err)
+	}
+	// Register idempotency key cleanup job
+	if err := scheduler.RegisterIdempotencyCleanupJob(cfg.Schedule.IdempotencyRetentionDays); err != nil {
+		log.Fatalf("Failed to register idempotency cleanup job: %v", err)
+	}
 	scheduler.Start()
 
 	// Start server
@@ -114,15 +164,25 @@ func main() {
 	}
 }
 
-func setupRouter(authHandler *handlers.AuthHandler, userHandler *handlers.UserHandler, ticketHandler *handlers.TicketHandler, itemHandler *handlers.ItemHandler, unblockingHandler *handlers.UnblockingHandler, scheduleHandler *handlers.ScheduleHandler, auditHandler *handlers.AuditHandler) *gin.Engine {
+func setupRouter(cfg *config.Config, authHandler *handlers.AuthHandler, userHandler *handlers.UserHandler, ticketHandler *handlers.TicketHandler, itemHandler *handlers.ItemHandler, unblockingHandler *handlers.UnblockingHandler, scheduleHandler *handlers.ScheduleHandler, auditHandler *handlers.AuditHandler, calendarHandler *handlers.CalendarHandler, roomHandler *handlers.RoomHandler, loanHandler *handlers.LoanHandler, searchHandler *handlers.SearchHandler, notificationHandler *handlers.NotificationHandler, queueHandler *handlers.QueueHandler, bookingHandler *handlers.BookingHandler, webhookHandler *handlers.WebhookHandler, streamHandler *handlers.StreamHandler) *gin.Engine {
 	// Set Gin mode based on environment
 	gin.SetMode(gin.DebugMode) // Change to gin.DebugMode for development
 
 	// Create router with default middleware
 	router := gin.New()
 
+	// Only believe X-Forwarded-* headers from the configured proxies
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	publicOrigin, err := middleware.PublicOrigin(cfg.PublicURL, cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Invalid PUBLIC_URL or TRUSTED_PROXIES: %v", err)
+	}
+
 	// Add custom middleware
 	router.Use(middleware.Logger())
+	router.Use(publicOrigin)
 	router.Use(middleware.CORS())
 	router.Use(middleware.ErrorHandler())
 	router.Use(gin.Recovery())
@@ -149,6 +209,17 @@ func setupRouter(authHandler *handlers.AuthHandler, userHandler *handlers.UserHa
 			auth.GET("/v1/google/callback", authHandler.GoogleCallback)
 		}
 
+		// Calendar feeds (public) - calendar clients authenticate with the feed token in the URL
+		calendarFeeds := api.Group("/calendar/v1/feeds")
+		{
+			calendarFeeds.GET("/:token/user.ics", calendarHandler.GetUserFeed)
+			calendarFeeds.GET("/:token/lab.ics", calendarHandler.GetLabFeed)
+		}
+
+		// Live updates (Server-Sent Events). EventSource cannot set headers, so the
+		// token may also be passed as ?access_token=
+		api.GET("/stream/v1", middleware.TokenFromQuery(), middleware.AuthRequired(), middleware.RequireRole("admin", "user"), streamHandler.Stream)
+
 		// Protected routes - require authentication
 		protected := api.Group("")
 		protected.Use(middleware.AuthRequired())
@@ -169,7 +240,7 @@ func setupRouter(authHandler *handlers.AuthHandler, userHandler *handlers.UserHa
 				// All authenticated users can view and create tickets
 				tickets.GET("/v1", middleware.RequireRole("admin", "user"), middleware.CheckUnblockState(), ticketHandler.GetAllTickets)
 				tickets.GET("/v1/:id", middleware.RequireRole("admin", "user"), middleware.CheckUnblockState(), ticketHandler.GetTicketByID)
-				tickets.POST("/v1", middleware.RequireRole("admin", "user"), middleware.CheckUnblockState(), ticketHandler.CreateTicket)
+				tickets.POST("/v1", middleware.RequireRole("admin", "user"), middleware.CheckBookingWindow(), ticketHandler.CreateTicket)
 
 				// Only admin can update, delete, and change status
 				tickets.PUT("/v1/:id", middleware.RequireRole("admin"), middleware.CheckUnblockState(), ticketHandler.UpdateTicket)
@@ -178,17 +249,32 @@ func setupRouter(authHandler *handlers.AuthHandler, userHandler *handlers.UserHa
 				tickets.POST("/v1/bulk-status", middleware.RequireRole("admin"), middleware.CheckUnblockState(), ticketHandler.BulkUpdateStatus)
 			}
 
+			// Bookings endpoints - requests go through the schedule queue, which
+			// parks or rejects them while booking is closed
+			bookings := protected.Group("/bookings")
+			{
+				bookings.POST("/v1", middleware.RequireRole("admin", "user"), bookingHandler.CreateBooking)
+				bookings.GET("/v1/:id", middleware.RequireRole("admin", "user"), bookingHandler.GetBooking)
+			}
+
 			// Items endpoints
 			items := protected.Group("/items")
 			{
 				// Users can read, admin can do everything
 				items.GET("/v1", middleware.RequireRole("admin", "user"), itemHandler.GetAllItems)
+				items.GET("/v1/export", middleware.RequireRole("admin", "user"), itemHandler.ExportItems)
 				items.GET("/v1/:id", middleware.RequireRole("admin", "user"), itemHandler.GetItemByID)
 				items.GET("/v1/category/:category_id", middleware.RequireRole("admin", "user"), itemHandler.GetItemsByCategoryID)
+				items.GET("/v1/kondisi/:kondisi", middleware.RequireRole("admin", "user"), itemHandler.GetItemsByKondisi)
+				items.GET("/v1/:id/history", middleware.RequireRole("admin", "user"), itemHandler.GetItemKondisiHistory)
+				items.GET("/v1/:id/qr", middleware.RequireRole("admin", "user"), itemHandler.GetItemQRCode)
+				items.GET("/v1/lookup/:tag", middleware.RequireRole("admin", "user"), itemHandler.LookupItem)
 
 				// Admin only
 				items.POST("/v1", middleware.RequireRole("admin"), itemHandler.CreateItem)
+				items.POST("/v1/import", middleware.RequireRole("admin"), itemHandler.ImportItems)
 				items.PUT("/v1/:id", middleware.RequireRole("admin"), itemHandler.UpdateItem)
+				items.PATCH("/v1/:id/kondisi", middleware.RequireRole("admin"), itemHandler.ChangeItemKondisi)
 				items.DELETE("/v1/:id", middleware.RequireRole("admin"), itemHandler.DeleteItem)
 			}
 
@@ -198,6 +284,7 @@ func setupRouter(authHandler *handlers.AuthHandler, userHandler *handlers.UserHa
 				// Users can read, admin can do everything
 				ItemsCategory.GET("/v1", middleware.RequireRole("admin", "user"), itemHandler.GetAllItemCategories)
 				ItemsCategory.GET("/v1/:id", middleware.RequireRole("admin", "user"), itemHandler.GetItemCategoryByID)
+				ItemsCategory.GET("/v1/:id/labels", middleware.RequireRole("admin", "user"), itemHandler.GetItemCategoryLabels)
 
 				// Admin only
 				ItemsCategory.POST("/v1", middleware.RequireRole("admin"), itemHandler.CreateItemCategory)
@@ -210,6 +297,7 @@ func setupRouter(authHandler *handlers.AuthHandler, userHandler *handlers.UserHa
 			{
 				// All authenticated users can view and create unblocking requests
 				unblocking.GET("/v1", middleware.RequireRole("admin"), unblockingHandler.GetAllUnblockings)
+				unblocking.GET("/v1/effective", middleware.RequireRole("admin", "user"), unblockingHandler.GetEffectiveUnblocking)
 				unblocking.GET("/v1/:id", middleware.RequireRole("admin"), unblockingHandler.GetUnblockingByID)
 				unblocking.GET("/v1/user/:user_id", middleware.RequireRole("admin"), unblockingHandler.GetUnblockingsByUserID)
 				unblocking.POST("/v1", middleware.RequireRole("admin"), unblockingHandler.CreateUnblocking)
@@ -225,10 +313,79 @@ func setupRouter(authHandler *handlers.AuthHandler, userHandler *handlers.UserHa
 
 				// Admin only
 				scheduleReguler.POST("/v1", middleware.RequireRole("admin"), scheduleHandler.CreateScheduleReguler)
+				scheduleReguler.POST("/v1/import", middleware.RequireRole("admin"), scheduleHandler.ImportScheduleReguler)
 				scheduleReguler.PUT("/v1/:id", middleware.RequireRole("admin"), middleware.CheckUnblockStateReverseTechnique(), scheduleHandler.UpdateScheduleReguler)
 				scheduleReguler.DELETE("/v1/:id", middleware.RequireRole("admin"), middleware.CheckUnblockStateReverseTechnique(), scheduleHandler.DeleteScheduleReguler)
 			}
 
+			// Equipment loan endpoints
+			loans := protected.Group("/loans")
+			{
+				// Users request and follow their own loans
+				loans.GET("/v1/availability", middleware.RequireRole("admin", "user"), loanHandler.CheckAvailability)
+				loans.GET("/v1/user/:user_id", middleware.RequireRole("admin", "user"), loanHandler.GetLoansByUserID)
+				loans.GET("/v1/:id", middleware.RequireRole("admin", "user"), loanHandler.GetLoanByID)
+				loans.POST("/v1", middleware.RequireRole("admin", "user"), loanHandler.CreateLoan)
+
+				// Admin only
+				loans.GET("/v1", middleware.RequireRole("admin"), loanHandler.GetAllLoans)
+				loans.PATCH("/v1/:id/approve", middleware.RequireRole("admin"), loanHandler.ApproveLoan)
+				loans.PATCH("/v1/:id/reject", middleware.RequireRole("admin"), loanHandler.RejectLoan)
+				loans.POST("/v1/:id/checkout", middleware.RequireRole("admin"), loanHandler.CheckOutLoan)
+				loans.POST("/v1/:id/checkin", middleware.RequireRole("admin"), loanHandler.CheckInLoan)
+			}
+
+			// Full-text search across tickets, items and schedules
+			search := protected.Group("/search")
+			{
+				search.GET("/v1", middleware.RequireRole("admin", "user"), searchHandler.Search)
+			}
+
+			// Notification endpoints, always for the authenticated user
+			notifications := protected.Group("/notifications")
+			{
+				notifications.GET("/v1", middleware.RequireRole("admin", "user"), notificationHandler.GetMyNotifications)
+				notifications.PATCH("/v1/read-all", middleware.RequireRole("admin", "user"), notificationHandler.MarkAllRead)
+				notifications.PATCH("/v1/:id/read", middleware.RequireRole("admin", "user"), notificationHandler.MarkRead)
+				notifications.GET("/v1/preferences", middleware.RequireRole("admin", "user"), notificationHandler.GetPreferences)
+				notifications.PUT("/v1/preferences", middleware.RequireRole("admin", "user"), notificationHandler.UpdatePreferences)
+
+				// Admin only
+				notifications.GET("/v1/templates/:event/preview", middleware.RequireRole("admin"), notificationHandler.PreviewTemplate)
+			}
+
+			// Message queue administration (admin only)
+			queueAdmin := protected.Group("/queue")
+			{
+				queueAdmin.GET("/v1/dead-letters", middleware.RequireRole("admin"), queueHandler.ListDeadLetters)
+				queueAdmin.POST("/v1/dead-letters/replay", middleware.RequireRole("admin"), queueHandler.ReplayDeadLetters)
+			}
+
+			// Webhook subscriptions and their delivery log (admin only)
+			webhooks := protected.Group("/webhooks")
+			{
+				webhooks.GET("/v1", middleware.RequireRole("admin"), webhookHandler.GetAllWebhooks)
+				webhooks.GET("/v1/:id", middleware.RequireRole("admin"), webhookHandler.GetWebhookByID)
+				webhooks.POST("/v1", middleware.RequireRole("admin"), webhookHandler.CreateWebhook)
+				webhooks.PUT("/v1/:id", middleware.RequireRole("admin"), webhookHandler.UpdateWebhook)
+				webhooks.DELETE("/v1/:id", middleware.RequireRole("admin"), webhookHandler.DeleteWebhook)
+				webhooks.GET("/v1/:id/deliveries", middleware.RequireRole("admin"), webhookHandler.GetWebhookDeliveries)
+				webhooks.POST("/v1/:id/deliveries/:delivery_id/redeliver", middleware.RequireRole("admin"), webhookHandler.RedeliverWebhook)
+			}
+
+			// Room endpoints
+			rooms := protected.Group("/rooms")
+			{
+				// All authenticated users can view, admin can manage
+				rooms.GET("/v1", middleware.RequireRole("admin", "user"), roomHandler.GetAllRooms)
+				rooms.GET("/v1/:id", middleware.RequireRole("admin", "user"), roomHandler.GetRoomByID)
+
+				// Admin only
+				rooms.POST("/v1", middleware.RequireRole("admin"), roomHandler.CreateRoom)
+				rooms.PUT("/v1/:id", middleware.RequireRole("admin"), roomHandler.UpdateRoom)
+				rooms.DELETE("/v1/:id", middleware.RequireRole("admin"), roomHandler.DeleteRoom)
+			}
+
 			// Schedule Ticket endpoints
 			scheduleTicket := protected.Group("/schedules/tickets")
 			{
@@ -251,8 +408,39 @@ func setupRouter(authHandler *handlers.AuthHandler, userHandler *handlers.UserHa
 				audit.GET("/tickets/:ticket_id/logs", middleware.RequireRole("admin", "user"), auditHandler.GetTicketEventLogs)
 				audit.GET("/users/:user_id/logs", middleware.RequireRole("admin"), auditHandler.GetEventLogsByUser)
 			}
+
+			// Calendar feed token endpoints
+			calendar := protected.Group("/calendar")
+			{
+				calendar.POST("/v1/token", middleware.RequireRole("admin", "user"), calendarHandler.CreateFeedToken)
+				calendar.DELETE("/v1/token", middleware.RequireRole("admin", "user"), calendarHandler.RevokeFeedToken)
+			}
 		}
 	}
 
 	return router
 }
+
+// setupNotifier registers the notification channels. With NOTIFY_LOG_ONLY
+// every channel is replaced by a log sink so nothing leaves the machine.
+func setupNotifier(cfg *config.Config, db *gorm.DB) *notify.Dispatcher {
+	notify.SetTemplateDir(cfg.Notify.TemplateDir)
+
+	if cfg.Notify.LogOnly {
+		return notify.NewDispatcher(nil,
+			notify.NewLogNotifier(models.ChannelEmail),
+			notify.NewLogNotifier(models.ChannelWebhook),
+			notify.NewLogNotifier(models.ChannelInApp),
+		)
+	}
+
+	dispatcher := notify.NewDispatcher(notify.NewPreferenceStore(db), notify.NewInAppNotifier(db))
+	if cfg.SMTPGmail.Email != "" {
+		smtpAuth := smtp.PlainAuth("", cfg.SMTPGmail.Email, cfg.SMTPGmail.Password, cfg.SMTPGmail.Host)
+		dispatcher.Register(notify.NewSMTPNotifier(smtpAuth, cfg.SMTPGmail.Host, cfg.SMTPGmail.Port, cfg.SMTPGmail.Email))
+	}
+	if cfg.Notify.WebhookURL != "" {
+		dispatcher.Register(notify.NewWebhookNotifier(cfg.Notify.WebhookURL))
+	}
+	return dispatcher
+}
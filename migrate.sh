@@ -33,4 +33,52 @@ psql -h postgres -U user -d mydb < /migrations/000007_adding_reason_in_ticket.up
 
 echo "Running migration 000008_create_ticket_event_log.up.sql..."
 psql -h postgres -U user -d mydb < /migrations/000008_create_ticket_event_log.up.sql
+
+echo "Running migration 000009_add_schedule_ticket_overlap_guard.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000009_add_schedule_ticket_overlap_guard.up.sql
+
+echo "Running migration 000010_add_recurrence_to_schedule_reguler.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000010_add_recurrence_to_schedule_reguler.up.sql
+
+echo "Running migration 000011_create_calendar_feed_tokens.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000011_create_calendar_feed_tokens.up.sql
+
+echo "Running migration 000012_create_rooms.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000012_create_rooms.up.sql
+
+echo "Running migration 000013_create_item_loans.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000013_create_item_loans.up.sql
+
+echo "Running migration 000014_add_item_kondisi_lifecycle.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000014_add_item_kondisi_lifecycle.up.sql
+
+echo "Running migration 000015_add_list_indexes.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000015_add_list_indexes.up.sql
+
+echo "Running migration 000016_add_full_text_search.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000016_add_full_text_search.up.sql
+
+echo "Running migration 000017_create_notifications.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000017_create_notifications.up.sql
+
+echo "Running migration 000018_add_locale_to_users.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000018_add_locale_to_users.up.sql
+
+echo "Running migration 000019_create_outbox.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000019_create_outbox.up.sql
+
+echo "Running migration 000020_create_processed_messages.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000020_create_processed_messages.up.sql
+
+echo "Running migration 000021_create_booking_requests.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000021_create_booking_requests.up.sql
+
+echo "Running migration 000022_create_webhooks.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000022_create_webhooks.up.sql
+
+echo "Running migration 000023_add_unblocking_scope.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000023_add_unblocking_scope.up.sql
+
+echo "Running migration 000024_add_booking_unpublished_status.up.sql..."
+psql -h postgres -U user -d mydb < /migrations/000024_add_booking_unpublished_status.up.sql
 echo "All migrations completed successfully!"
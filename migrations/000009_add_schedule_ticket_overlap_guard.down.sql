@@ -0,0 +1,18 @@
+-- ================================================
+-- Rollback: Remove overlap guard from schedule_ticket
+-- ================================================
+
+DROP TRIGGER IF EXISTS trigger_sync_schedule_ticket_booked ON tickets;
+DROP FUNCTION IF EXISTS sync_schedule_ticket_booked();
+
+ALTER TABLE schedule_ticket
+DROP CONSTRAINT IF EXISTS excl_schedule_ticket_booked_overlap;
+
+ALTER TABLE schedule_ticket
+DROP CONSTRAINT IF EXISTS chk_schedule_ticket_date_range;
+
+ALTER TABLE schedule_ticket
+DROP COLUMN IF EXISTS unbooked_reason;
+
+ALTER TABLE schedule_ticket
+DROP COLUMN IF EXISTS is_booked;
@@ -0,0 +1,106 @@
+-- ================================================
+-- Migration: Prevent overlapping lab bookings
+-- Adds a booked flag to schedule_ticket (kept in sync with the linked
+-- ticket status) and an exclusion constraint so two booked schedules
+-- can never share the same time slot.
+--
+-- Existing data may already hold overlapping bookings, which would make the
+-- constraint fail. Before adding it, booked schedules are walked in creation
+-- order (id_schedule) and every one overlapping an earlier booked schedule
+-- is un-booked, with the reason in unbooked_reason. Booked schedules whose
+-- end is not after their start are un-booked the same way. Their tickets
+-- keep their status; admins list what needs attention with:
+--
+--   SELECT id_schedule, title, start_date, end_date, unbooked_reason
+--   FROM schedule_ticket WHERE unbooked_reason IS NOT NULL ORDER BY id_schedule;
+-- PostgreSQL
+-- ================================================
+
+-- A schedule is booked when it has no pending/rejected ticket attached,
+-- i.e. it was entered directly by an admin or its ticket was accepted
+ALTER TABLE schedule_ticket
+ADD COLUMN IF NOT EXISTS is_booked BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE schedule_ticket s
+SET is_booked = NOT EXISTS (
+    SELECT 1 FROM tickets t
+    WHERE t.id_schedule = s.id_schedule
+      AND t.status <> 'accepted'
+);
+
+-- Why the migration took the slot from a schedule, NULL when it did not
+ALTER TABLE schedule_ticket
+ADD COLUMN IF NOT EXISTS unbooked_reason TEXT;
+
+UPDATE schedule_ticket
+SET is_booked = FALSE,
+    unbooked_reason = 'end_date is not after start_date'
+WHERE is_booked
+  AND end_date <= start_date;
+
+-- The first booked schedule keeps a slot; later overlapping ones give it up
+DO $$
+DECLARE
+    booking RECORD;
+    kept INTEGER;
+BEGIN
+    FOR booking IN
+        SELECT id_schedule, start_date, end_date
+        FROM schedule_ticket
+        WHERE is_booked
+        ORDER BY id_schedule
+    LOOP
+        SELECT k.id_schedule INTO kept
+        FROM schedule_ticket k
+        WHERE k.is_booked
+          AND k.id_schedule < booking.id_schedule
+          AND k.start_date < booking.end_date
+          AND k.end_date > booking.start_date
+        ORDER BY k.id_schedule
+        LIMIT 1;
+
+        IF kept IS NOT NULL THEN
+            UPDATE schedule_ticket
+            SET is_booked = FALSE,
+                unbooked_reason = 'overlaps schedule ' || kept
+            WHERE id_schedule = booking.id_schedule;
+        END IF;
+    END LOOP;
+END $$;
+
+-- Reject empty or inverted ranges. Existing rows are not checked; the
+-- booked ones among them were un-booked above.
+ALTER TABLE schedule_ticket
+ADD CONSTRAINT chk_schedule_ticket_date_range
+CHECK (end_date > start_date) NOT VALID;
+
+-- No two booked schedules may overlap. Ranges are half-open so a booking
+-- ending at 10:00 does not clash with one starting at 10:00. The check is
+-- deferred to commit so the application can report conflicts itself first.
+ALTER TABLE schedule_ticket
+ADD CONSTRAINT excl_schedule_ticket_booked_overlap
+EXCLUDE USING gist (tsrange(start_date, end_date, '[)') WITH &&)
+WHERE (is_booked)
+DEFERRABLE INITIALLY DEFERRED;
+
+-- Keep is_booked in sync with the status of the linked ticket
+CREATE OR REPLACE FUNCTION sync_schedule_ticket_booked()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.id_schedule IS NOT NULL THEN
+        UPDATE schedule_ticket
+        SET is_booked = (NEW.status = 'accepted')
+        WHERE id_schedule = NEW.id_schedule
+          AND is_booked IS DISTINCT FROM (NEW.status = 'accepted');
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_sync_schedule_ticket_booked
+    AFTER INSERT OR UPDATE OF status, id_schedule ON tickets
+    FOR EACH ROW
+    EXECUTE FUNCTION sync_schedule_ticket_booked();
+
+COMMENT ON COLUMN schedule_ticket.is_booked IS 'TRUE when the schedule holds the slot (admin entry or accepted ticket). Only booked schedules take part in the overlap constraint.';
+COMMENT ON COLUMN schedule_ticket.unbooked_reason IS 'Set when the overlap guard migration un-booked the schedule because it clashed with an earlier booking or had an inverted range';
@@ -0,0 +1,11 @@
+-- ================================================
+-- Rollback: Remove recurrence from schedule_reguler
+-- ================================================
+
+DROP INDEX IF EXISTS idx_schedule_reguler_tahun_semester;
+
+ALTER TABLE schedule_reguler
+DROP COLUMN IF EXISTS rrule,
+DROP COLUMN IF EXISTS exdates,
+DROP COLUMN IF EXISTS tahun,
+DROP COLUMN IF EXISTS semester;
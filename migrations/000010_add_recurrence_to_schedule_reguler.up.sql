@@ -0,0 +1,21 @@
+-- ================================================
+-- Migration: Recurring weekly regular schedules
+-- start_date/end_date describe the first occurrence; rrule (RFC 5545
+-- subset: FREQ=WEEKLY, INTERVAL, BYDAY, UNTIL, COUNT) repeats it and
+-- exdates lists cancelled occurrences. Open-ended rules are bounded by the
+-- unblocking window of the given tahun/semester.
+-- PostgreSQL
+-- ================================================
+
+ALTER TABLE schedule_reguler
+ADD COLUMN IF NOT EXISTS rrule TEXT,
+ADD COLUMN IF NOT EXISTS exdates JSONB,
+ADD COLUMN IF NOT EXISTS tahun INTEGER,
+ADD COLUMN IF NOT EXISTS semester semester_category;
+
+CREATE INDEX IF NOT EXISTS idx_schedule_reguler_tahun_semester ON schedule_reguler(tahun, semester);
+
+COMMENT ON COLUMN schedule_reguler.rrule IS 'Weekly recurrence rule (RFC 5545 subset). NULL for a one-off schedule.';
+COMMENT ON COLUMN schedule_reguler.exdates IS 'JSON array of occurrence dates that are skipped';
+COMMENT ON COLUMN schedule_reguler.tahun IS 'Academic year the series belongs to';
+COMMENT ON COLUMN schedule_reguler.semester IS 'Semester (Ganjil/Genap) the series belongs to';
@@ -0,0 +1,7 @@
+-- ================================================
+-- Rollback: Drop calendar_feed_tokens table
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_calendar_feed_tokens_active_user;
+DROP TABLE IF EXISTS calendar_feed_tokens;
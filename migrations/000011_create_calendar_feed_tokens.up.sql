@@ -0,0 +1,23 @@
+-- ================================================
+-- Migration: Create calendar_feed_tokens table for iCalendar feeds
+-- PostgreSQL
+-- ================================================
+
+-- Calendar clients cannot send a Bearer JWT, so feeds are authenticated by a
+-- per-user token embedded in the feed URL. Only the SHA-256 hash is stored.
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+-- At most one active token per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feed_tokens_active_user
+    ON calendar_feed_tokens(user_id)
+    WHERE revoked_at IS NULL;
+
+COMMENT ON TABLE calendar_feed_tokens IS 'Revocable tokens for per-user and lab iCalendar feeds';
+COMMENT ON COLUMN calendar_feed_tokens.token_hash IS 'SHA-256 hex digest of the feed token';
@@ -0,0 +1,24 @@
+-- ================================================
+-- Rollback: Remove multi-room support
+-- ================================================
+
+ALTER TABLE schedule_ticket
+DROP CONSTRAINT IF EXISTS excl_schedule_ticket_booked_overlap;
+
+-- Restores the single-lab constraint; fails if bookings in different rooms overlap
+ALTER TABLE schedule_ticket
+ADD CONSTRAINT excl_schedule_ticket_booked_overlap
+EXCLUDE USING gist (tsrange(start_date, end_date, '[)') WITH &&)
+WHERE (is_booked)
+DEFERRABLE INITIALLY DEFERRED;
+
+DROP INDEX IF EXISTS idx_tickets_room_id;
+DROP INDEX IF EXISTS idx_schedule_reguler_room_id;
+DROP INDEX IF EXISTS idx_schedule_ticket_room_id;
+
+ALTER TABLE tickets DROP COLUMN IF EXISTS room_id;
+ALTER TABLE schedule_reguler DROP COLUMN IF EXISTS room_id;
+ALTER TABLE schedule_ticket DROP COLUMN IF EXISTS room_id;
+
+DROP TRIGGER IF EXISTS update_rooms_updated_at ON rooms;
+DROP TABLE IF EXISTS rooms;
@@ -0,0 +1,72 @@
+-- ================================================
+-- Migration: Multi-room support
+-- Adds a rooms table and a room_id to schedule_ticket, schedule_reguler
+-- and tickets. Existing rows are assigned to a default room, and the
+-- overlap constraint on booked schedules becomes per room.
+-- PostgreSQL
+-- ================================================
+
+-- Needed to combine "room_id WITH =" and a range in one GiST exclusion constraint
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS rooms (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    location VARCHAR(255),
+    capacity INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0),
+    opens_at TIME,
+    closes_at TIME,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_rooms_opening_hours CHECK (
+        (opens_at IS NULL AND closes_at IS NULL)
+        OR (opens_at IS NOT NULL AND closes_at IS NOT NULL AND closes_at > opens_at)
+    )
+);
+
+CREATE TRIGGER update_rooms_updated_at
+    BEFORE UPDATE ON rooms
+    FOR EACH ROW
+    EXECUTE FUNCTION update_schedule_updated_at_column();
+
+-- Everything booked so far happened in the single existing lab
+INSERT INTO rooms (name, location)
+SELECT 'Lab 1', 'Default lab created by migration'
+WHERE NOT EXISTS (SELECT 1 FROM rooms);
+
+ALTER TABLE schedule_ticket ADD COLUMN IF NOT EXISTS room_id INTEGER REFERENCES rooms(id) ON DELETE RESTRICT;
+ALTER TABLE schedule_reguler ADD COLUMN IF NOT EXISTS room_id INTEGER REFERENCES rooms(id) ON DELETE RESTRICT;
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS room_id INTEGER REFERENCES rooms(id) ON DELETE RESTRICT;
+
+UPDATE schedule_ticket SET room_id = (SELECT MIN(id) FROM rooms) WHERE room_id IS NULL;
+UPDATE schedule_reguler SET room_id = (SELECT MIN(id) FROM rooms) WHERE room_id IS NULL;
+UPDATE tickets t
+SET room_id = COALESCE(
+    (SELECT s.room_id FROM schedule_ticket s WHERE s.id_schedule = t.id_schedule),
+    (SELECT MIN(id) FROM rooms)
+)
+WHERE room_id IS NULL;
+
+-- Schedules always occupy a room. Tickets may not (e.g. equipment requests).
+ALTER TABLE schedule_ticket ALTER COLUMN room_id SET NOT NULL;
+ALTER TABLE schedule_reguler ALTER COLUMN room_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_schedule_ticket_room_id ON schedule_ticket(room_id);
+CREATE INDEX IF NOT EXISTS idx_schedule_reguler_room_id ON schedule_reguler(room_id);
+CREATE INDEX IF NOT EXISTS idx_tickets_room_id ON tickets(room_id);
+
+-- Booked schedules may only overlap when they are in different rooms
+ALTER TABLE schedule_ticket
+DROP CONSTRAINT IF EXISTS excl_schedule_ticket_booked_overlap;
+
+ALTER TABLE schedule_ticket
+ADD CONSTRAINT excl_schedule_ticket_booked_overlap
+EXCLUDE USING gist (room_id WITH =, tsrange(start_date, end_date, '[)') WITH &&)
+WHERE (is_booked)
+DEFERRABLE INITIALLY DEFERRED;
+
+COMMENT ON TABLE rooms IS 'Labs and rooms that can be booked';
+COMMENT ON COLUMN rooms.opens_at IS 'Daily opening time; NULL means no restriction';
+COMMENT ON COLUMN rooms.closes_at IS 'Daily closing time; NULL means no restriction';
+COMMENT ON COLUMN rooms.is_active IS 'Inactive rooms keep their history but cannot be booked';
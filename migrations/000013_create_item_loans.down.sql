@@ -0,0 +1,15 @@
+-- ================================================
+-- Rollback: Drop item_loans tables and related types
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_item_loan_items_item_id;
+DROP INDEX IF EXISTS idx_item_loans_range;
+DROP INDEX IF EXISTS idx_item_loans_status;
+DROP INDEX IF EXISTS idx_item_loans_user_id;
+
+DROP TABLE IF EXISTS item_loan_items;
+DROP TRIGGER IF EXISTS set_item_loans_updated_at ON item_loans;
+DROP TABLE IF EXISTS item_loans;
+
+DROP TYPE IF EXISTS loan_status;
@@ -0,0 +1,61 @@
+-- ================================================
+-- Migration: Create item_loans and item_loan_items tables
+-- Equipment loans go through the ticket approval flow: every loan owns a
+-- ticket, and accepting or rejecting that ticket approves or rejects the loan.
+-- PostgreSQL
+-- ================================================
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'loan_status') THEN
+        CREATE TYPE loan_status AS ENUM (
+            'requested',
+            'approved',
+            'rejected',
+            'checked_out',
+            'returned'
+        );
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS item_loans (
+    id SERIAL PRIMARY KEY,
+    ticket_id INT NOT NULL UNIQUE REFERENCES tickets(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NOT NULL,
+    purpose TEXT NOT NULL,
+    status loan_status NOT NULL DEFAULT 'requested',
+    is_overdue BOOLEAN NOT NULL DEFAULT FALSE,
+    checked_out_at TIMESTAMPTZ,
+    checked_out_by INT REFERENCES users(id) ON DELETE SET NULL,
+    returned_at TIMESTAMPTZ,
+    returned_by INT REFERENCES users(id) ON DELETE SET NULL,
+    return_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_item_loans_range CHECK (end_date > start_date)
+);
+
+CREATE TABLE IF NOT EXISTS item_loan_items (
+    loan_id INT NOT NULL REFERENCES item_loans(id) ON DELETE CASCADE,
+    item_id INT NOT NULL REFERENCES items(id) ON DELETE RESTRICT,
+    return_kondisi VARCHAR(100),
+    return_note TEXT,
+    PRIMARY KEY (loan_id, item_id)
+);
+
+CREATE TRIGGER set_item_loans_updated_at
+BEFORE UPDATE ON item_loans
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX IF NOT EXISTS idx_item_loans_user_id ON item_loans(user_id);
+CREATE INDEX IF NOT EXISTS idx_item_loans_status ON item_loans(status);
+CREATE INDEX IF NOT EXISTS idx_item_loans_range ON item_loans(start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_item_loan_items_item_id ON item_loan_items(item_id);
+
+COMMENT ON TABLE item_loans IS 'Equipment loan requests and their check-out/check-in lifecycle';
+COMMENT ON COLUMN item_loans.ticket_id IS 'Ticket used to approve or reject the loan';
+COMMENT ON COLUMN item_loans.is_overdue IS 'Set by the scheduler when a checked out loan passes its end date';
+COMMENT ON TABLE item_loan_items IS 'Items lent in a loan and the condition they were returned in';
@@ -0,0 +1,19 @@
+-- ================================================
+-- Rollback: Item kondisi lifecycle and history
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_items_kondisi;
+
+ALTER TABLE item_loan_items
+    ALTER COLUMN return_kondisi TYPE VARCHAR(100) USING return_kondisi::TEXT;
+
+ALTER TABLE items
+    ALTER COLUMN kondisi DROP NOT NULL,
+    ALTER COLUMN kondisi DROP DEFAULT,
+    ALTER COLUMN kondisi TYPE VARCHAR(100) USING kondisi::TEXT;
+
+DROP INDEX IF EXISTS idx_item_kondisi_history_item_id;
+DROP TABLE IF EXISTS item_kondisi_history;
+
+DROP TYPE IF EXISTS item_kondisi;
@@ -0,0 +1,74 @@
+-- ================================================
+-- Migration: Item kondisi lifecycle and history
+-- Turns items.kondisi into a controlled enum and records every change in
+-- item_kondisi_history. Free-form values are mapped onto the enum; values
+-- that don't match become 'Baik' and the original text is kept in the
+-- first history entry.
+-- PostgreSQL
+-- ================================================
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'item_kondisi') THEN
+        CREATE TYPE item_kondisi AS ENUM (
+            'Baik',
+            'Rusak Ringan',
+            'Rusak Berat',
+            'Dalam Perbaikan',
+            'Dihapuskan'
+        );
+    END IF;
+END $$;
+
+CREATE FUNCTION pg_temp.normalize_kondisi(value TEXT)
+RETURNS item_kondisi AS $$
+    SELECT CASE lower(btrim(coalesce(value, '')))
+        WHEN 'rusak ringan' THEN 'Rusak Ringan'::item_kondisi
+        WHEN 'rusak berat' THEN 'Rusak Berat'::item_kondisi
+        WHEN 'dalam perbaikan' THEN 'Dalam Perbaikan'::item_kondisi
+        WHEN 'dihapuskan' THEN 'Dihapuskan'::item_kondisi
+        ELSE 'Baik'::item_kondisi
+    END;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE TABLE IF NOT EXISTS item_kondisi_history (
+    id SERIAL PRIMARY KEY,
+    item_id INT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    from_kondisi item_kondisi,
+    to_kondisi item_kondisi NOT NULL,
+    changed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    loan_id INT REFERENCES item_loans(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_kondisi_history_item_id ON item_kondisi_history(item_id, created_at);
+
+-- Start every timeline with the kondisi the item had before the migration
+INSERT INTO item_kondisi_history (item_id, from_kondisi, to_kondisi, reason, created_at)
+SELECT
+    id,
+    NULL,
+    pg_temp.normalize_kondisi(kondisi),
+    CASE
+        WHEN kondisi IS NULL OR btrim(kondisi) = '' THEN 'Kondisi not recorded before migration'
+        WHEN pg_temp.normalize_kondisi(kondisi)::TEXT = btrim(kondisi) THEN 'Kondisi before migration'
+        ELSE 'Migrated from free-form kondisi: ' || kondisi
+    END,
+    COALESCE(updated_at, created_at, NOW())
+FROM items;
+
+ALTER TABLE items
+    ALTER COLUMN kondisi TYPE item_kondisi USING pg_temp.normalize_kondisi(kondisi),
+    ALTER COLUMN kondisi SET DEFAULT 'Baik',
+    ALTER COLUMN kondisi SET NOT NULL;
+
+ALTER TABLE item_loan_items
+    ALTER COLUMN return_kondisi TYPE item_kondisi
+    USING CASE WHEN return_kondisi IS NULL THEN NULL ELSE pg_temp.normalize_kondisi(return_kondisi) END;
+
+CREATE INDEX IF NOT EXISTS idx_items_kondisi ON items(kondisi);
+
+COMMENT ON TABLE item_kondisi_history IS 'Timeline of item kondisi transitions';
+COMMENT ON COLUMN item_kondisi_history.from_kondisi IS 'NULL for the first entry of an item';
+COMMENT ON COLUMN item_kondisi_history.loan_id IS 'Loan whose check-in caused the change, if any';
@@ -0,0 +1,11 @@
+-- ================================================
+-- Rollback: Indexes for paginated list endpoints
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_users_role;
+DROP INDEX IF EXISTS idx_item_loans_start_date;
+DROP INDEX IF EXISTS idx_ticket_event_log_user_id_id;
+DROP INDEX IF EXISTS idx_ticket_event_log_ticket_id_id;
+DROP INDEX IF EXISTS idx_tickets_user_id_id;
+DROP INDEX IF EXISTS idx_tickets_status_id;
@@ -0,0 +1,13 @@
+-- ================================================
+-- Migration: Indexes for paginated list endpoints
+-- Lists are filtered by status or owner and paged newest first, so these
+-- indexes let PostgreSQL read a single page instead of sorting whole tables.
+-- PostgreSQL
+-- ================================================
+
+CREATE INDEX IF NOT EXISTS idx_tickets_status_id ON tickets(status, id DESC);
+CREATE INDEX IF NOT EXISTS idx_tickets_user_id_id ON tickets(user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_ticket_event_log_ticket_id_id ON ticket_event_log(ticket_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_ticket_event_log_user_id_id ON ticket_event_log(user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_item_loans_start_date ON item_loans(start_date DESC);
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
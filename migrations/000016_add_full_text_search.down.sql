@@ -0,0 +1,18 @@
+-- ================================================
+-- Rollback: Full-text search
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_schedule_reguler_search;
+DROP INDEX IF EXISTS idx_schedule_ticket_search;
+DROP INDEX IF EXISTS idx_items_category_search;
+DROP INDEX IF EXISTS idx_items_search;
+DROP INDEX IF EXISTS idx_tickets_search;
+
+ALTER TABLE schedule_reguler DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE schedule_ticket DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE items_category DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE items DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE tickets DROP COLUMN IF EXISTS search_vector;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS ketuk;
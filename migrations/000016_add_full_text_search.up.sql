@@ -0,0 +1,59 @@
+-- ================================================
+-- Migration: Full-text search
+-- Adds generated tsvector columns with GIN indexes to tickets, items,
+-- item categories and schedules. The ketuk text search configuration uses
+-- the Indonesian Snowball stemmer and ignores accents.
+-- PostgreSQL
+-- ================================================
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'ketuk') THEN
+        IF EXISTS (SELECT 1 FROM pg_ts_dict WHERE dictname = 'indonesian_stem') THEN
+            CREATE TEXT SEARCH CONFIGURATION ketuk (COPY = indonesian);
+            ALTER TEXT SEARCH CONFIGURATION ketuk
+                ALTER MAPPING FOR hword, hword_part, word WITH unaccent, indonesian_stem;
+        ELSE
+            CREATE TEXT SEARCH CONFIGURATION ketuk (COPY = simple);
+            ALTER TEXT SEARCH CONFIGURATION ketuk
+                ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+        END IF;
+    END IF;
+END $$;
+
+ALTER TABLE tickets
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE items
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(note, '')), 'C')
+    ) STORED;
+
+ALTER TABLE items_category
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(category_name, '')), 'B') ||
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(specification, '')), 'C')
+    ) STORED;
+
+ALTER TABLE schedule_ticket
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE schedule_reguler
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('ketuk'::regconfig, COALESCE(title, '')), 'A')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_tickets_search ON tickets USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_items_search ON items USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_items_category_search ON items_category USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_schedule_ticket_search ON schedule_ticket USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_schedule_reguler_search ON schedule_reguler USING GIN (search_vector);
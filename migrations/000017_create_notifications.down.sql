@@ -0,0 +1,9 @@
+-- ================================================
+-- Rollback: Drop notifications and notification_preferences tables
+-- PostgreSQL
+-- ================================================
+
+DROP TABLE IF EXISTS notification_preferences;
+DROP INDEX IF EXISTS idx_notifications_user_unread;
+DROP INDEX IF EXISTS idx_notifications_user_created;
+DROP TABLE IF EXISTS notifications;
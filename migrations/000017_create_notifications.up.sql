@@ -0,0 +1,34 @@
+-- ================================================
+-- Migration: Create notifications and notification_preferences tables
+-- notifications holds the in-app channel; notification_preferences lets a
+-- user switch a channel off for an event type. Without a row every channel
+-- routed for the event is enabled.
+-- PostgreSQL
+-- ================================================
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event VARCHAR(100) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    data JSONB,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event VARCHAR(100) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, event, channel),
+    CONSTRAINT chk_notification_preferences_channel CHECK (channel IN ('email', 'webhook', 'in_app'))
+);
+
+COMMENT ON TABLE notifications IS 'In-app notifications shown to users';
+COMMENT ON TABLE notification_preferences IS 'Per-user opt-outs of notification channels by event type';
@@ -0,0 +1,7 @@
+-- ================================================
+-- Rollback: Remove locale from users
+-- PostgreSQL
+-- ================================================
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_locale;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
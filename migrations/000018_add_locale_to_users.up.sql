@@ -0,0 +1,12 @@
+-- ================================================
+-- Migration: Add locale to users
+-- Emails are sent in Indonesian or English depending on the user's locale.
+-- PostgreSQL
+-- ================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(5) NOT NULL DEFAULT 'id';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_locale;
+ALTER TABLE users ADD CONSTRAINT chk_users_locale CHECK (locale IN ('id', 'en'));
+
+COMMENT ON COLUMN users.locale IS 'Language of notifications: id (Indonesian) or en (English)';
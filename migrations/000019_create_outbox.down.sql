@@ -0,0 +1,8 @@
+-- ================================================
+-- Rollback: Drop outbox table
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+DROP TABLE IF EXISTS outbox;
+DROP TYPE IF EXISTS outbox_status;
@@ -0,0 +1,38 @@
+-- ================================================
+-- Migration: Create outbox table
+-- Side effects of a transaction (notifications) are written here in the same
+-- transaction and delivered afterwards by the outbox relay, so a crash or a
+-- failing channel cannot lose them or leave half-created bookings behind.
+-- PostgreSQL
+-- ================================================
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'outbox_status') THEN
+        CREATE TYPE outbox_status AS ENUM (
+            'pending',
+            'delivered',
+            'failed'
+        );
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    destination VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status outbox_status NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The relay only ever looks at pending messages that are due
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(next_attempt_at) WHERE status = 'pending';
+
+COMMENT ON TABLE outbox IS 'Transactional outbox of side effects delivered by the relay';
+COMMENT ON COLUMN outbox.kind IS 'Kind of message, e.g. notification';
+COMMENT ON COLUMN outbox.destination IS 'Where the message goes, e.g. the notification channel';
@@ -0,0 +1,7 @@
+-- ================================================
+-- Rollback: Drop processed messages table
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_processed_messages_created_at;
+DROP TABLE IF EXISTS processed_messages;
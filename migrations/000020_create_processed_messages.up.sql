@@ -0,0 +1,24 @@
+-- ================================================
+-- Migration: Create processed messages table
+-- Records the idempotency key of every processed queue message and API
+-- request in the same transaction as its side effects, so a redelivered
+-- message or a retried request is answered from here instead of booking twice.
+-- PostgreSQL
+-- ================================================
+
+CREATE TABLE IF NOT EXISTS processed_messages (
+    scope VARCHAR(100) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scope, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_processed_messages_created_at ON processed_messages(created_at);
+
+COMMENT ON TABLE processed_messages IS 'Idempotency keys of processed queue messages and API requests';
+COMMENT ON COLUMN processed_messages.scope IS 'Where the key was used, e.g. queue:schedule or api:tickets:<user id>';
+COMMENT ON COLUMN processed_messages.request_hash IS 'SHA-256 of the request, to detect a key reused for a different request';
+COMMENT ON COLUMN processed_messages.resource_id IS 'ID of the record the request created';
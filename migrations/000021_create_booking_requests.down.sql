@@ -0,0 +1,9 @@
+-- ================================================
+-- Rollback: Drop booking requests table
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_booking_requests_idempotency_key;
+DROP INDEX IF EXISTS idx_booking_requests_user_id;
+DROP TABLE IF EXISTS booking_requests;
+DROP TYPE IF EXISTS booking_status;
@@ -0,0 +1,44 @@
+-- ================================================
+-- Migration: Create booking requests table
+-- Bookings submitted over HTTP are recorded here and published to the
+-- schedule queue; the worker reports back whether the request was processed
+-- or rejected and which ticket it created.
+-- PostgreSQL
+-- ================================================
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'booking_status') THEN
+        CREATE TYPE booking_status AS ENUM (
+            'queued',
+            'processed',
+            'rejected'
+        );
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS booking_requests (
+    id UUID PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    idempotency_key VARCHAR(255),
+    request_hash CHAR(64) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    kategori ticket_category NOT NULL,
+    room_id INT NOT NULL REFERENCES rooms(id),
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NOT NULL,
+    status booking_status NOT NULL DEFAULT 'queued',
+    ticket_id INT REFERENCES tickets(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_requests_user_id ON booking_requests(user_id);
+-- A retried request with the same key returns the booking of the first one
+CREATE UNIQUE INDEX IF NOT EXISTS idx_booking_requests_idempotency_key ON booking_requests(user_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+
+COMMENT ON TABLE booking_requests IS 'Bookings submitted through the API and their processing status';
+COMMENT ON COLUMN booking_requests.id IS 'Tracking ID, also the message ID on the schedule queue';
+COMMENT ON COLUMN booking_requests.reason IS 'Why the booking was rejected';
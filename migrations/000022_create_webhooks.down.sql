@@ -0,0 +1,10 @@
+-- ================================================
+-- Rollback: Drop webhook subscription and delivery tables
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_webhook_deliveries_pending;
+DROP INDEX IF EXISTS idx_webhook_deliveries_subscription_id;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TYPE IF EXISTS webhook_delivery_status;
@@ -0,0 +1,60 @@
+-- ================================================
+-- Migration: Create webhook subscription and delivery tables
+-- Admins subscribe external URLs to domain events. Every matching event gets
+-- a delivery row in the transaction that caused it; the webhook relay posts
+-- it signed with the subscription secret and records how the receiver
+-- responded.
+-- PostgreSQL
+-- ================================================
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'webhook_delivery_status') THEN
+        CREATE TYPE webhook_delivery_status AS ENUM (
+            'pending',
+            'succeeded',
+            'failed'
+        );
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    description VARCHAR(255),
+    event_types JSONB NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id INT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    response_code INT,
+    response_body TEXT,
+    last_error TEXT,
+    duration_ms INT,
+    redelivery_of BIGINT REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries(subscription_id, id);
+-- The relay only ever looks at pending deliveries that are due
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+COMMENT ON TABLE webhook_subscriptions IS 'External URLs subscribed to domain events';
+COMMENT ON COLUMN webhook_subscriptions.event_types IS 'JSON array of subscribed event types, e.g. ["ticket.created"]';
+COMMENT ON COLUMN webhook_subscriptions.secret IS 'Key of the HMAC-SHA256 signature sent with every delivery';
+COMMENT ON TABLE webhook_deliveries IS 'Log of webhook deliveries and the last response of the receiver';
+COMMENT ON COLUMN webhook_deliveries.redelivery_of IS 'Delivery this one was manually redelivered from';
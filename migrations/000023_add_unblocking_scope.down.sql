@@ -0,0 +1,8 @@
+-- ================================================
+-- Rollback: Remove category and role scope from unblocking windows
+-- PostgreSQL
+-- ================================================
+
+DROP INDEX IF EXISTS idx_unblocking_dates;
+ALTER TABLE unblocking DROP COLUMN IF EXISTS role;
+ALTER TABLE unblocking DROP COLUMN IF EXISTS kategori;
@@ -0,0 +1,19 @@
+-- ================================================
+-- Migration: Scope unblocking windows by category and role
+-- A window without kategori or role applies to every category or role, so
+-- existing windows keep opening booking for everyone. Booking for a request
+-- is open while any window that applies to its category and role is.
+-- PostgreSQL
+-- ================================================
+
+ALTER TABLE unblocking
+ADD COLUMN IF NOT EXISTS kategori ticket_category;
+
+ALTER TABLE unblocking
+ADD COLUMN IF NOT EXISTS role user_role;
+
+-- The scheduler looks up the windows covering the current moment
+CREATE INDEX IF NOT EXISTS idx_unblocking_dates ON unblocking(start_date, end_date);
+
+COMMENT ON COLUMN unblocking.kategori IS 'Booking category the window opens, NULL for every category';
+COMMENT ON COLUMN unblocking.role IS 'Role the window opens booking for, NULL for every role';
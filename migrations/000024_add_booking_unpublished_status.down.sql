@@ -0,0 +1,22 @@
+-- ================================================
+-- Rollback: Remove unpublished booking status
+-- Bookings still waiting to be published are rejected, since nothing will
+-- publish them any more.
+-- PostgreSQL
+-- ================================================
+
+UPDATE booking_requests
+SET status = 'rejected', reason = 'booking could not be queued'
+WHERE status = 'unpublished';
+
+ALTER TABLE booking_requests ALTER COLUMN status DROP DEFAULT;
+ALTER TYPE booking_status RENAME TO booking_status_old;
+CREATE TYPE booking_status AS ENUM (
+    'queued',
+    'processed',
+    'rejected'
+);
+ALTER TABLE booking_requests
+ALTER COLUMN status TYPE booking_status USING status::text::booking_status;
+ALTER TABLE booking_requests ALTER COLUMN status SET DEFAULT 'queued';
+DROP TYPE booking_status_old;
@@ -0,0 +1,9 @@
+-- ================================================
+-- Migration: Add unpublished booking status
+-- A booking whose publish to the schedule queue failed or timed out is kept
+-- as unpublished and published again by the outbox relay, instead of being
+-- removed while the broker may already hold its message.
+-- PostgreSQL
+-- ================================================
+
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'unpublished';